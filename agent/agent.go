@@ -7,16 +7,23 @@ import (
 	"log/slog"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/Mirai3103/Project-Re-ENE/asr"
 	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/live2d"
+	"github.com/Mirai3103/Project-Re-ENE/llm"
+	"github.com/Mirai3103/Project-Re-ENE/metering"
+	"github.com/Mirai3103/Project-Re-ENE/package/lipsync"
 	localTools "github.com/Mirai3103/Project-Re-ENE/package/tools"
 	"github.com/Mirai3103/Project-Re-ENE/package/utils"
 	"github.com/Mirai3103/Project-Re-ENE/store"
+	"github.com/Mirai3103/Project-Re-ENE/telemetry"
 	"github.com/Mirai3103/Project-Re-ENE/tts"
 	"github.com/firebase/genkit/go/ai"
 	"github.com/firebase/genkit/go/core"
 	"github.com/firebase/genkit/go/genkit"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/api/customsearch/v1"
 	"google.golang.org/api/option"
 )
@@ -32,35 +39,97 @@ type FlowInput struct {
 	CharacterFacts []store.CharacterFact
 	User           *store.User
 	Character      *store.Character
+	// OnTextChunk, when set, receives the raw text chunks as they are generated.
+	OnTextChunk func(text string) `json:"-"`
 }
 
 type Agent struct {
-	llmModel          *genkit.Genkit
-	ttsAgent          tts.TTSAgent
-	asrAgent          asr.ASRAgent
-	store             *store.Queries
-	flow              *core.Flow[FlowInput, string, string]
-	agentConfig       *config.AgentConfig
-	logger            *slog.Logger
-	modelArg          ai.ModelArg
+	llm              atomic.Pointer[llmState]
+	providerMu       sync.RWMutex // guards ttsAgent, asrAgent and localTools
+	ttsAgent         tts.TTSAgent
+	asrAgent         asr.ASRAgent
+	store            *store.Queries
+	cfg              *config.Live
+	logger           *slog.Logger
+	embeddingService *EmbeddingService
+	toolConfirmer    ToolConfirmer
+	mcpManager       *MCPManager
+	motionMapper     *live2d.Mapper
+	localTools       []ai.Tool
+}
+
+// llmState is everything built on one genkit instance. It is replaced as a
+// whole when the LLM config changes, a turn keeps the state it started with.
+type llmState struct {
+	models            *llm.Models
+	flow              *core.Flow[FlowInput, string, string] // nil until Compile
 	extractMemoryFlow *ExtractMemoryFlow
 	summaryFlow       *SummaryFlow
-	embeddingService  *EmbeddingService
 }
 
-func NewAgent(llmModel *genkit.Genkit, modelArg ai.ModelArg, embeddingService *EmbeddingService, ttsAgent tts.TTSAgent, asrAgent asr.ASRAgent, store *store.Queries, agentConfig *config.AgentConfig, logger *slog.Logger) *Agent {
-	return &Agent{
-		llmModel:          llmModel,
-		ttsAgent:          ttsAgent,
-		asrAgent:          asrAgent,
-		store:             store,
-		agentConfig:       agentConfig,
-		logger:            logger,
-		modelArg:          modelArg,
-		extractMemoryFlow: NewExtractMemoryFlow(llmModel, modelArg, embeddingService),
-		summaryFlow:       NewGenSummaryFlow(llmModel, modelArg),
-		embeddingService:  embeddingService,
+func NewAgent(models *llm.Models, embeddingService *EmbeddingService, ttsAgent tts.TTSAgent, asrAgent asr.ASRAgent, store *store.Queries, cfg *config.Live, toolConfirmer ToolConfirmer, mcpManager *MCPManager, motionMapper *live2d.Mapper, logger *slog.Logger) *Agent {
+	a := &Agent{
+		ttsAgent:         ttsAgent,
+		asrAgent:         asrAgent,
+		store:            store,
+		cfg:              cfg,
+		logger:           logger,
+		embeddingService: embeddingService,
+		toolConfirmer:    toolConfirmer,
+		mcpManager:       mcpManager,
+		motionMapper:     motionMapper,
 	}
+	a.llm.Store(a.newLLMState(models, false))
+	return a
+}
+
+func (a *Agent) newLLMState(models *llm.Models, withFlow bool) *llmState {
+	state := &llmState{
+		models:            models,
+		extractMemoryFlow: NewExtractMemoryFlow(models.Extraction, a.embeddingService),
+		summaryFlow:       NewGenSummaryFlow(models.Summary),
+	}
+	if withFlow {
+		state.flow = a.defineAgentFlow(models.Chat)
+	}
+	return state
+}
+
+// config is the running agent config, a reload may change it between turns.
+func (a *Agent) config() *config.AgentConfig {
+	return &a.cfg.Load().AgentConfig
+}
+
+// SetLLM switches to another model. g must be a new instance since the
+// flows are registered on it again. Turns already running finish on the old
+// model.
+func (a *Agent) SetLLM(models *llm.Models) {
+	compiled := a.llm.Load().flow != nil
+	a.llm.Store(a.newLLMState(models, compiled))
+}
+
+func (a *Agent) SetTTS(ttsAgent tts.TTSAgent) {
+	a.providerMu.Lock()
+	a.ttsAgent = ttsAgent
+	a.providerMu.Unlock()
+}
+
+func (a *Agent) SetASR(asrAgent asr.ASRAgent) {
+	a.providerMu.Lock()
+	a.asrAgent = asrAgent
+	a.providerMu.Unlock()
+}
+
+func (a *Agent) tts() tts.TTSAgent {
+	a.providerMu.RLock()
+	defer a.providerMu.RUnlock()
+	return a.ttsAgent
+}
+
+func (a *Agent) asr() asr.ASRAgent {
+	a.providerMu.RLock()
+	defer a.providerMu.RUnlock()
+	return a.asrAgent
 }
 
 type GoogleSearchInput struct {
@@ -68,19 +137,19 @@ type GoogleSearchInput struct {
 	Num   int64
 }
 
-func (a *Agent) getTools(ctx context.Context) ([]ai.Tool, error) {
+func (a *Agent) getTools(ctx context.Context, cfg *config.AgentConfig) ([]ai.Tool, error) {
 	var tools []ai.Tool
-	if a.agentConfig.ToolsConfig.GoogleSearch.Enable {
-		searchService, err := customsearch.NewService(ctx, option.WithAPIKey(a.agentConfig.ToolsConfig.GoogleSearch.APIKey))
+	if cfg.ToolsConfig.GoogleSearch.Enable {
+		searchEngineID := cfg.ToolsConfig.GoogleSearch.SearchEngineID
+		searchService, err := customsearch.NewService(ctx, option.WithAPIKey(cfg.ToolsConfig.GoogleSearch.APIKey))
 		if err != nil {
 			goto afterGoogleSearch
 		}
-		googleSearchTool := genkit.DefineTool(
-			a.llmModel,
+		googleSearchTool := ai.NewTool(
 			"googleSearch",
 			"Searches the web for a given query",
 			func(ctx *ai.ToolContext, input GoogleSearchInput) (any, error) {
-				results, err := searchService.Cse.List().Q(input.Query).Num(input.Num).Cx(a.agentConfig.ToolsConfig.GoogleSearch.SearchEngineID).Do()
+				results, err := searchService.Cse.List().Q(input.Query).Num(input.Num).Cx(searchEngineID).Do()
 				if err != nil {
 					return nil, err
 				}
@@ -92,13 +161,12 @@ func (a *Agent) getTools(ctx context.Context) ([]ai.Tool, error) {
 
 afterGoogleSearch:
 
-	if a.agentConfig.ToolsConfig.BrowserHistory.Enable {
-		browserHistoryTool, err := localTools.NewBrowserHistoryTool(a.agentConfig.ToolsConfig.BrowserHistory.ChromeProfilePath)
+	if cfg.ToolsConfig.BrowserHistory.Enable {
+		browserHistoryTool, err := localTools.NewBrowserHistoryTool(cfg.ToolsConfig.BrowserHistory.ChromeProfilePath)
 		if err != nil {
 			goto afterBrowserHistory
 		}
-		browserHistoryToolRef := genkit.DefineTool(
-			a.llmModel,
+		browserHistoryToolRef := ai.NewTool(
 			"browserHistory",
 			"Gets the browser history",
 			func(ctx *ai.ToolContext, input localTools.GetRecentInput) (string, error) {
@@ -116,8 +184,7 @@ afterGoogleSearch:
 			},
 		)
 		tools = append(tools, browserHistoryToolRef)
-		// browserHistoryToolRef = genkit.DefineTool(
-		// 	a.llmModel,
+		// browserHistoryToolRef = ai.NewTool(
 		// 	"browserHistoryByDomain",
 		// 	"Gets the browser history by domain",
 		// 	func(ctx *ai.ToolContext, input localTools.GetByDomainInput) (string, error) {
@@ -135,8 +202,7 @@ afterGoogleSearch:
 		// 	},
 		// )
 		// tools = append(tools, browserHistoryToolRef)
-		browserHistoryToolRef = genkit.DefineTool(
-			a.llmModel,
+		browserHistoryToolRef = ai.NewTool(
 			"browserHistoryByKeyword",
 			"Gets the browser history by keyword",
 			func(ctx *ai.ToolContext, input localTools.GetByKeywordInput) (string, error) {
@@ -156,16 +222,44 @@ afterGoogleSearch:
 		tools = append(tools, browserHistoryToolRef)
 	}
 afterBrowserHistory:
-	mcpTools, err := a.parseMcpTools(ctx)
-	if err == nil {
-		tools = append(tools, mcpTools...)
-	}
 	for _, tool := range tools {
 		a.logger.Info("Registered tool", "name", tool.Name(), "description", tool.Definition().Description)
 	}
 	return tools, nil
 }
 
+// currentTools returns the local tools plus whatever the MCP servers offer
+// right now, wrapped with the permission guard.
+func (a *Agent) currentTools() []ai.ToolRef {
+	a.providerMu.RLock()
+	local := a.localTools
+	a.providerMu.RUnlock()
+	tools := a.guardTools(local, toolSourceLocal)
+	tools = append(tools, a.guardTools(a.mcpManager.Tools(), toolSourceMCP)...)
+	var toolsRefs []ai.ToolRef
+	for _, tool := range tools {
+		toolsRefs = append(toolsRefs, tool)
+	}
+	return toolsRefs
+}
+
+func (a *Agent) setLocalTools(tools []ai.Tool) {
+	a.providerMu.Lock()
+	a.localTools = tools
+	a.providerMu.Unlock()
+}
+
+// ReloadTools rebuilds the local tools from the running agent config and
+// reloads the MCP servers, after a config change touched either.
+func (a *Agent) ReloadTools(ctx context.Context) error {
+	tools, err := a.getTools(ctx, a.config())
+	if err != nil {
+		return err
+	}
+	a.setLocalTools(tools)
+	return a.mcpManager.Reload()
+}
+
 type ContextKey string
 
 const (
@@ -175,17 +269,22 @@ const (
 )
 
 func (a *Agent) Compile(ctx context.Context) error {
-	tools, err := a.getTools(ctx)
+	tools, err := a.getTools(ctx, a.config())
 	if err != nil {
 		return err
 	}
-	var toolsRefs []ai.ToolRef
-	for _, tool := range tools {
-		toolsRefs = append(toolsRefs, tool)
-	}
+	a.setLocalTools(tools)
+	state := *a.llm.Load()
+	a.mcpManager.Start(ctx, state.models.Chat.G)
+	// the other flows are already registered
+	state.flow = a.defineAgentFlow(state.models.Chat)
+	a.llm.Store(&state)
+	return nil
+}
 
-	agentFlow := genkit.DefineStreamingFlow(
-		a.llmModel,
+func (a *Agent) defineAgentFlow(model *llm.Model) *core.Flow[FlowInput, string, string] {
+	return genkit.DefineStreamingFlow(
+		model.G,
 		"agentFlow",
 		func(ctx context.Context, input FlowInput, callback core.StreamCallback[string]) (string, error) {
 			cvs, err := a.store.CreateConversationIfNotExists(ctx, store.CreateConversationParams{
@@ -196,7 +295,7 @@ func (a *Agent) Compile(ctx context.Context) error {
 			if err != nil {
 				return "", err
 			}
-			var windowSize = int64(a.agentConfig.ShortTermMemoryConfig.MaxWindowSize)
+			var windowSize = int64(a.config().ShortTermMemoryConfig.MaxWindowSize)
 			if cvs.MaxWindowSize != nil {
 				windowSize = *cvs.MaxWindowSize
 			}
@@ -213,14 +312,19 @@ func (a *Agent) Compile(ctx context.Context) error {
 			ctx = context.WithValue(ctx, ConversationID, input.ConversationID)
 			ctx = context.WithValue(ctx, CharacterID, input.CharacterID)
 			ctx = context.WithValue(ctx, UserID, input.UserID)
+			// genkit rejects a request with tools to a model without them
+			var tools []ai.ToolRef
+			if model.Capabilities.Tools {
+				tools = a.currentTools()
+			}
 			finalResp, err := genkit.Generate(
 				ctx,
-				a.llmModel,
-				ai.WithModel(a.modelArg),
+				model.G,
+				ai.WithModel(model.Ref),
 				ai.WithSystem(NewPrompt(input.UserFacts, input.CharacterFacts, input.User, input.Character)),
 				ai.WithMessages(historyMessages...),
 				ai.WithPrompt(input.Text),
-				ai.WithTools(toolsRefs...),
+				ai.WithTools(tools...),
 				ai.WithStreaming(func(ctx context.Context, chunk *ai.ModelResponseChunk) error {
 					a.logger.Info("Chunk", "text", chunk.Text())
 					trimmedText := strings.TrimSpace(chunk.Text())
@@ -232,7 +336,7 @@ func (a *Agent) Compile(ctx context.Context) error {
 					return nil
 
 				}),
-				ai.WithMiddleware(a.SaveConversationMiddleware),
+				ai.WithMiddleware(a.SaveConversationMiddleware, model.Fallback),
 			)
 			if err != nil {
 				a.logger.Error("Generation error", "error", err)
@@ -241,8 +345,6 @@ func (a *Agent) Compile(ctx context.Context) error {
 			return finalResp.Text(), nil
 		},
 	)
-	a.flow = agentFlow
-	return nil
 }
 
 func (a *Agent) preProcessInput(ctx context.Context, input *FlowInput) (*FlowInput, error) {
@@ -261,7 +363,7 @@ func (a *Agent) preProcessInput(ctx context.Context, input *FlowInput) (*FlowInp
 	}
 
 	// Case 3: Only has audio - transcribe it
-	transcribedText, err := a.asrAgent.GetASR(ctx, input.Audio)
+	transcribedText, err := a.asr().GetASR(ctx, input.Audio)
 	if err != nil {
 		return nil, fmt.Errorf("ASR transcription failed: %w", err)
 	}
@@ -276,6 +378,8 @@ func (a *Agent) preProcessInput(ctx context.Context, input *FlowInput) (*FlowInp
 	return input, nil
 }
 func (a *Agent) RetrieveRelatedInfo(ctx context.Context, input *FlowInput) *FlowInput {
+	ctx, span := telemetry.Start(ctx, "retrieve")
+	defer span.End()
 	user, _ := a.store.GetUser(ctx, input.UserID)
 	userFacts, _ := a.store.GetUserFacts(ctx, store.GetUserFactsParams{
 		Limit:  10,
@@ -295,8 +399,11 @@ func (a *Agent) RetrieveRelatedInfo(ctx context.Context, input *FlowInput) *Flow
 }
 
 func (a *Agent) InferSpeak(ctx context.Context, input *FlowInput) (chan SpeakResponse, error) {
+	ctx = metering.WithConversation(ctx, input.ConversationID)
+	ctx, span := telemetry.StartTurn(ctx, "voice.turn", attribute.String("ene.conversation_id", input.ConversationID))
 	input, err := a.preProcessInput(ctx, input)
 	if err != nil {
+		telemetry.End(span, err)
 		return nil, err
 	}
 	input = a.RetrieveRelatedInfo(ctx, input)
@@ -305,71 +412,151 @@ func (a *Agent) InferSpeak(ctx context.Context, input *FlowInput) (chan SpeakRes
 	resultChan := make(chan SpeakResponse, 20)
 
 	// Start flow in goroutine
+	var streamErr error
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
+		defer close(input.chunkChan) // Signal that streaming is complete
 		input.Audio = []byte(input.Text)
-		chunks := a.flow.Stream(ctx, *input)
 
-		a.logger.Info("Flow completed", "final response", chunks)
-		for chunk := range chunks {
+		var tags tagFilter
+		for chunk, err := range a.llm.Load().flow.Stream(ctx, *input) {
+			if err != nil {
+				streamErr = err
+				break
+			}
 			if chunk == nil {
 				continue
 			}
 			if chunk.Done {
 				break
 			}
+			if input.OnTextChunk != nil {
+				input.OnTextChunk(tags.Write(chunk.Stream))
+			}
+		}
+		if rest := tags.Flush(); rest != "" && input.OnTextChunk != nil {
+			input.OnTextChunk(rest)
 		}
-
-		close(input.chunkChan) // Signal that streaming is complete
 	}()
 
-	// Process chunks and convert to speech
-	go a.handleStreamToSpeech(ctx, input.chunkChan, resultChan)
-
+	// Process chunks and convert to speech, the last step of the turn. A
+	// failed generation is reported as the last response.
 	go func() {
+		defer close(resultChan)
+		a.handleStreamToSpeech(ctx, input.chunkChan, resultChan)
+		// speech stops early on cancellation, the flow must not block on the channel
+		for range input.chunkChan {
+		}
 		wg.Wait()
-		bgCtx := context.Background()
-		historyMessages, _ := a.store.ListConversationMessages(ctx, utils.Ptr(input.ConversationID))
-		if len(historyMessages)%20 == 0 && len(historyMessages) > 0 {
-			summary, err := a.summaryFlow.Run(bgCtx, ExtractInput{
-				ChatHistory:    ParseHistoryMessages(historyMessages),
-				UserFacts:      input.UserFacts,
-				CharacterFacts: input.CharacterFacts,
-				User:           input.User,
-				Character:      input.Character})
-			if err != nil {
-				a.logger.Error("Lỗi khi tạo summary", "error", err)
-				return
+		telemetry.End(span, streamErr)
+		if streamErr != nil {
+			a.logger.Error("Voice turn failed", "conversation_id", input.ConversationID, "error", streamErr)
+			select {
+			case resultChan <- SpeakResponse{Err: streamErr}:
+			case <-ctx.Done():
 			}
-			a.logger.Info("Summary", "summary", summary)
-			// todo: update summary to database
 		}
-		if len(historyMessages) > 0 {
-			facts, err := a.extractMemoryFlow.Run(bgCtx, ExtractInput{
-				ChatHistory:    ParseHistoryMessages(historyMessages),
-				UserFacts:      input.UserFacts,
-				CharacterFacts: input.CharacterFacts,
-				User:           input.User,
-				Character:      input.Character})
-			if err != nil {
-				a.logger.Error("Lỗi khi tạo facts", "error", err)
-				return
-			}
-			a.logger.Info("Facts", "facts", facts)
+	}()
+
+	go func() {
+		wg.Wait()
+		if streamErr == nil {
+			a.afterTurn(ctx, input)
 		}
-		// todo: save facts to database
 	}()
 	return resultChan, nil
 }
 
+// Chat runs one turn without speech synthesis and returns the full reply.
+func (a *Agent) Chat(ctx context.Context, input *FlowInput) (reply string, err error) {
+	ctx = metering.WithConversation(ctx, input.ConversationID)
+	ctx, span := telemetry.StartTurn(ctx, "chat.turn", attribute.String("ene.conversation_id", input.ConversationID))
+	defer func() { telemetry.End(span, err) }()
+	input, err = a.preProcessInput(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	input = a.RetrieveRelatedInfo(ctx, input)
+
+	// the flow always forwards chunks for speech, nobody needs them here
+	input.chunkChan = make(chan string, 20)
+	go func() {
+		for range input.chunkChan {
+		}
+	}()
+
+	var tags tagFilter
+	for chunk, streamErr := range a.llm.Load().flow.Stream(ctx, *input) {
+		if streamErr != nil {
+			err = streamErr
+			break
+		}
+		if chunk == nil {
+			continue
+		}
+		if chunk.Done {
+			reply = StripEmotionTags(chunk.Output)
+		} else if input.OnTextChunk != nil {
+			input.OnTextChunk(tags.Write(chunk.Stream))
+		}
+	}
+	close(input.chunkChan)
+	if err != nil {
+		return "", err
+	}
+	if rest := tags.Flush(); rest != "" && input.OnTextChunk != nil {
+		input.OnTextChunk(rest)
+	}
+
+	// the caller may cancel ctx as soon as the reply is returned
+	go a.afterTurn(context.WithoutCancel(ctx), input)
+	return reply, nil
+}
+
+// afterTurn summarizes the conversation and extracts facts once a turn is done.
+func (a *Agent) afterTurn(ctx context.Context, input *FlowInput) {
+	bgCtx := metering.WithConversation(context.Background(), input.ConversationID)
+	state := a.llm.Load()
+	historyMessages, _ := a.store.ListConversationMessages(ctx, utils.Ptr(input.ConversationID))
+	if len(historyMessages)%20 == 0 && len(historyMessages) > 0 {
+		summary, err := state.summaryFlow.Run(bgCtx, ExtractInput{
+			ChatHistory:    ParseHistoryMessages(historyMessages),
+			UserFacts:      input.UserFacts,
+			CharacterFacts: input.CharacterFacts,
+			User:           input.User,
+			Character:      input.Character})
+		if err != nil {
+			a.logger.Error("Lỗi khi tạo summary", "error", err)
+			return
+		}
+		a.logger.Info("Summary", "summary", summary)
+		// todo: update summary to database
+	}
+	if len(historyMessages) > 0 {
+		facts, err := state.extractMemoryFlow.Run(bgCtx, ExtractInput{
+			ChatHistory:    ParseHistoryMessages(historyMessages),
+			UserFacts:      input.UserFacts,
+			CharacterFacts: input.CharacterFacts,
+			User:           input.User,
+			Character:      input.Character})
+		if err != nil {
+			a.logger.Error("Lỗi khi tạo facts", "error", err)
+			return
+		}
+		a.logger.Info("Facts", "facts", facts)
+	}
+	// todo: save facts to database
+}
+
 func (a *Agent) handleStreamToSpeech(
 	ctx context.Context,
 	chunkChan <-chan string,
 	resultChan chan<- SpeakResponse,
 ) {
-	defer close(resultChan)
+	ctx, span := telemetry.Start(ctx, "speech.stream")
+	defer span.End()
 
 	var buffer string
 
@@ -447,18 +634,72 @@ func (a *Agent) sendSpeechResponse(
 		return err
 	}
 
-	audio, err := a.ttsAgent.GetTTS(ctx, text)
+	emotion, text := ExtractEmotion(text)
+	if text == "" {
+		return nil
+	}
+	audio, alignment, err := a.synthesize(ctx, text)
 	if err != nil {
 		a.logger.Error("TTS generation failed", "error", err, "text", text)
 		return nil // Continue processing other sentences
 	}
+	response := SpeakResponse{Text: text, AudioBuffer: audio, Emotion: emotion}
+	if a.motionMapper != nil {
+		response.Motion = a.motionMapper.Motion(emotion)
+	}
+	response.LipSync = a.lipSync(audio, alignment)
 
 	// Send with context check
 	select {
 	case <-ctx.Done():
 		a.logger.Error("Context cancelled during send", "error", ctx.Err())
 		return ctx.Err()
-	case resultChan <- SpeakResponse{Text: text, AudioBuffer: audio}:
+	case resultChan <- response:
+		telemetry.FirstAudio(ctx)
+		return nil
+	}
+}
+
+// Speak synthesizes a finished reply sentence by sentence, for callers that
+// send the text first and the voice after it. The MP3 chunks are joined as
+// they are.
+func (a *Agent) Speak(ctx context.Context, text string) ([]byte, error) {
+	var audio []byte
+	for _, sentence := range utils.SplitSentences(text) {
+		sentence = strings.TrimSpace(sentence)
+		if sentence == "" {
+			continue
+		}
+		chunk, _, err := a.synthesize(ctx, sentence)
+		if err != nil {
+			return nil, err
+		}
+		audio = append(audio, chunk...)
+	}
+	return audio, nil
+}
+
+// synthesize asks for character timings when the TTS provider has them.
+func (a *Agent) synthesize(ctx context.Context, text string) ([]byte, *tts.Alignment, error) {
+	ttsAgent := a.tts()
+	if timed, ok := ttsAgent.(tts.TimedTTSAgent); ok {
+		return timed.GetTimedTTS(ctx, text)
+	}
+	audio, err := ttsAgent.GetTTS(ctx, text)
+	return audio, nil, err
+}
+
+// lipSync computes the mouth data shipped with a chunk. A chunk that cannot be
+// decoded still plays, the frontend falls back to its own analysis.
+func (a *Agent) lipSync(audio []byte, alignment *tts.Alignment) *lipsync.Data {
+	envelope, err := lipsync.Envelope(audio, lipsync.DefaultFrameMs)
+	if err != nil {
+		a.logger.Warn("Cannot compute lip-sync envelope", "error", err)
 		return nil
 	}
+	data := &lipsync.Data{FrameMs: lipsync.DefaultFrameMs, Envelope: envelope}
+	if alignment != nil {
+		data.Visemes = lipsync.Visemes(alignment.Characters, alignment.Starts, alignment.Ends)
+	}
+	return data
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync"
 
 	"github.com/Mirai3103/Project-Re-ENE/config"
 	"github.com/Mirai3103/Project-Re-ENE/embedding"
@@ -14,20 +15,36 @@ import (
 type EmbeddingService struct {
 	cfg    *config.Config
 	logger *slog.Logger
-	model  embedding.Model
 	store  *store.Queries
+
+	mu    sync.RWMutex
+	model embedding.Model
 }
 
 func NewEmbeddingService(cfg *config.Config, logger *slog.Logger, model embedding.Model, store *store.Queries) *EmbeddingService {
 	return &EmbeddingService{cfg: cfg, logger: logger, model: model, store: store}
 }
 
+// SetModel switches the embedding model. Vectors already stored keep the old
+// model's space, so changing models makes older memories harder to find.
+func (s *EmbeddingService) SetModel(model embedding.Model) {
+	s.mu.Lock()
+	s.model = model
+	s.mu.Unlock()
+}
+
+func (s *EmbeddingService) embedder() embedding.Model {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model
+}
+
 func (s *EmbeddingService) EmbedText(ctx context.Context, text string) ([]float32, error) {
-	return s.model.Get(ctx, text)
+	return s.embedder().Get(ctx, text)
 }
 
 func (s *EmbeddingService) AddMemory(ctx context.Context, memory *store.Memory) error {
-	vector, err := s.model.Get(ctx, *memory.Content)
+	vector, err := s.embedder().Get(ctx, *memory.Content)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,107 @@
+package agent
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Mirai3103/Project-Re-ENE/live2d"
+)
+
+// The model starts each sentence with an inline tag such as "[happy]". Tags
+// are stripped before TTS and before text reaches any client.
+var emotionTagPattern = regexp.MustCompile(`\[([A-Za-z_]{2,20})\]`)
+
+// maxTagLength bounds how much text the stream filter holds back while
+// waiting for a "]".
+const maxTagLength = 22
+
+func emotionPrompt() string {
+	tags := make([]string, 0, len(live2d.Emotions))
+	for _, e := range live2d.Emotions {
+		tags = append(tags, "["+e+"]")
+	}
+	return "Bắt đầu mỗi câu bằng đúng một thẻ cảm xúc trong ngoặc vuông, chọn trong: " +
+		strings.Join(tags, " ") + ". Ví dụ: [happy] Chào bạn! [thinking] Để mình xem nào."
+}
+
+// ExtractEmotion returns the first known emotion tag in text and the text
+// without any tags.
+func ExtractEmotion(text string) (emotion string, clean string) {
+	for _, m := range emotionTagPattern.FindAllStringSubmatch(text, -1) {
+		if name := strings.ToLower(m[1]); live2d.IsEmotion(name) {
+			emotion = name
+			break
+		}
+	}
+	return emotion, StripEmotionTags(text)
+}
+
+// StripEmotionTags removes the tags that name an emotion. Other bracketed
+// text such as "[1]" stays, and so do line breaks and indentation, only the
+// spaces left next to a removed tag go.
+func StripEmotionTags(text string) string {
+	if !strings.Contains(text, "[") {
+		return text
+	}
+	clean, _ := stripEmotionTags(text, true)
+	return clean
+}
+
+// stripEmotionTags drops each emotion tag with the spaces after it. A tag
+// that ends a line also takes the spaces before it, final says whether the
+// end of text is the end of the reply. afterTag reports that text ended
+// right after a removed tag, so the spaces may still follow.
+func stripEmotionTags(text string, final bool) (clean string, afterTag bool) {
+	var b strings.Builder
+	last := 0
+	for _, loc := range emotionTagPattern.FindAllStringSubmatchIndex(text, -1) {
+		if !live2d.IsEmotion(strings.ToLower(text[loc[2]:loc[3]])) {
+			continue
+		}
+		before := text[last:loc[0]]
+		rest := strings.TrimLeft(text[loc[1]:], " \t")
+		if (rest == "" && final) || strings.HasPrefix(rest, "\n") || strings.HasPrefix(rest, "\r") {
+			before = strings.TrimRight(before, " \t")
+		}
+		b.WriteString(before)
+		last = len(text) - len(rest)
+		afterTag = rest == ""
+	}
+	b.WriteString(text[last:])
+	return b.String(), afterTag && last == len(text)
+}
+
+// tagFilter strips tags from streamed chunks. A tag may be split across
+// chunks, so an unclosed "[" is held back until it closes or grows too long.
+type tagFilter struct {
+	pending string
+	// skipSpace drops the spaces that follow a tag removed at the end of
+	// the previous chunk.
+	skipSpace bool
+}
+
+func (f *tagFilter) Write(chunk string) string {
+	text := f.pending + chunk
+	f.pending = ""
+	if i := strings.LastIndex(text, "["); i >= 0 && !strings.Contains(text[i:], "]") && len(text)-i < maxTagLength {
+		f.pending = text[i:]
+		text = text[:i]
+	}
+	if f.skipSpace {
+		text = strings.TrimLeft(text, " \t")
+		f.skipSpace = text == ""
+	}
+	clean, afterTag := stripEmotionTags(text, false)
+	if afterTag {
+		f.skipSpace = true
+	}
+	return clean
+}
+
+// Flush returns what is still held back once the stream ends.
+func (f *tagFilter) Flush() string {
+	text := f.pending
+	f.pending = ""
+	f.skipSpace = false
+	return text
+}
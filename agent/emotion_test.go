@@ -0,0 +1,37 @@
+package agent
+
+import "testing"
+
+func TestExtractEmotion(t *testing.T) {
+	tests := []struct {
+		text, emotion, clean string
+	}{
+		{"[happy] Chào bạn!", "happy", "Chào bạn!"},
+		{"Chào bạn!", "", "Chào bạn!"},
+		{"[Sad]  Buồn quá.", "sad", "Buồn quá."},
+		{"[happy] [shy] Hì hì.", "happy", "Hì hì."},
+		{"[excited] [shy] Hì hì.", "shy", "[excited] Hì hì."},
+		{"Giá là [1] đô.", "", "Giá là [1] đô."},
+		{"[happy] Chào bạn! [sad] Buồn quá.", "happy", "Chào bạn! Buồn quá."},
+		{"Xem [docs](https://ene.dev) [sic] nhé.", "", "Xem [docs](https://ene.dev) [sic] nhé."},
+		{"[thinking] Các bước:\n\n1. Mở app [happy]\n2. Bấm nút\n\n```go\n\tfmt.Println(\"hi\")\n```", "thinking", "Các bước:\n\n1. Mở app\n2. Bấm nút\n\n```go\n\tfmt.Println(\"hi\")\n```"},
+	}
+	for _, tt := range tests {
+		emotion, clean := ExtractEmotion(tt.text)
+		if emotion != tt.emotion || clean != tt.clean {
+			t.Errorf("ExtractEmotion(%q) = %q, %q; want %q, %q", tt.text, emotion, clean, tt.emotion, tt.clean)
+		}
+	}
+}
+
+func TestTagFilterAcrossChunks(t *testing.T) {
+	var f tagFilter
+	var out string
+	for _, chunk := range []string{"[hap", "py]", " Chào", " bạn! [", "sad] Buồn", " quá\n  [1"} {
+		out += f.Write(chunk)
+	}
+	out += f.Flush()
+	if want := "Chào bạn! Buồn quá\n  [1"; out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
@@ -1,36 +1,69 @@
 package agent
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/Mirai3103/Project-Re-ENE/package/utils"
-	"github.com/firebase/genkit/go/ai"
 	"github.com/firebase/genkit/go/plugins/mcp"
 )
 
 type MCPConfig struct {
+	// Type selects the transport: "stdio", "sse" or "http" (streamable HTTP).
+	// When empty it is inferred from command/url.
+	Type    *string   `json:"type"`
 	Command *string   `json:"command"`
 	Args    *[]string `json:"args"`
 	Env     *[]string `json:"env"`
-	Enable  bool      `json:"enable" default:"true"`
+	Enable  *bool     `json:"enable" default:"true"`
 	Url     *string   `json:"url"`
+	// Headers are sent with every request of the sse and http transports.
+	Headers map[string]string `json:"headers"`
+	// BearerToken is sent as "Authorization: Bearer <token>".
+	BearerToken *string `json:"bearerToken"`
+	// Timeout is the per-request timeout in seconds for the http transport.
+	Timeout *int `json:"timeout"`
 }
 
 func (c *MCPConfig) IsValid() bool {
-	return c.Command != nil || c.Url != nil
+	switch c.GetType() {
+	case MCPTypeStdio:
+		return c.Command != nil
+	case MCPTypeSSE, MCPTypeStreamableHTTP:
+		return c.Url != nil
+	default:
+		return false
+	}
+}
+
+// IsEnabled reports whether the server should be started. Servers without an
+// explicit "enable" key are enabled.
+func (c *MCPConfig) IsEnabled() bool {
+	return utils.OrDefault(c.Enable, true)
 }
 
 type MCPType string
 
 const (
-	MCPTypeStdio MCPType = "stdio"
-	MCPTypeSSE   MCPType = "sse"
+	MCPTypeStdio          MCPType = "stdio"
+	MCPTypeSSE            MCPType = "sse"
+	MCPTypeStreamableHTTP MCPType = "http"
 )
 
 func (c *MCPConfig) GetType() MCPType {
+	if c.Type != nil {
+		switch strings.ToLower(*c.Type) {
+		case "http", "streamable-http", "streamablehttp", "streamable_http":
+			return MCPTypeStreamableHTTP
+		default:
+			return MCPType(strings.ToLower(*c.Type))
+		}
+	}
 	if c.Command != nil {
 		return MCPTypeStdio
 	}
@@ -41,6 +74,8 @@ type MCPConfigFile struct {
 	McpServers map[string]MCPConfig `json:"mcpServers"`
 }
 
+var ErrNoMCPServers = errors.New("no valid mcp servers found")
+
 func ParseMCPConfigFile(mcpConfigPath string) (*MCPConfigFile, error) {
 	data, err := os.ReadFile(mcpConfigPath)
 	if err != nil {
@@ -51,63 +86,120 @@ func ParseMCPConfigFile(mcpConfigPath string) (*MCPConfigFile, error) {
 	if err != nil {
 		return nil, err
 	}
-	// ignore enable false and invalid config
+	// ignore invalid config, disabled servers are kept so their status can be shown
 	for name, config := range mcpConfig.McpServers {
 		if !config.IsValid() {
 			delete(mcpConfig.McpServers, name)
 		}
-		if !config.Enable {
-			delete(mcpConfig.McpServers, name)
-		}
 	}
 	if len(mcpConfig.McpServers) == 0 {
-		return nil, errors.New("no valid mcp servers found")
+		return nil, ErrNoMCPServers
 	}
 	return &mcpConfig, nil
 }
-func (a *Agent) parseMcpTools(ctx context.Context) ([]ai.Tool, error) {
-	if !a.agentConfig.ToolsConfig.MCP.Enable {
-		return []ai.Tool{}, nil
+
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces ${NAME} with the value of the environment variable NAME.
+// Unlike os.ExpandEnv a bare $ is left alone and unset variables are an error,
+// so a missing secret fails loudly instead of sending an empty token.
+func expandEnv(s string) (string, error) {
+	var missing []string
+	out := envRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		name := envRefPattern.FindStringSubmatch(ref)[1]
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable not set: %s", strings.Join(missing, ", "))
+	}
+	return out, nil
+}
+
+// Expand returns a copy of the config with ${ENV} references resolved in
+// every string field.
+func (c MCPConfig) Expand() (MCPConfig, error) {
+	var err error
+	expand := func(s string) string {
+		if err != nil {
+			return s
+		}
+		var out string
+		out, err = expandEnv(s)
+		return out
 	}
-	mcpConfigs, err := ParseMCPConfigFile(a.agentConfig.ToolsConfig.MCP.ConfigPath)
+	expandPtr := func(s *string) *string {
+		if s == nil {
+			return nil
+		}
+		return utils.Ptr(expand(*s))
+	}
+	expandSlice := func(s *[]string) *[]string {
+		if s == nil {
+			return nil
+		}
+		out := make([]string, len(*s))
+		for i, v := range *s {
+			out[i] = expand(v)
+		}
+		return &out
+	}
+
+	expanded := c
+	expanded.Command = expandPtr(c.Command)
+	expanded.Args = expandSlice(c.Args)
+	expanded.Env = expandSlice(c.Env)
+	expanded.Url = expandPtr(c.Url)
+	expanded.BearerToken = expandPtr(c.BearerToken)
+	if c.Headers != nil {
+		expanded.Headers = make(map[string]string, len(c.Headers))
+		for k, v := range c.Headers {
+			expanded.Headers[k] = expand(v)
+		}
+	}
+	return expanded, err
+}
+
+func (c *MCPConfig) httpHeaders() map[string]string {
+	headers := make(map[string]string, len(c.Headers)+1)
+	for k, v := range c.Headers {
+		headers[k] = v
+	}
+	if !utils.IsNilOrBlank(c.BearerToken) {
+		headers["Authorization"] = "Bearer " + *c.BearerToken
+	}
+	return headers
+}
+
+func newMCPClient(name string, config MCPConfig) (*mcp.GenkitMCPClient, error) {
+	config, err := config.Expand()
 	if err != nil {
 		return nil, err
 	}
-	var tools []ai.Tool
-	for name, config := range mcpConfigs.McpServers {
-		if config.GetType() == MCPTypeStdio {
-			client, err := mcp.NewGenkitMCPClient(mcp.MCPClientOptions{
-				Name: name,
-				Stdio: &mcp.StdioConfig{
-					Command: *config.Command,
-					Args:    *config.Args,
-					Env:     utils.OrDefault(config.Env, []string{}),
-				},
-			})
-			if err != nil {
-				return nil, err
-			}
-			mcpTools, err := client.GetActiveTools(ctx, a.llmModel)
-			if err != nil {
-				return nil, err
-			}
-			tools = append(tools, mcpTools...)
-		} else if config.GetType() == MCPTypeSSE {
-			client, err := mcp.NewGenkitMCPClient(mcp.MCPClientOptions{
-				Name: name,
-				SSE: &mcp.SSEConfig{
-					BaseURL: *config.Url,
-				},
-			})
-			if err != nil {
-				return nil, err
-			}
-			mcpTools, err := client.GetActiveTools(ctx, a.llmModel)
-			if err != nil {
-				return nil, err
-			}
-			tools = append(tools, mcpTools...)
+	options := mcp.MCPClientOptions{Name: name}
+	switch config.GetType() {
+	case MCPTypeStdio:
+		options.Stdio = &mcp.StdioConfig{
+			Command: *config.Command,
+			Args:    utils.OrDefault(config.Args, []string{}),
+			Env:     utils.OrDefault(config.Env, []string{}),
+		}
+	case MCPTypeSSE:
+		options.SSE = &mcp.SSEConfig{
+			BaseURL: *config.Url,
+			Headers: config.httpHeaders(),
+		}
+	case MCPTypeStreamableHTTP:
+		options.StreamableHTTP = &mcp.StreamableHTTPConfig{
+			BaseURL: *config.Url,
+			Headers: config.httpHeaders(),
+			Timeout: time.Duration(utils.OrDefault(config.Timeout, 0)) * time.Second,
 		}
+	default:
+		return nil, fmt.Errorf("mcp transport is not supported: %s", config.GetType())
 	}
-	return tools, nil
+	return mcp.NewGenkitMCPClient(options)
 }
@@ -0,0 +1,412 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/firebase/genkit/go/ai"
+	"github.com/firebase/genkit/go/genkit"
+	"github.com/firebase/genkit/go/plugins/mcp"
+)
+
+type MCPServerStatus string
+
+const (
+	MCPStatusConnecting MCPServerStatus = "connecting"
+	MCPStatusReady      MCPServerStatus = "ready"
+	MCPStatusFailed     MCPServerStatus = "failed"
+	MCPStatusDisabled   MCPServerStatus = "disabled"
+)
+
+const (
+	mcpWatchInterval       = 2 * time.Second
+	mcpHealthCheckInterval = 30 * time.Second
+	mcpMinReconnectDelay   = time.Second
+	mcpMaxReconnectDelay   = time.Minute
+)
+
+type MCPToolInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type MCPServerInfo struct {
+	Name      string          `json:"name"`
+	Type      MCPType         `json:"type"`
+	Status    MCPServerStatus `json:"status"`
+	Error     string          `json:"error"`
+	Attempts  int             `json:"attempts"`
+	Tools     []MCPToolInfo   `json:"tools"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// MCPManager keeps one connection per MCP server. Each server is supervised
+// on its own, so a broken server only loses its own tools, and the config
+// file is watched so edits apply without restarting the app.
+type MCPManager struct {
+	cfg    *config.Live
+	logger *slog.Logger
+
+	mu       sync.RWMutex
+	ctx      context.Context
+	cancel   context.CancelFunc
+	g        *genkit.Genkit
+	servers  map[string]*mcpServer
+	modTime  time.Time
+	onChange func(MCPServerInfo)
+}
+
+type mcpServer struct {
+	name    string
+	config  MCPConfig
+	ctx     context.Context
+	cancel  context.CancelFunc
+	done    chan struct{}
+	restart chan struct{}
+
+	mu     sync.RWMutex
+	client *mcp.GenkitMCPClient
+	tools  []ai.Tool
+	info   MCPServerInfo
+}
+
+func NewMCPManager(cfg *config.Live, logger *slog.Logger) *MCPManager {
+	return &MCPManager{
+		cfg:     cfg,
+		logger:  logger,
+		servers: make(map[string]*mcpServer),
+	}
+}
+
+// config is the running agent config, a reload may change it.
+func (m *MCPManager) config() *config.AgentConfig {
+	return &m.cfg.Load().AgentConfig
+}
+
+// OnStatusChange registers a callback fired whenever a server changes state.
+func (m *MCPManager) OnStatusChange(fn func(MCPServerInfo)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onChange = fn
+}
+
+// Start loads the config, connects every server and watches the config file
+// until ctx is done or Stop is called.
+func (m *MCPManager) Start(ctx context.Context, g *genkit.Genkit) {
+	ctx, cancel := context.WithCancel(ctx)
+	m.mu.Lock()
+	m.ctx = ctx
+	m.cancel = cancel
+	m.g = g
+	m.mu.Unlock()
+
+	if err := m.Reload(); err != nil {
+		m.logger.Warn("Không thể tải cấu hình MCP", "error", err)
+	}
+	go m.watch(ctx)
+}
+
+func (m *MCPManager) Stop() {
+	m.mu.Lock()
+	if m.cancel != nil {
+		m.cancel()
+	}
+	servers := m.servers
+	m.servers = make(map[string]*mcpServer)
+	m.mu.Unlock()
+	for _, s := range servers {
+		s.stop()
+	}
+}
+
+// Reload re-reads the MCP config file. Added servers are started, removed
+// ones are stopped and servers whose config changed are restarted.
+func (m *MCPManager) Reload() error {
+	configs := map[string]MCPConfig{}
+	if mcpConfig := m.config().ToolsConfig.MCP; mcpConfig.Enable {
+		configPath := mcpConfig.ConfigPath
+		if info, err := os.Stat(configPath); err == nil {
+			m.mu.Lock()
+			m.modTime = info.ModTime()
+			m.mu.Unlock()
+		}
+		file, err := ParseMCPConfigFile(configPath)
+		switch {
+		case errors.Is(err, ErrNoMCPServers):
+		case err != nil:
+			// keep the running servers, a half-written file should not kill them
+			return err
+		default:
+			configs = file.McpServers
+		}
+	}
+
+	m.mu.Lock()
+	ctx := m.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var stale []*mcpServer
+	for name, s := range m.servers {
+		next, ok := configs[name]
+		if !ok || !reflect.DeepEqual(next, s.config) {
+			stale = append(stale, s)
+			delete(m.servers, name)
+		}
+	}
+	var started []*mcpServer
+	for name, c := range configs {
+		if _, ok := m.servers[name]; ok {
+			continue
+		}
+		s := m.newServer(ctx, name, c)
+		m.servers[name] = s
+		started = append(started, s)
+	}
+	m.mu.Unlock()
+
+	for _, s := range stale {
+		m.logger.Info("Stopping MCP server", "name", s.name)
+		s.stop()
+	}
+	for _, s := range started {
+		m.logger.Info("Starting MCP server", "name", s.name)
+		go m.supervise(s)
+	}
+	return nil
+}
+
+// Restart drops the connection of one server and connects again right away.
+func (m *MCPManager) Restart(name string) error {
+	m.mu.RLock()
+	s, ok := m.servers[name]
+	m.mu.RUnlock()
+	if !ok {
+		return errors.New("mcp server not found: " + name)
+	}
+	select {
+	case s.restart <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Tools returns the tools of every ready server.
+func (m *MCPManager) Tools() []ai.Tool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var tools []ai.Tool
+	for _, s := range m.servers {
+		s.mu.RLock()
+		tools = append(tools, s.tools...)
+		s.mu.RUnlock()
+	}
+	return tools
+}
+
+func (m *MCPManager) ListServers() []MCPServerInfo {
+	m.mu.RLock()
+	infos := make([]MCPServerInfo, 0, len(m.servers))
+	for _, s := range m.servers {
+		infos = append(infos, s.snapshot())
+	}
+	m.mu.RUnlock()
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+func (m *MCPManager) newServer(parent context.Context, name string, c MCPConfig) *mcpServer {
+	ctx, cancel := context.WithCancel(parent)
+	return &mcpServer{
+		name:    name,
+		config:  c,
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		restart: make(chan struct{}, 1),
+		info: MCPServerInfo{
+			Name:      name,
+			Type:      c.GetType(),
+			Status:    MCPStatusConnecting,
+			UpdatedAt: time.Now(),
+		},
+	}
+}
+
+func (m *MCPManager) watch(ctx context.Context) {
+	ticker := time.NewTicker(mcpWatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		configPath := m.config().ToolsConfig.MCP.ConfigPath
+		info, err := os.Stat(configPath)
+		if err != nil {
+			continue
+		}
+		m.mu.RLock()
+		changed := !info.ModTime().Equal(m.modTime)
+		m.mu.RUnlock()
+		if !changed {
+			continue
+		}
+		m.logger.Info("MCP config changed, reloading", "path", configPath)
+		if err := m.Reload(); err != nil {
+			m.logger.Error("Lỗi khi tải lại cấu hình MCP", "error", err)
+		}
+	}
+}
+
+// supervise connects a server, checks it periodically and reconnects with
+// exponential backoff whenever it fails.
+func (m *MCPManager) supervise(s *mcpServer) {
+	ctx := s.ctx
+	defer close(s.done)
+	defer s.disconnect()
+
+	if !s.config.IsEnabled() {
+		m.setStatus(s, MCPStatusDisabled, nil)
+		<-ctx.Done()
+		return
+	}
+
+	delay := mcpMinReconnectDelay
+	for {
+		m.setStatus(s, MCPStatusConnecting, nil)
+		err := m.connect(ctx, s)
+		if err == nil {
+			delay = mcpMinReconnectDelay
+			m.setStatus(s, MCPStatusReady, nil)
+			err = m.monitor(ctx, s)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		s.disconnect()
+		if err == nil {
+			// restart requested while healthy
+			continue
+		}
+		m.logger.Error("MCP server failed", "name", s.name, "error", err, "retry_in", delay)
+		m.setStatus(s, MCPStatusFailed, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.restart:
+			delay = mcpMinReconnectDelay
+		case <-time.After(delay):
+			delay = min(delay*2, mcpMaxReconnectDelay)
+		}
+	}
+}
+
+func (m *MCPManager) connect(ctx context.Context, s *mcpServer) error {
+	s.mu.Lock()
+	s.info.Attempts++
+	s.mu.Unlock()
+	client, err := newMCPClient(s.name, s.config)
+	if err != nil {
+		return err
+	}
+	m.mu.RLock()
+	g := m.g
+	m.mu.RUnlock()
+	// listing tools is also the handshake check, the client swallows initialize errors
+	tools, err := client.GetActiveTools(ctx, g)
+	if err != nil {
+		client.Disconnect()
+		return err
+	}
+	s.mu.Lock()
+	s.client = client
+	s.tools = tools
+	s.info.Tools = make([]MCPToolInfo, 0, len(tools))
+	for _, t := range tools {
+		s.info.Tools = append(s.info.Tools, MCPToolInfo{Name: t.Name(), Description: t.Definition().Description})
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// monitor blocks while the server stays healthy. A nil error means a restart
+// was requested.
+func (m *MCPManager) monitor(ctx context.Context, s *mcpServer) error {
+	ticker := time.NewTicker(mcpHealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.restart:
+			return nil
+		case <-ticker.C:
+		}
+		s.mu.RLock()
+		client := s.client
+		s.mu.RUnlock()
+		m.mu.RLock()
+		g := m.g
+		m.mu.RUnlock()
+		if _, err := client.GetActiveTools(ctx, g); err != nil {
+			return err
+		}
+	}
+}
+
+func (m *MCPManager) setStatus(s *mcpServer, status MCPServerStatus, err error) {
+	s.mu.Lock()
+	s.info.Status = status
+	s.info.Error = ""
+	if err != nil {
+		s.info.Error = err.Error()
+	}
+	s.info.UpdatedAt = time.Now()
+	info := s.snapshotLocked()
+	s.mu.Unlock()
+
+	m.mu.RLock()
+	onChange := m.onChange
+	m.mu.RUnlock()
+	if onChange != nil {
+		onChange(info)
+	}
+}
+
+func (s *mcpServer) snapshot() MCPServerInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.snapshotLocked()
+}
+
+func (s *mcpServer) snapshotLocked() MCPServerInfo {
+	info := s.info
+	info.Tools = append([]MCPToolInfo(nil), s.info.Tools...)
+	return info
+}
+
+func (s *mcpServer) disconnect() {
+	s.mu.Lock()
+	client := s.client
+	s.client = nil
+	s.tools = nil
+	s.info.Tools = nil
+	s.mu.Unlock()
+	if client != nil {
+		client.Disconnect()
+	}
+}
+
+func (s *mcpServer) stop() {
+	s.cancel()
+	<-s.done
+}
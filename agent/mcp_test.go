@@ -0,0 +1,272 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/package/mcphttp"
+	"github.com/Mirai3103/Project-Re-ENE/package/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func newEchoMCPServer() *server.MCPServer {
+	s := server.NewMCPServer("echo", "1.0.0", server.WithToolCapabilities(true))
+	s.AddTool(
+		mcp.NewTool("echo", mcp.WithDescription("Echoes the text back"), mcp.WithString("text", mcp.Required())),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			text, _ := req.GetArguments()["text"].(string)
+			return mcp.NewToolResultText("echo: " + text), nil
+		},
+	)
+	return s
+}
+
+// requireHeader rejects requests whose header does not match.
+func requireHeader(name, value string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(name) != value {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func newSSETestServer(t *testing.T, s *server.MCPServer, wrap func(http.Handler) http.Handler) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewUnstartedServer(nil)
+	ts.Start()
+	sse := server.NewSSEServer(s, server.WithBaseURL(ts.URL))
+	ts.Config.Handler = wrap(sse)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func newStreamableHTTPTestServer(t *testing.T, s *server.MCPServer, wrap func(http.Handler) http.Handler) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(wrap(mcphttp.StreamableHandler(s)))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func writeMCPConfig(t *testing.T, path string, servers map[string]MCPConfig) {
+	t.Helper()
+	data, err := json.Marshal(MCPConfigFile{McpServers: servers})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestMCPManager(t *testing.T, servers map[string]MCPConfig) (*MCPManager, string) {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	writeMCPConfig(t, configPath, servers)
+	cfg := &config.Config{}
+	cfg.AgentConfig.ToolsConfig.MCP.Enable = true
+	cfg.AgentConfig.ToolsConfig.MCP.ConfigPath = configPath
+	m := NewMCPManager(config.NewLive(cfg), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	m.Start(context.Background(), nil)
+	t.Cleanup(m.Stop)
+	return m, configPath
+}
+
+func waitForStatus(t *testing.T, m *MCPManager, name string, status MCPServerStatus) MCPServerInfo {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	var last MCPServerInfo
+	for time.Now().Before(deadline) {
+		for _, info := range m.ListServers() {
+			if info.Name == name {
+				last = info
+				if info.Status == status {
+					return info
+				}
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("server %s did not reach status %s, last: %+v", name, status, last)
+	return last
+}
+
+func callEcho(t *testing.T, m *MCPManager, toolName string) string {
+	t.Helper()
+	for _, tool := range m.Tools() {
+		if tool.Name() != toolName {
+			continue
+		}
+		out, err := tool.RunRaw(context.Background(), map[string]any{"text": "hi"})
+		if err != nil {
+			t.Fatalf("call %s: %v", toolName, err)
+		}
+		data, _ := json.Marshal(out)
+		return string(data)
+	}
+	t.Fatalf("tool %s not found", toolName)
+	return ""
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("ENE_TEST_TOKEN", "secret")
+
+	got, err := expandEnv("Bearer ${ENE_TEST_TOKEN} costs $5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "Bearer secret costs $5" {
+		t.Fatalf("unexpected expansion: %q", got)
+	}
+
+	if _, err := expandEnv("${ENE_TEST_MISSING}"); err == nil || !strings.Contains(err.Error(), "ENE_TEST_MISSING") {
+		t.Fatalf("expected missing variable error, got %v", err)
+	}
+}
+
+func TestMCPConfigGetType(t *testing.T) {
+	cases := []struct {
+		config MCPConfig
+		want   MCPType
+	}{
+		{MCPConfig{Command: utils.Ptr("npx")}, MCPTypeStdio},
+		{MCPConfig{Url: utils.Ptr("http://localhost/sse")}, MCPTypeSSE},
+		{MCPConfig{Type: utils.Ptr("streamable-http"), Url: utils.Ptr("http://localhost/mcp")}, MCPTypeStreamableHTTP},
+		{MCPConfig{Type: utils.Ptr("HTTP"), Url: utils.Ptr("http://localhost/mcp")}, MCPTypeStreamableHTTP},
+	}
+	for _, c := range cases {
+		if got := c.config.GetType(); got != c.want {
+			t.Errorf("GetType() = %s, want %s", got, c.want)
+		}
+		if !c.config.IsValid() {
+			t.Errorf("config %+v should be valid", c.config)
+		}
+	}
+	if (&MCPConfig{Type: utils.Ptr("http")}).IsValid() {
+		t.Error("http config without url should be invalid")
+	}
+}
+
+func TestMCPManagerSSEWithBearerToken(t *testing.T) {
+	t.Setenv("ENE_TEST_MCP_TOKEN", "s3cret")
+	ts := newSSETestServer(t, newEchoMCPServer(), func(h http.Handler) http.Handler {
+		return requireHeader("Authorization", "Bearer s3cret", h)
+	})
+
+	m, _ := newTestMCPManager(t, map[string]MCPConfig{
+		"echo-sse": {
+			Url:         utils.Ptr(ts.URL + "/sse"),
+			BearerToken: utils.Ptr("${ENE_TEST_MCP_TOKEN}"),
+		},
+	})
+
+	info := waitForStatus(t, m, "echo-sse", MCPStatusReady)
+	if info.Type != MCPTypeSSE || len(info.Tools) != 1 {
+		t.Fatalf("unexpected server info: %+v", info)
+	}
+	if out := callEcho(t, m, "echo-sse_echo"); !strings.Contains(out, "echo: hi") {
+		t.Fatalf("unexpected tool output: %s", out)
+	}
+}
+
+func TestMCPManagerStreamableHTTPWithHeaders(t *testing.T) {
+	t.Setenv("ENE_TEST_MCP_KEY", "k-123")
+	ts := newStreamableHTTPTestServer(t, newEchoMCPServer(), func(h http.Handler) http.Handler {
+		return requireHeader("X-Api-Key", "k-123", h)
+	})
+
+	m, _ := newTestMCPManager(t, map[string]MCPConfig{
+		"echo-http": {
+			Type:    utils.Ptr("http"),
+			Url:     utils.Ptr(ts.URL),
+			Headers: map[string]string{"X-Api-Key": "${ENE_TEST_MCP_KEY}"},
+			Timeout: utils.Ptr(5),
+		},
+	})
+
+	waitForStatus(t, m, "echo-http", MCPStatusReady)
+	if out := callEcho(t, m, "echo-http_echo"); !strings.Contains(out, "echo: hi") {
+		t.Fatalf("unexpected tool output: %s", out)
+	}
+}
+
+func TestMCPManagerWrongTokenFails(t *testing.T) {
+	ts := newStreamableHTTPTestServer(t, newEchoMCPServer(), func(h http.Handler) http.Handler {
+		return requireHeader("Authorization", "Bearer right", h)
+	})
+
+	m, _ := newTestMCPManager(t, map[string]MCPConfig{
+		"echo-http": {
+			Type:        utils.Ptr("http"),
+			Url:         utils.Ptr(ts.URL),
+			BearerToken: utils.Ptr("wrong"),
+		},
+	})
+
+	info := waitForStatus(t, m, "echo-http", MCPStatusFailed)
+	if info.Error == "" {
+		t.Fatal("failed server should report its error")
+	}
+	if len(m.Tools()) != 0 {
+		t.Fatal("failed server must not expose tools")
+	}
+}
+
+func TestMCPManagerIsolatesFailingServer(t *testing.T) {
+	good := newStreamableHTTPTestServer(t, newEchoMCPServer(), func(h http.Handler) http.Handler { return h })
+	dead := httptest.NewServer(http.NotFoundHandler())
+	dead.Close()
+
+	m, _ := newTestMCPManager(t, map[string]MCPConfig{
+		"good": {Type: utils.Ptr("http"), Url: utils.Ptr(good.URL)},
+		"dead": {Url: utils.Ptr(dead.URL + "/sse")},
+	})
+
+	waitForStatus(t, m, "good", MCPStatusReady)
+	waitForStatus(t, m, "dead", MCPStatusFailed)
+	tools := m.Tools()
+	if len(tools) != 1 || tools[0].Name() != "good_echo" {
+		t.Fatalf("expected only the good server's tool, got %d tools", len(tools))
+	}
+}
+
+func TestMCPManagerReload(t *testing.T) {
+	first := newStreamableHTTPTestServer(t, newEchoMCPServer(), func(h http.Handler) http.Handler { return h })
+	second := newStreamableHTTPTestServer(t, newEchoMCPServer(), func(h http.Handler) http.Handler { return h })
+
+	m, configPath := newTestMCPManager(t, map[string]MCPConfig{
+		"first": {Type: utils.Ptr("http"), Url: utils.Ptr(first.URL)},
+	})
+	waitForStatus(t, m, "first", MCPStatusReady)
+
+	writeMCPConfig(t, configPath, map[string]MCPConfig{
+		"second": {Type: utils.Ptr("http"), Url: utils.Ptr(second.URL)},
+		"off":    {Type: utils.Ptr("http"), Url: utils.Ptr(second.URL), Enable: utils.Ptr(false)},
+	})
+	if err := m.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	waitForStatus(t, m, "second", MCPStatusReady)
+	waitForStatus(t, m, "off", MCPStatusDisabled)
+	for _, info := range m.ListServers() {
+		if info.Name == "first" {
+			t.Fatal("removed server is still listed")
+		}
+	}
+	if out := callEcho(t, m, "second_echo"); !strings.Contains(out, "echo: hi") {
+		t.Fatalf("unexpected tool output: %s", out)
+	}
+}
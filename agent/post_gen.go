@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/Mirai3103/Project-Re-ENE/llm"
 	"github.com/Mirai3103/Project-Re-ENE/package/utils"
 	"github.com/Mirai3103/Project-Re-ENE/store"
 	"github.com/firebase/genkit/go/ai"
@@ -39,17 +40,18 @@ type ExtractInput struct {
 }
 type ExtractMemoryFlow = core.Flow[ExtractInput, ExtractOutput, struct{}]
 
-func NewExtractMemoryFlow(g *genkit.Genkit, m ai.ModelArg, embeddingService *EmbeddingService) *core.Flow[ExtractInput, ExtractOutput, struct{}] {
+func NewExtractMemoryFlow(model *llm.Model, embeddingService *EmbeddingService) *core.Flow[ExtractInput, ExtractOutput, struct{}] {
 	return genkit.DefineFlow(
-		g,
+		model.G,
 		"extractMemoryFlow",
 		func(ctx context.Context, in ExtractInput) (ExtractOutput, error) {
 			conversationText := ConversationToText(in.ChatHistory)
-			resp, err := genkit.Generate(ctx, g,
+			resp, err := genkit.Generate(ctx, model.G,
 				ai.WithPrompt(conversationText),
 				ai.WithOutputType(ExtractOutput{}),
 				ai.WithSystem(NewExtractPrompt(in.UserFacts, in.CharacterFacts, in.User, in.Character, in.ChatHistory)),
-				ai.WithModel(m),
+				ai.WithModel(model.Ref),
+				ai.WithMiddleware(model.Fallback),
 			)
 			if err != nil {
 				return ExtractOutput{}, err
@@ -92,16 +94,17 @@ func NewExtractMemoryFlow(g *genkit.Genkit, m ai.ModelArg, embeddingService *Emb
 
 type SummaryFlow = core.Flow[ExtractInput, string, struct{}]
 
-func NewGenSummaryFlow(g *genkit.Genkit, m ai.ModelArg) *core.Flow[ExtractInput, string, struct{}] {
+func NewGenSummaryFlow(model *llm.Model) *core.Flow[ExtractInput, string, struct{}] {
 	return genkit.DefineFlow(
-		g,
+		model.G,
 		"genSummaryFlow",
 		func(ctx context.Context, in ExtractInput) (string, error) {
 			conversationText := ConversationToText(in.ChatHistory)
-			resp, err := genkit.Generate(ctx, g,
+			resp, err := genkit.Generate(ctx, model.G,
 				ai.WithPrompt(conversationText),
 				ai.WithSystem(NewSummaryPrompt(in.Character, in.User, in.ChatHistory)),
-				ai.WithModel(m),
+				ai.WithModel(model.Ref),
+				ai.WithMiddleware(model.Fallback),
 			)
 			if err != nil {
 				return "", err
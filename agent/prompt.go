@@ -23,6 +23,7 @@ func NewPrompt(userFacts []store.UserFact, characterFacts []store.CharacterFact,
 		{{ .Name }}: {{ .Value }}
 		{{ end }}
 		Chỉ trả lời ngắn gọn, từ 1 đến 3 câu trừ khi cần thiết. Thời gian bây giờ là {{ .now }}
+		{{ .emotion_prompt }}
 `
 	t := template.Must(template.New("system_prompt").Parse(promptTemplate))
 	var values = map[string]any{
@@ -32,6 +33,7 @@ func NewPrompt(userFacts []store.UserFact, characterFacts []store.CharacterFact,
 		"name":                  user.Name,
 		"bio":                   user.Bio,
 		"now":                   time.Now().Format("2006-01-02 15:04:05"),
+		"emotion_prompt":        emotionPrompt(),
 	}
 	var prompt strings.Builder
 	t.Execute(&prompt, values)
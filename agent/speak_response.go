@@ -2,11 +2,23 @@ package agent
 
 import (
 	"encoding/base64"
+
+	"github.com/Mirai3103/Project-Re-ENE/live2d"
+	"github.com/Mirai3103/Project-Re-ENE/package/lipsync"
 )
 
 type SpeakResponse struct {
 	Text        string `json:"text"`
 	AudioBuffer []byte `json:"audio_buffer"`
+	// Emotion is the sentence's tag, Motion what the active model plays for
+	// it. Motion is nil when the model has nothing fitting.
+	Emotion string         `json:"emotion,omitempty"`
+	Motion  *live2d.Motion `json:"motion,omitempty"`
+	// LipSync is nil when the audio could not be decoded.
+	LipSync *lipsync.Data `json:"lip_sync,omitempty"`
+	// Err is set on the last response of a turn whose generation failed.
+	// That response carries no text or audio.
+	Err error `json:"-"`
 }
 
 func (s *SpeakResponse) ToBase64() string {
@@ -0,0 +1,181 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	tool "github.com/Mirai3103/Project-Re-ENE/config/tool"
+	"github.com/Mirai3103/Project-Re-ENE/package/utils"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+	"github.com/Mirai3103/Project-Re-ENE/telemetry"
+	"github.com/firebase/genkit/go/ai"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ToolConfirmRequest is sent to the user when a tool with the "ask" policy is called.
+type ToolConfirmRequest struct {
+	ID             string `json:"id"`
+	ToolName       string `json:"tool_name"`
+	Description    string `json:"description"`
+	Arguments      string `json:"arguments"`
+	ConversationID string `json:"conversation_id"`
+}
+
+// ToolConfirmer asks the user whether a tool call may run. It blocks until the
+// user answers or ctx is done.
+type ToolConfirmer interface {
+	Confirm(ctx context.Context, req ToolConfirmRequest) (bool, error)
+}
+
+const (
+	ToolDecisionAllowed   = "allowed"
+	ToolDecisionApproved  = "approved"
+	ToolDecisionRejected  = "rejected"
+	ToolDecisionTimeout   = "timeout"
+	ToolDecisionCancelled = "cancelled"
+)
+
+var ErrToolRejected = errors.New("tool call rejected by user")
+
+// ErrNoConfirmer is returned by a ToolConfirmer that has no one to ask, such
+// as the desktop app running headless. The call then follows the unattended
+// policy instead of ask.
+var ErrNoConfirmer = errors.New("no one can confirm the tool call")
+
+const defaultToolConfirmTimeout = 60 * time.Second
+
+// Where a tool comes from, the provider of its telemetry.
+const (
+	toolSourceLocal = "local"
+	toolSourceMCP   = "mcp"
+)
+
+// guardTools wraps every tool with the permission check, audit log and
+// tracing. Tools with the deny policy are dropped so the model never sees
+// them.
+func (a *Agent) guardTools(tools []ai.Tool, source string) []ai.Tool {
+	permissions := &a.config().ToolsConfig.Permissions
+	guarded := make([]ai.Tool, 0, len(tools))
+	for _, t := range tools {
+		permission := permissions.Resolve(t.Name())
+		if permission == tool.PermissionDeny {
+			a.logger.Debug("Tool denied by policy", "name", t.Name())
+			continue
+		}
+		guarded = append(guarded, a.guardTool(t, source))
+	}
+	return guarded
+}
+
+func (a *Agent) guardTool(t ai.Tool, source string) ai.Tool {
+	def := t.Definition()
+	fn := func(ctx *ai.ToolContext, input any) (any, error) {
+		return a.runGuardedTool(ctx, t, source, input)
+	}
+	if len(def.InputSchema) > 0 {
+		return ai.NewToolWithInputSchema(def.Name, def.Description, def.InputSchema, fn)
+	}
+	return ai.NewTool(def.Name, def.Description, fn)
+}
+
+func (a *Agent) runGuardedTool(ctx context.Context, t ai.Tool, source string, input any) (output any, err error) {
+	start := time.Now()
+	permission := a.config().ToolsConfig.Permissions.Resolve(t.Name())
+	decision := ToolDecisionAllowed
+	ctx, call := telemetry.StartCall(ctx, telemetry.KindTool, source, t.Name())
+	defer func() {
+		call.SetAttributes(attribute.String("ene.tool.permission", string(permission)), attribute.String("ene.tool.decision", decision))
+		call.End(err)
+	}()
+
+	if permission == tool.PermissionAsk {
+		approved, err := a.confirmTool(ctx, t, input)
+		switch {
+		case ctx.Err() != nil:
+			// the turn itself was cancelled while waiting, not the user's answer
+			decision = ToolDecisionCancelled
+			a.logger.Info("Tool confirmation cancelled", "tool", t.Name(), "error", ctx.Err())
+			a.auditTool(ctx, t.Name(), permission, decision, input, nil, ctx.Err(), time.Since(start))
+			return nil, ctx.Err()
+		case errors.Is(err, ErrNoConfirmer):
+			if a.config().ToolsConfig.Permissions.ResolveUnattended() == tool.PermissionAllow {
+				decision = ToolDecisionAllowed
+				break
+			}
+			a.logger.Warn("Tool rejected, nobody can confirm it and the unattended policy is deny", "tool", t.Name())
+			decision = ToolDecisionRejected
+		case errors.Is(err, context.DeadlineExceeded):
+			decision = ToolDecisionTimeout
+		case err != nil:
+			a.logger.Error("Tool confirmation failed", "tool", t.Name(), "error", err)
+			decision = ToolDecisionRejected
+		case approved:
+			decision = ToolDecisionApproved
+		default:
+			decision = ToolDecisionRejected
+		}
+		if decision != ToolDecisionApproved && decision != ToolDecisionAllowed {
+			// tell the model instead of failing the whole generation
+			output := map[string]any{"error": ErrToolRejected.Error()}
+			a.auditTool(ctx, t.Name(), permission, decision, input, output, nil, time.Since(start))
+			return output, nil
+		}
+	}
+
+	output, err = t.RunRaw(ctx, input)
+	a.auditTool(ctx, t.Name(), permission, decision, input, output, err, time.Since(start))
+	return output, err
+}
+
+func (a *Agent) confirmTool(ctx context.Context, t ai.Tool, input any) (bool, error) {
+	if a.toolConfirmer == nil {
+		return false, ErrNoConfirmer
+	}
+	timeout := defaultToolConfirmTimeout
+	if seconds := a.config().ToolsConfig.Permissions.ConfirmTimeout; seconds > 0 {
+		timeout = time.Duration(seconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	args, _ := json.Marshal(input)
+	conversationID, _ := ctx.Value(ConversationID).(string)
+	return a.toolConfirmer.Confirm(ctx, ToolConfirmRequest{
+		ID:             uuid.New().String(),
+		ToolName:       t.Name(),
+		Description:    t.Definition().Description,
+		Arguments:      string(args),
+		ConversationID: conversationID,
+	})
+}
+
+func (a *Agent) auditTool(ctx context.Context, name string, permission tool.ToolPermission, decision string, input any, output any, runErr error, duration time.Duration) {
+	args, _ := json.Marshal(input)
+	result, _ := json.Marshal(output)
+	var errText *string
+	if runErr != nil {
+		errText = utils.Ptr(runErr.Error())
+	}
+	var conversationID *string
+	if id, ok := ctx.Value(ConversationID).(string); ok {
+		conversationID = utils.Ptr(id)
+	}
+	// the tool context may already be cancelled, the audit row must still be written
+	err := a.store.CreateToolAudit(context.WithoutCancel(ctx), store.CreateToolAuditParams{
+		ID:             uuid.New().String(),
+		ConversationID: conversationID,
+		ToolName:       utils.Ptr(name),
+		Permission:     utils.Ptr(string(permission)),
+		Decision:       utils.Ptr(decision),
+		Arguments:      args,
+		Result:         result,
+		Error:          errText,
+		DurationMs:     utils.Ptr(duration.Milliseconds()),
+	})
+	if err != nil {
+		a.logger.Error("Lỗi khi lưu audit tool", "tool", name, "error", err)
+	}
+}
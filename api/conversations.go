@@ -0,0 +1,164 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/package/utils"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+	"github.com/firebase/genkit/go/ai"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+const defaultConversationLimit = 20
+
+type Conversation struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title,omitempty"`
+	Summary     string     `json:"summary,omitempty"`
+	UserID      string     `json:"user_id,omitempty"`
+	CharacterID string     `json:"character_id,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+type Message struct {
+	ID        string     `json:"id"`
+	Role      string     `json:"role"`
+	Text      string     `json:"text"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+type createConversationRequest struct {
+	ID string `json:"id"`
+}
+
+type sendMessageRequest struct {
+	Text string `json:"text"`
+}
+
+type sendMessageResponse struct {
+	ConversationID string `json:"conversation_id"`
+	Reply          string `json:"reply"`
+}
+
+func toConversation(c store.Conversation) Conversation {
+	return Conversation{
+		ID:          c.ID,
+		Title:       utils.OrDefault(c.Title, ""),
+		Summary:     utils.OrDefault(c.CurrentSummary, ""),
+		UserID:      utils.OrDefault(c.UserID, ""),
+		CharacterID: utils.OrDefault(c.CharacterID, ""),
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+func toMessage(m store.ConversationMessage) Message {
+	var msg ai.Message
+	text := string(m.Content)
+	if err := json.Unmarshal(m.Content, &msg); err == nil {
+		text = agent.StripEmotionTags(msg.Text())
+	}
+	return Message{
+		ID:        m.ID,
+		Role:      utils.OrDefault(m.Role, ""),
+		Text:      text,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func (s *Server) listConversations(w http.ResponseWriter, r *http.Request) {
+	conversations, err := s.store.ListConversations(r.Context(), int64(queryInt(r, "limit", defaultConversationLimit)))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	result := make([]Conversation, 0, len(conversations))
+	for _, c := range conversations {
+		result = append(result, toConversation(c))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) createConversation(w http.ResponseWriter, r *http.Request) {
+	var req createConversationRequest
+	if r.ContentLength != 0 {
+		if err := decodeJSON(w, r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	if req.ID == "" {
+		req.ID = uuid.New().String()
+	}
+	conversation, err := s.store.CreateConversationIfNotExists(r.Context(), store.CreateConversationParams{
+		ID:          req.ID,
+		UserID:      utils.Ptr(s.cfg.UserID),
+		CharacterID: utils.Ptr(s.cfg.CharacterID),
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toConversation(conversation))
+}
+
+func (s *Server) getConversation(w http.ResponseWriter, r *http.Request) {
+	conversation, err := s.store.GetConversation(r.Context(), chi.URLParam(r, "id"))
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, errors.New("conversation not found"))
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toConversation(conversation))
+}
+
+func (s *Server) listMessages(w http.ResponseWriter, r *http.Request) {
+	messages, err := s.store.ListConversationMessages(r.Context(), utils.Ptr(chi.URLParam(r, "id")))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	result := make([]Message, 0, len(messages))
+	for _, m := range messages {
+		result = append(result, toMessage(m))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// sendMessage runs one turn and answers with the whole reply. Use the
+// websocket to stream text and audio instead.
+func (s *Server) sendMessage(w http.ResponseWriter, r *http.Request) {
+	var req sendMessageRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if strings.TrimSpace(req.Text) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("text is required"))
+		return
+	}
+	conversationID := chi.URLParam(r, "id")
+	reply, err := s.agent.Chat(r.Context(), &agent.FlowInput{
+		Text:           req.Text,
+		ConversationID: conversationID,
+		UserID:         s.cfg.UserID,
+		CharacterID:    s.cfg.CharacterID,
+	})
+	if err != nil {
+		s.logger.Error("API chat failed", "conversation_id", conversationID, "error", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, sendMessageResponse{ConversationID: conversationID, Reply: reply})
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/package/utils"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMemoryLimit = 20
+	// memories further than this (L2 distance) are not considered related
+	memorySearchThreshold = 1.2
+)
+
+type Memory struct {
+	ID         string     `json:"id"`
+	Content    string     `json:"content"`
+	Tags       string     `json:"tags,omitempty"`
+	Source     string     `json:"source,omitempty"`
+	Importance float64    `json:"importance"`
+	Confidence float64    `json:"confidence"`
+	Distance   *float64   `json:"distance,omitempty"` // only set by search
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+}
+
+type createMemoryRequest struct {
+	Content    string   `json:"content"`
+	Tags       string   `json:"tags"`
+	Importance *float64 `json:"importance"`
+	Confidence *float64 `json:"confidence"`
+}
+
+func toMemory(m store.Memory) Memory {
+	return Memory{
+		ID:         m.ID,
+		Content:    utils.OrDefault(m.Content, ""),
+		Tags:       utils.OrDefault(m.Tags, ""),
+		Source:     utils.OrDefault(m.Source, ""),
+		Importance: utils.OrDefault(m.Importance, 0),
+		Confidence: utils.OrDefault(m.Confidence, 0),
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+func (s *Server) listMemories(w http.ResponseWriter, r *http.Request) {
+	memories, err := s.store.ListMemories(r.Context(), store.ListMemoriesParams{
+		Limit:  int64(queryInt(r, "limit", defaultMemoryLimit)),
+		Offset: int64(queryInt(r, "offset", 0)),
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	result := make([]Memory, 0, len(memories))
+	for _, m := range memories {
+		result = append(result, toMemory(m))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) searchMemories(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		writeError(w, http.StatusBadRequest, errors.New("q is required"))
+		return
+	}
+	vector, err := s.embeddingService.EmbedText(r.Context(), query)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	memories, err := s.store.SimilarMemories(r.Context(), vector, queryInt(r, "limit", defaultMemoryLimit), memorySearchThreshold)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	result := make([]Memory, 0, len(memories))
+	for _, m := range memories {
+		memory := toMemory(m.Memory)
+		memory.Distance = utils.Ptr(m.Score)
+		result = append(result, memory)
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) createMemory(w http.ResponseWriter, r *http.Request) {
+	var req createMemoryRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if strings.TrimSpace(req.Content) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("content is required"))
+		return
+	}
+	memory := &store.Memory{
+		ID:          uuid.New().String(),
+		UserID:      utils.Ptr(s.cfg.UserID),
+		CharacterID: utils.Ptr(s.cfg.CharacterID),
+		Content:     utils.Ptr(req.Content),
+		Importance:  req.Importance,
+		Confidence:  req.Confidence,
+		Source:      utils.Ptr("api"),
+	}
+	if req.Tags != "" {
+		memory.Tags = utils.Ptr(req.Tags)
+	}
+	if err := s.embeddingService.AddMemory(r.Context(), memory); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toMemory(*memory))
+}
+
+func (s *Server) deleteMemory(w http.ResponseWriter, r *http.Request) {
+	if err := s.store.DeleteMemory(r.Context(), chi.URLParam(r, "id")); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/package/httpserver"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Agent is what the API needs from agent.Agent.
+type Agent interface {
+	Chat(ctx context.Context, input *agent.FlowInput) (string, error)
+	InferSpeak(ctx context.Context, input *agent.FlowInput) (chan agent.SpeakResponse, error)
+}
+
+// Server is the headless HTTP/WebSocket API. It drives the same agent as the
+// window, so bots, scripts and tests do not need the GUI.
+type Server struct {
+	cfg              *config.APIServerConfig
+	agent            Agent
+	embeddingService *agent.EmbeddingService
+	store            *store.Queries
+	logger           *slog.Logger
+	router           *chi.Mux
+}
+
+func New(cfg *config.Config, ag *agent.Agent, embeddingService *agent.EmbeddingService, store *store.Queries, logger *slog.Logger) *Server {
+	return newServer(cfg, ag, embeddingService, store, logger)
+}
+
+func newServer(cfg *config.Config, ag Agent, embeddingService *agent.EmbeddingService, store *store.Queries, logger *slog.Logger) *Server {
+	s := &Server{
+		cfg:              &cfg.APIServerConfig,
+		agent:            ag,
+		embeddingService: embeddingService,
+		store:            store,
+		logger:           logger,
+		router:           chi.NewRouter(),
+	}
+	s.setupRoutes()
+	return s
+}
+
+func (s *Server) setupRoutes() {
+	s.router.Use(middleware.Recoverer)
+	s.router.Route("/api", func(r chi.Router) {
+		r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+		})
+		r.Group(func(r chi.Router) {
+			r.Use(httpserver.RequireToken(s.cfg.BearerToken, true))
+
+			r.Get("/conversations", s.listConversations)
+			r.Post("/conversations", s.createConversation)
+			r.Get("/conversations/{id}", s.getConversation)
+			r.Get("/conversations/{id}/messages", s.listMessages)
+			r.Post("/conversations/{id}/messages", s.sendMessage)
+
+			r.Get("/memories", s.listMemories)
+			r.Post("/memories", s.createMemory)
+			r.Get("/memories/search", s.searchMemories)
+			r.Delete("/memories/{id}", s.deleteMemory)
+
+			r.Get("/ws", s.serveWS)
+		})
+	})
+}
+
+func (s *Server) Handler() http.Handler {
+	return s.router
+}
+
+// Serve listens on the configured address until ctx is done.
+func (s *Server) Serve(ctx context.Context) error {
+	s.logger.Info("Starting API server", "address", s.cfg.Address)
+	return httpserver.Serve(ctx, s.cfg.Address, s.router)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	r.Body = http.MaxBytesReader(w, r.Body, 16<<20)
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+// queryInt reads a positive integer query parameter.
+func queryInt(r *http.Request, name string, defaultValue int) int {
+	value, err := strconv.Atoi(r.URL.Query().Get(name))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
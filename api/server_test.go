@@ -0,0 +1,265 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/package/utils"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+	"github.com/firebase/genkit/go/ai"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	_ "modernc.org/sqlite"
+)
+
+const testToken = "s3cret"
+
+// fakeAgent saves each turn like the real agent and answers with a fixed
+// prefix. InferSpeak fails with err when it is set.
+type fakeAgent struct {
+	store *store.Queries
+	err   error
+}
+
+func (a *fakeAgent) Chat(ctx context.Context, input *agent.FlowInput) (string, error) {
+	reply := "reply to " + input.Text
+	for _, msg := range []*ai.Message{ai.NewUserTextMessage(input.Text), ai.NewModelTextMessage("[happy] " + reply)} {
+		content, _ := json.Marshal(msg)
+		err := a.store.CreateConversationMessage(ctx, store.CreateConversationMessageParams{
+			ID:             uuid.New().String(),
+			ConversationID: utils.Ptr(input.ConversationID),
+			Content:        content,
+			Role:           utils.Ptr(string(msg.Role)),
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	return reply, nil
+}
+
+func (a *fakeAgent) InferSpeak(ctx context.Context, input *agent.FlowInput) (chan agent.SpeakResponse, error) {
+	ch := make(chan agent.SpeakResponse, 2)
+	go func() {
+		defer close(ch)
+		input.OnTextChunk("Hello")
+		ch <- agent.SpeakResponse{Text: "Hello", AudioBuffer: []byte("mp3"), Emotion: "happy"}
+		if a.err != nil {
+			ch <- agent.SpeakResponse{Err: a.err}
+		}
+	}()
+	return ch, nil
+}
+
+// testStore is an in-memory database with every migration applied.
+func testStore(t *testing.T) *store.Queries {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1) // every connection would get its own database
+	t.Cleanup(func() { db.Close() })
+	files, err := filepath.Glob("../store/migrations/*.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		schema, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(string(schema)); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+	}
+	return store.New(db)
+}
+
+func newTestServer(t *testing.T) (*httptest.Server, *fakeAgent) {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.APIServerConfig = config.APIServerConfig{Enable: true, BearerToken: testToken, UserID: "1", CharacterID: "1"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	queries := testStore(t)
+	ag := &fakeAgent{store: queries}
+	ts := httptest.NewServer(newServer(cfg, ag, nil, queries, logger).Handler())
+	t.Cleanup(ts.Close)
+	return ts, ag
+}
+
+// do sends a request with the test token and decodes the JSON answer into out.
+func do(t *testing.T, ts *httptest.Server, method, path string, body any, out any) int {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, ts.URL+path, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestRejectsMissingToken(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	resp, err := http.Get(ts.URL + "/api/health")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("health should not need the token, got %d", resp.StatusCode)
+	}
+
+	for _, header := range []string{"", "Bearer wrong", testToken} {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/api/conversations", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Authorization %q: got %d, want 401", header, resp.StatusCode)
+		}
+	}
+}
+
+func TestConversationRoundTrip(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	var created Conversation
+	if status := do(t, ts, http.MethodPost, "/api/conversations", createConversationRequest{ID: "c1"}, &created); status != http.StatusCreated {
+		t.Fatalf("create: got %d", status)
+	}
+	if created.ID != "c1" || created.UserID != "1" || created.CharacterID != "1" {
+		t.Fatalf("unexpected conversation: %+v", created)
+	}
+
+	var got Conversation
+	if status := do(t, ts, http.MethodGet, "/api/conversations/c1", nil, &got); status != http.StatusOK || got.ID != "c1" {
+		t.Fatalf("get: got %d %+v", status, got)
+	}
+	if status := do(t, ts, http.MethodGet, "/api/conversations/missing", nil, nil); status != http.StatusNotFound {
+		t.Fatalf("get missing: got %d, want 404", status)
+	}
+
+	var list []Conversation
+	if status := do(t, ts, http.MethodGet, "/api/conversations", nil, &list); status != http.StatusOK || len(list) != 1 || list[0].ID != "c1" {
+		t.Fatalf("list: got %d %+v", status, list)
+	}
+
+	var sent sendMessageResponse
+	if status := do(t, ts, http.MethodPost, "/api/conversations/c1/messages", sendMessageRequest{Text: "hi"}, &sent); status != http.StatusOK {
+		t.Fatalf("send: got %d", status)
+	}
+	if sent.ConversationID != "c1" || sent.Reply != "reply to hi" {
+		t.Fatalf("unexpected reply: %+v", sent)
+	}
+	if status := do(t, ts, http.MethodPost, "/api/conversations/c1/messages", sendMessageRequest{Text: " "}, nil); status != http.StatusBadRequest {
+		t.Fatalf("empty text: got %d, want 400", status)
+	}
+
+	var messages []Message
+	if status := do(t, ts, http.MethodGet, "/api/conversations/c1/messages", nil, &messages); status != http.StatusOK {
+		t.Fatalf("messages: got %d", status)
+	}
+	if len(messages) != 2 || messages[0].Text != "hi" || messages[1].Text != "reply to hi" {
+		t.Fatalf("unexpected messages: %+v", messages)
+	}
+}
+
+func dialWS(t *testing.T, ts *httptest.Server) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/api/ws?token=" + testToken
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn
+}
+
+// readTurn reads events until the turn ends with done or error.
+func readTurn(t *testing.T, conn *websocket.Conn) []WSEvent {
+	t.Helper()
+	var events []WSEvent
+	for {
+		var event WSEvent
+		if err := conn.ReadJSON(&event); err != nil {
+			t.Fatalf("read after %+v: %v", events, err)
+		}
+		events = append(events, event)
+		if event.Type == wsTypeDone || event.Type == wsTypeError {
+			return events
+		}
+	}
+}
+
+func TestWebSocketTurn(t *testing.T) {
+	ts, ag := newTestServer(t)
+	conn := dialWS(t, ts)
+
+	if err := conn.WriteJSON(WSRequest{Type: wsTypeChat, ConversationID: "c1", Text: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+	events := readTurn(t, conn)
+	if len(events) != 3 {
+		t.Fatalf("expected text, audio and done, got %+v", events)
+	}
+	if events[0].Type != wsTypeText || events[0].Text != "Hello" {
+		t.Errorf("unexpected text event: %+v", events[0])
+	}
+	if events[1].Type != wsTypeAudio || events[1].Audio != base64.StdEncoding.EncodeToString([]byte("mp3")) || events[1].Emotion != "happy" {
+		t.Errorf("unexpected audio event: %+v", events[1])
+	}
+	if events[2].Type != wsTypeDone || events[2].ConversationID != "c1" {
+		t.Errorf("unexpected done event: %+v", events[2])
+	}
+
+	ag.err = errors.New("model down")
+	if err := conn.WriteJSON(WSRequest{Type: wsTypeChat, ConversationID: "c1", Text: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+	events = readTurn(t, conn)
+	last := events[len(events)-1]
+	if last.Type != wsTypeError || last.Error != "model down" || last.ConversationID != "c1" {
+		t.Fatalf("failed turn should end with an error frame, got %+v", events)
+	}
+}
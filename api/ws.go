@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/live2d"
+	"github.com/Mirai3103/Project-Re-ENE/package/lipsync"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// Client → server message types.
+const (
+	wsTypeChat   = "chat"
+	wsTypeCancel = "cancel"
+)
+
+// Server → client event types. A turn sends any number of text and audio
+// events and ends with done or error.
+const (
+	wsTypeText  = "text"
+	wsTypeAudio = "audio"
+	wsTypeDone  = "done"
+	wsTypeError = "error"
+)
+
+// WSRequest starts or cancels a turn. Audio is base64 and is transcribed when
+// text is empty.
+type WSRequest struct {
+	Type           string `json:"type"`
+	ConversationID string `json:"conversation_id"`
+	Text           string `json:"text"`
+	Audio          string `json:"audio"`
+}
+
+type WSEvent struct {
+	Type           string         `json:"type"`
+	ConversationID string         `json:"conversation_id,omitempty"`
+	Text           string         `json:"text,omitempty"`
+	Audio          string         `json:"audio,omitempty"` // base64 mp3 of Text
+	Emotion        string         `json:"emotion,omitempty"`
+	Motion         *live2d.Motion `json:"motion,omitempty"`
+	LipSync        *lipsync.Data  `json:"lip_sync,omitempty"`
+	Error          string         `json:"error,omitempty"`
+}
+
+var upgrader = websocket.Upgrader{
+	// the api is meant for local tools, the token guards it when exposed
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+type wsConn struct {
+	conn *websocket.Conn
+	mu   sync.Mutex // gorilla allows a single concurrent writer
+}
+
+func (c *wsConn) send(event WSEvent) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.WriteJSON(event)
+}
+
+func (s *Server) serveWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.logger.Error("Websocket upgrade failed", "error", err)
+		return
+	}
+	ws := &wsConn{conn: conn}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// one turn at a time per connection
+	var (
+		turnMu     sync.Mutex
+		cancelTurn context.CancelFunc
+		turnDone   chan struct{}
+	)
+	defer func() {
+		turnMu.Lock()
+		done := turnDone
+		turnMu.Unlock()
+		cancel()
+		if done != nil {
+			<-done
+		}
+	}()
+
+	for {
+		var req WSRequest
+		if err := conn.ReadJSON(&req); err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				s.logger.Warn("Websocket read failed", "error", err)
+			}
+			return
+		}
+
+		switch req.Type {
+		case wsTypeCancel:
+			turnMu.Lock()
+			if cancelTurn != nil {
+				cancelTurn()
+			}
+			turnMu.Unlock()
+		case wsTypeChat:
+			turnMu.Lock()
+			busy := turnDone != nil
+			if !busy {
+				var turnCtx context.Context
+				turnCtx, cancelTurn = context.WithCancel(ctx)
+				turnDone = make(chan struct{})
+				done := turnDone
+				go func() {
+					last := s.runTurn(turnCtx, ws, req)
+					turnMu.Lock()
+					cancelTurn()
+					cancelTurn = nil
+					turnDone = nil
+					turnMu.Unlock()
+					// the connection takes a new turn once the client sees the end of this one
+					ws.send(last)
+					close(done)
+				}()
+			}
+			turnMu.Unlock()
+			if busy {
+				ws.send(WSEvent{Type: wsTypeError, ConversationID: req.ConversationID, Error: "a turn is already running"})
+			}
+		default:
+			ws.send(WSEvent{Type: wsTypeError, Error: "unknown message type: " + req.Type})
+		}
+	}
+}
+
+// runTurn streams one turn and returns the done or error event that ends it.
+func (s *Server) runTurn(ctx context.Context, ws *wsConn, req WSRequest) WSEvent {
+	conversationID := req.ConversationID
+	if conversationID == "" {
+		conversationID = uuid.New().String()
+	}
+	fail := func(err error) WSEvent {
+		return WSEvent{Type: wsTypeError, ConversationID: conversationID, Error: err.Error()}
+	}
+
+	input := &agent.FlowInput{
+		Text:           req.Text,
+		ConversationID: conversationID,
+		UserID:         s.cfg.UserID,
+		CharacterID:    s.cfg.CharacterID,
+		OnTextChunk: func(text string) {
+			ws.send(WSEvent{Type: wsTypeText, ConversationID: conversationID, Text: text})
+		},
+	}
+	if req.Text == "" && req.Audio != "" {
+		audio, err := base64.StdEncoding.DecodeString(req.Audio)
+		if err != nil {
+			return fail(errors.New("audio must be base64"))
+		}
+		input.Audio = audio
+	}
+
+	speakChan, err := s.agent.InferSpeak(ctx, input)
+	if err != nil {
+		return fail(err)
+	}
+	for speakResponse := range speakChan {
+		if speakResponse.Err != nil {
+			return fail(speakResponse.Err)
+		}
+		ws.send(WSEvent{
+			Type:           wsTypeAudio,
+			ConversationID: conversationID,
+			Text:           speakResponse.Text,
+			Audio:          speakResponse.ToBase64(),
+			Emotion:        speakResponse.Emotion,
+			Motion:         speakResponse.Motion,
+			LipSync:        speakResponse.LipSync,
+		})
+	}
+	if err := ctx.Err(); err != nil {
+		return fail(err)
+	}
+	return WSEvent{Type: wsTypeDone, ConversationID: conversationID}
+}
@@ -3,31 +3,55 @@ package asr
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/Mirai3103/Project-Re-ENE/config/asr"
+	"github.com/Mirai3103/Project-Re-ENE/metering"
 	"github.com/Mirai3103/Project-Re-ENE/package/elevenlabs"
 	"github.com/Mirai3103/Project-Re-ENE/package/utils"
+	"github.com/Mirai3103/Project-Re-ENE/telemetry"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type elevenlabsASRAgent struct {
 	client *elevenlabs.Client
 	cfg    *asr.ElevenLabsConfig
+	meter  *metering.Meter
 	logger *slog.Logger
 }
 
-func newElevenlabsASRAgent(cfg *asr.ElevenLabsConfig, logger *slog.Logger) ASRAgent {
+func newElevenlabsASRAgent(cfg *asr.ElevenLabsConfig, meter *metering.Meter, logger *slog.Logger) ASRAgent {
 	client := elevenlabs.NewClient(elevenlabs.NewClientOptions{
 		APIKey: cfg.APIKey,
 	}, logger)
-	return &elevenlabsASRAgent{client: client, cfg: cfg, logger: logger}
+	return &elevenlabsASRAgent{client: client, cfg: cfg, meter: meter, logger: logger}
 }
 func (a *elevenlabsASRAgent) GetASR(ctx context.Context, audioData []byte) (string, error) {
-
+	if err := a.meter.Allow(ctx); err != nil {
+		return "", err
+	}
+	ctx, call := telemetry.StartCall(ctx, telemetry.KindASR, "elevenlabs", a.cfg.ModelID)
+	start := time.Now()
 	response, err := a.client.CreateTranscript(ctx, audioData, elevenlabs.CreateTranscriptOptions{
 		ModelID:        a.cfg.ModelID,
 		LanguageCode:   utils.Ptr(a.cfg.LanguageCode),
 		TagAudioEvents: utils.Ptr(false),
 	})
+	event := metering.Event{
+		Kind:         metering.KindASR,
+		Provider:     "elevenlabs",
+		Model:        a.cfg.ModelID,
+		AudioSeconds: metering.WAVSeconds(audioData),
+		Latency:      time.Since(start),
+		Err:          err,
+	}
+	// the last word ends close to the end of the audio
+	if event.AudioSeconds == 0 && response != nil && len(response.Words) > 0 {
+		event.AudioSeconds = response.Words[len(response.Words)-1].End
+	}
+	call.SetAttributes(attribute.Float64("ene.audio_seconds", event.AudioSeconds))
+	call.End(err)
+	a.meter.Record(ctx, event)
 	if err != nil {
 		return "", err
 	}
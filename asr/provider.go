@@ -6,6 +6,7 @@ import (
 	"log/slog"
 
 	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/metering"
 )
 
 type ASRAgent interface {
@@ -16,10 +17,10 @@ type ASRProvider interface {
 	GetASRAgent() (ASRAgent, error)
 }
 
-func New(cfg *config.Config, logger *slog.Logger) (ASRAgent, error) {
+func New(cfg *config.Config, meter *metering.Meter, logger *slog.Logger) (ASRAgent, error) {
 	switch cfg.ASRConfig.Provider {
 	case "elevenlabs":
-		return newElevenlabsASRAgent(cfg.ASRConfig.ElevenLabsConfig, logger), nil
+		return newElevenlabsASRAgent(cfg.ASRConfig.ElevenLabsConfig, meter, logger), nil
 	default:
 		return nil, fmt.Errorf("asr provider not found")
 	}
@@ -0,0 +1,263 @@
+// Command ene talks to Ene from the terminal.
+//
+//	ene [flags]                 interactive chat
+//	ene [flags] ask <message>   send one message and exit
+//	echo "hi" | ene [flags]     send piped text as one message
+//	ene [flags] config          print the effective config and where each value came from
+//
+// The dependency graph is the same as the desktop app's, built by wire.
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/logging"
+	"github.com/Mirai3103/Project-Re-ENE/package/audio"
+)
+
+var (
+	configPath     = flag.String("config", "config.yaml", "config file")
+	conversationID = flag.String("conversation", "cli", "conversation to continue")
+	userID         = flag.String("user", "huuhoang", "user the conversation belongs to")
+	characterID    = flag.String("character", "1", "character to talk to")
+	audioOut       = flag.String("audio-out", "", "append the spoken replies as mp3 to this file")
+	speak          = flag.Bool("speak", false, "play the spoken replies")
+	verbose        = flag.Bool("v", false, "log at the configured level instead of warn")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: ene [flags] [ask <message> | config]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	if flag.Arg(0) == "config" {
+		return printConfig(os.Stdout)
+	}
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	logger, closeLog, err := newLogger(cfg, *verbose)
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+	term := newTerminal(os.Stdin, os.Stderr)
+	cli, err := initializeCLI(ctx, cfg, logger, term)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// export what the last turns left behind, without hanging on a dead collector
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := cli.Telemetry.Shutdown(ctx); err != nil {
+			fmt.Fprintln(os.Stderr, "telemetry shutdown failed:", err)
+		}
+	}()
+	if err := cli.Agent.Compile(ctx); err != nil {
+		return err
+	}
+
+	out, err := newAudioOutput(*audioOut, *speak)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	chat := &session{agent: cli.Agent, audio: out}
+
+	switch flag.Arg(0) {
+	case "ask":
+		message := strings.Join(flag.Args()[1:], " ")
+		if strings.TrimSpace(message) == "" {
+			return errors.New("ask needs a message")
+		}
+		return chat.Turn(ctx, message)
+	case "":
+		if !term.interactive {
+			return chat.Turn(ctx, term.ReadAll(ctx))
+		}
+		return chat.REPL(ctx, term)
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command: %s", flag.Arg(0))
+	}
+}
+
+// printConfig explains the config without loading it, so it also works when
+// the config does not validate.
+func printConfig(w io.Writer) error {
+	origins, err := config.Explain(*configPath)
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, o := range origins {
+		source := o.Layer
+		if o.File != "" {
+			source += " (" + o.File + ")"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", o.Path, o.Value, source)
+	}
+	return tw.Flush()
+}
+
+// newLogger builds the logger the config describes, redaction included.
+// Without -v only warnings reach the terminal, so they don't mix with the
+// reply.
+func newLogger(cfg *config.Config, verbose bool) (*slog.Logger, func(), error) {
+	if !verbose {
+		quiet := *cfg
+		quiet.LoggerConfig.Level = "warn"
+		cfg = &quiet
+	}
+	l, closeLog, err := logging.New(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return l.Logger, closeLog, nil
+}
+
+type session struct {
+	agent *agent.Agent
+	audio *audioOutput
+}
+
+func (s *session) REPL(ctx context.Context, term *terminal) error {
+	fmt.Fprintln(os.Stderr, "Type a message, Ctrl+D to quit.")
+	for {
+		fmt.Fprint(os.Stderr, "> ")
+		line, ok := term.ReadLine(ctx)
+		if !ok {
+			fmt.Fprintln(os.Stderr)
+			return nil
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if err := s.Turn(ctx, line); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			fmt.Fprintln(os.Stderr, "error:", err)
+		}
+	}
+}
+
+// Turn prints the reply as it streams. Speech is only synthesized when it is
+// written to a file or played.
+func (s *session) Turn(ctx context.Context, message string) error {
+	input := &agent.FlowInput{
+		Text:           message,
+		ConversationID: *conversationID,
+		UserID:         *userID,
+		CharacterID:    *characterID,
+		OnTextChunk: func(text string) {
+			fmt.Print(text)
+		},
+	}
+	defer fmt.Println()
+
+	if !s.audio.Enabled() {
+		_, err := s.agent.Chat(ctx, input)
+		return err
+	}
+	speakChan, err := s.agent.InferSpeak(ctx, input)
+	if err != nil {
+		return err
+	}
+	for speakResponse := range speakChan {
+		if speakResponse.Err != nil {
+			return speakResponse.Err
+		}
+		if err := s.audio.Write(speakResponse.AudioBuffer); err != nil {
+			return err
+		}
+	}
+	s.audio.Wait()
+	return ctx.Err()
+}
+
+// audioOutput writes spoken replies to a file and/or the speakers.
+type audioOutput struct {
+	file   *os.File
+	player audio.Player
+}
+
+func newAudioOutput(path string, play bool) (*audioOutput, error) {
+	out := &audioOutput{}
+	if path != "" {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, err
+		}
+		out.file = file
+	}
+	if play {
+		player, err := audio.NewAudioPlayer()
+		if err != nil {
+			out.Close()
+			return nil, err
+		}
+		out.player = player
+	}
+	return out, nil
+}
+
+func (o *audioOutput) Enabled() bool {
+	return o.file != nil || o.player != nil
+}
+
+func (o *audioOutput) Write(mp3 []byte) error {
+	if o.file != nil {
+		if _, err := o.file.Write(mp3); err != nil {
+			return err
+		}
+	}
+	if o.player != nil {
+		o.player.Play(bytes.NewReader(mp3))
+	}
+	return nil
+}
+
+// Wait blocks until the queued audio has been played.
+func (o *audioOutput) Wait() {
+	if o.player != nil {
+		o.player.Wait()
+	}
+}
+
+func (o *audioOutput) Close() {
+	if o.player != nil {
+		o.player.Wait()
+		o.player.Close()
+	}
+	if o.file != nil {
+		o.file.Close()
+	}
+}
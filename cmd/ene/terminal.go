@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+)
+
+var errNotInteractive = fmt.Errorf("stdin is not a terminal: %w", agent.ErrNoConfirmer)
+
+// terminal reads stdin on a single goroutine so the prompt and tool
+// confirmations never race for the same line.
+type terminal struct {
+	lines       chan string
+	out         io.Writer
+	interactive bool
+}
+
+func newTerminal(in *os.File, out io.Writer) *terminal {
+	t := &terminal{
+		lines:       make(chan string),
+		out:         out,
+		interactive: isTerminal(in),
+	}
+	go func() {
+		defer close(t.lines)
+		scanner := bufio.NewScanner(in)
+		scanner.Buffer(make([]byte, 64*1024), 1<<20)
+		for scanner.Scan() {
+			t.lines <- scanner.Text()
+		}
+	}()
+	return t
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// ReadLine returns false once stdin is closed or ctx is done.
+func (t *terminal) ReadLine(ctx context.Context) (string, bool) {
+	select {
+	case line, ok := <-t.lines:
+		return line, ok
+	case <-ctx.Done():
+		return "", false
+	}
+}
+
+// ReadAll returns everything piped into stdin.
+func (t *terminal) ReadAll(ctx context.Context) string {
+	var lines []string
+	for {
+		line, ok := t.ReadLine(ctx)
+		if !ok {
+			return strings.Join(lines, "\n")
+		}
+		lines = append(lines, line)
+	}
+}
+
+// Confirm implements agent.ToolConfirmer.
+func (t *terminal) Confirm(ctx context.Context, req agent.ToolConfirmRequest) (bool, error) {
+	if !t.interactive {
+		return false, errNotInteractive
+	}
+	fmt.Fprintf(t.out, "\nAllow tool %s with %s? [y/N] ", req.ToolName, req.Arguments)
+	line, ok := t.ReadLine(ctx)
+	if !ok {
+		if err := ctx.Err(); err != nil {
+			fmt.Fprintln(t.out)
+			return false, err
+		}
+		return false, nil
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes", nil
+}
@@ -0,0 +1,31 @@
+//go:build wireinject
+
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/providers"
+	"github.com/Mirai3103/Project-Re-ENE/telemetry"
+	"github.com/google/wire"
+)
+
+// CLI holds what the terminal client needs from the dependency graph
+type CLI struct {
+	Agent     *agent.Agent
+	Telemetry *telemetry.Telemetry
+}
+
+// initializeCLI builds the same agent as the desktop app, tool calls are
+// confirmed in the terminal
+func initializeCLI(ctx context.Context, cfg *config.Config, logger *slog.Logger, term *terminal) (*CLI, error) {
+	wire.Build(
+		providers.CoreSet,
+		wire.Bind(new(agent.ToolConfirmer), new(*terminal)),
+		wire.Struct(new(CLI), "*"),
+	)
+	return nil, nil
+}
@@ -0,0 +1,73 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"context"
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/asr"
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/embedding"
+	"github.com/Mirai3103/Project-Re-ENE/live2d"
+	"github.com/Mirai3103/Project-Re-ENE/llm"
+	"github.com/Mirai3103/Project-Re-ENE/metering"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+	"github.com/Mirai3103/Project-Re-ENE/telemetry"
+	"github.com/Mirai3103/Project-Re-ENE/tts"
+	"log/slog"
+)
+
+// Injectors from wire.go:
+
+// initializeCLI builds the same agent as the desktop app, tool calls are
+// confirmed in the terminal
+func initializeCLI(ctx context.Context, cfg *config.Config, logger *slog.Logger, term *terminal) (*CLI, error) {
+	live := config.NewLive(cfg)
+	db, err := store.NewSQLiteDB()
+	if err != nil {
+		return nil, err
+	}
+	queries := store.New(db)
+	meter := metering.New(live, queries, logger)
+	models, err := llm.NewModels(ctx, cfg, meter, logger)
+	if err != nil {
+		return nil, err
+	}
+	model, err := embedding.New(ctx, cfg, meter)
+	if err != nil {
+		return nil, err
+	}
+	embeddingService := agent.NewEmbeddingService(cfg, logger, model, queries)
+	ttsAgent, err := tts.New(cfg, meter, logger)
+	if err != nil {
+		return nil, err
+	}
+	asrAgent, err := asr.New(cfg, meter, logger)
+	if err != nil {
+		return nil, err
+	}
+	mcpManager := agent.NewMCPManager(live, logger)
+	mapper := live2d.NewMapper(live, logger)
+	agentAgent := agent.NewAgent(models, embeddingService, ttsAgent, asrAgent, queries, live, term, mcpManager, mapper, logger)
+	telemetryTelemetry, err := telemetry.New(ctx, cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	cli := &CLI{
+		Agent:     agentAgent,
+		Telemetry: telemetryTelemetry,
+	}
+	return cli, nil
+}
+
+// wire.go:
+
+// CLI holds what the terminal client needs from the dependency graph
+type CLI struct {
+	Agent     *agent.Agent
+	Telemetry *telemetry.Telemetry
+}
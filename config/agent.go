@@ -7,7 +7,7 @@ import (
 )
 
 type ShortTermMemoryConfig struct {
-	MaxWindowSize int `yaml:"max_window_size"`
+	MaxWindowSize int `yaml:"max_window_size" json:"max_window_size" jsonschema:"description=Number of recent messages sent to the model with each turn,minimum=1"`
 }
 
 func (c *ShortTermMemoryConfig) Validate() error {
@@ -19,8 +19,8 @@ func (c *ShortTermMemoryConfig) Validate() error {
 }
 
 type AgentConfig struct {
-	ShortTermMemoryConfig ShortTermMemoryConfig `yaml:"short_term_memory_config"`
-	ToolsConfig           tool.ToolConfig       `yaml:"tools_config"`
+	ShortTermMemoryConfig ShortTermMemoryConfig `yaml:"short_term_memory_config" json:"short_term_memory_config" jsonschema:"title=Short term memory"`
+	ToolsConfig           tool.ToolConfig       `yaml:"tools_config" json:"tools_config" jsonschema:"title=Tools"`
 }
 
 func getDefaultAgentConfig() *AgentConfig {
@@ -28,6 +28,7 @@ func getDefaultAgentConfig() *AgentConfig {
 		ShortTermMemoryConfig: ShortTermMemoryConfig{
 			MaxWindowSize: 10,
 		},
+		ToolsConfig: *tool.GetDefaultToolConfig(),
 	}
 }
 
@@ -35,5 +36,11 @@ func (c *AgentConfig) Validate() error {
 	if err := c.ShortTermMemoryConfig.Validate(); err != nil {
 		return err
 	}
+	if err := c.ToolsConfig.GoogleSearch.Validate(); err != nil {
+		return err
+	}
+	if err := c.ToolsConfig.Permissions.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
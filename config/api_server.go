@@ -0,0 +1,35 @@
+package config
+
+import "errors"
+
+// APIServerConfig is the headless HTTP/WebSocket API. It runs next to the
+// window when enabled, or alone with the -headless flag.
+type APIServerConfig struct {
+	Enable      bool   `yaml:"enable" json:"enable" jsonschema:"description=Serve the REST and WebSocket API"`
+	Address     string `yaml:"address" json:"address" jsonschema:"description=Listen address such as 127.0.0.1:8080"`
+	BearerToken string `yaml:"bearer_token" json:"bearer_token" jsonschema:"description=Required on every request when set,writeOnly=true"` // required on every request when set, ?token= is accepted for websockets
+	UserID      string `yaml:"user_id" json:"user_id" jsonschema:"description=User the API conversations belong to"`
+	CharacterID string `yaml:"character_id" json:"character_id" jsonschema:"description=Character that answers API requests"`
+}
+
+func (c *APIServerConfig) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+	if c.Address == "" {
+		return errors.New("address is required")
+	}
+	if c.UserID == "" || c.CharacterID == "" {
+		return errors.New("user_id and character_id are required")
+	}
+	return nil
+}
+
+func getDefaultAPIServerConfig() *APIServerConfig {
+	return &APIServerConfig{
+		Enable:      false,
+		Address:     "127.0.0.1:8766",
+		UserID:      "huuhoang",
+		CharacterID: "1",
+	}
+}
@@ -10,9 +10,9 @@ import (
 var supportedASRProviders = []string{"elevenlabs"}
 
 type ASRConfig struct {
-	Provider         string                `yaml:"provider"`
-	ElevenLabsConfig *asr.ElevenLabsConfig `yaml:"eleven_labs_config"`
-	InputDevice      string                `yaml:"input_device"`
+	Provider         string                `yaml:"provider" json:"provider" jsonschema:"description=Speech recognition service"`
+	ElevenLabsConfig *asr.ElevenLabsConfig `yaml:"eleven_labs_config" json:"eleven_labs_config" jsonschema:"title=ElevenLabs"`
+	InputDevice      string                `yaml:"input_device" json:"input_device" jsonschema:"description=Microphone used for recording. Empty uses the system default"`
 }
 
 func (c *ASRConfig) Validate() error {
@@ -5,10 +5,10 @@ import (
 )
 
 type ElevenLabsConfig struct {
-	Name         string `yaml:"name"`
-	APIKey       string `yaml:"api_key"`
-	ModelID      string `yaml:"model_id"`
-	LanguageCode string `yaml:"language_code"`
+	Name         string `yaml:"name" json:"name" jsonschema:"description=Display name"`
+	APIKey       string `yaml:"api_key" json:"api_key" jsonschema:"title=API key,writeOnly=true"`
+	ModelID      string `yaml:"model_id" json:"model_id" jsonschema:"description=Transcription model such as scribe_v1"`
+	LanguageCode string `yaml:"language_code" json:"language_code" jsonschema:"description=Language code or auto to detect it"`
 }
 
 func (e *ElevenLabsConfig) Validate() error {
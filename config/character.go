@@ -1,10 +1,10 @@
 package config
 
 type CharacterConfig struct {
-	Live2DModelName string `yaml:"live2d_model_name"` // select dropdown from list of live2d models, just mock data for now
-	CharacterName   string `yaml:"character_name"`    // input text
-	UserName        string `yaml:"user_name"`         // input text
-	PersonaPrompt   string `yaml:"persona_prompt"`    // input textarea
+	Live2DModelName string `yaml:"live2d_model_name" json:"live2d_model_name" jsonschema:"title=Live2D model,description=Folder name of the active Live2D model"` // select dropdown from list of live2d models
+	CharacterName   string `yaml:"character_name" json:"character_name" jsonschema:"description=Name the character goes by"`                                      // input text
+	UserName        string `yaml:"user_name" json:"user_name" jsonschema:"description=What the character calls you"`                                              // input text
+	PersonaPrompt   string `yaml:"persona_prompt" json:"persona_prompt" jsonschema:"description=Personality and speaking style added to the system prompt"`       // input textarea
 }
 
 func getDefaultCharacterConfig() *CharacterConfig {
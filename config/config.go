@@ -1,14 +1,28 @@
 package config
 
+import "github.com/Mirai3103/Project-Re-ENE/secrets"
+
 type Config struct {
-	LLMConfig       LLMConfig       `yaml:"llm_config"`
-	LoggerConfig    LoggerConfig    `yaml:"logger_config"`
-	TTSConfig       TTSConfig       `yaml:"tts_config"`
-	ASRConfig       ASRConfig       `yaml:"asr_config"`
-	CharacterConfig CharacterConfig `yaml:"character_config"`
-	AgentConfig     AgentConfig     `yaml:"agent_config"`
-	ModelsConfig    ModelsConfig    `yaml:"models_config"`
-	EmbeddingConfig EmbeddingConfig `yaml:"embedding_config"`
+	Version         int             `yaml:"version" json:"version" jsonschema:"description=Config format version. Older files are upgraded on load,readOnly=true"`
+	LLMConfig       LLMConfig       `yaml:"llm_config" json:"llm_config" jsonschema:"title=LLM"`
+	LoggerConfig    LoggerConfig    `yaml:"logger_config" json:"logger_config" jsonschema:"title=Logger"`
+	TTSConfig       TTSConfig       `yaml:"tts_config" json:"tts_config" jsonschema:"title=Text to speech"`
+	ASRConfig       ASRConfig       `yaml:"asr_config" json:"asr_config" jsonschema:"title=Speech recognition"`
+	CharacterConfig CharacterConfig `yaml:"character_config" json:"character_config" jsonschema:"title=Character"`
+	AgentConfig     AgentConfig     `yaml:"agent_config" json:"agent_config" jsonschema:"title=Agent"`
+	ModelsConfig    ModelsConfig    `yaml:"models_config" json:"models_config" jsonschema:"title=Live2D models"`
+	EmbeddingConfig EmbeddingConfig `yaml:"embedding_config" json:"embedding_config" jsonschema:"title=Embedding"`
+	MCPServerConfig MCPServerConfig `yaml:"mcp_server_config" json:"mcp_server_config" jsonschema:"title=MCP server"`
+	APIServerConfig APIServerConfig `yaml:"api_server_config" json:"api_server_config" jsonschema:"title=API server"`
+	DiscordConfig   DiscordConfig   `yaml:"discord_config" json:"discord_config" jsonschema:"title=Discord"`
+	TelegramConfig  TelegramConfig  `yaml:"telegram_config" json:"telegram_config" jsonschema:"title=Telegram"`
+	MeteringConfig  MeteringConfig  `yaml:"metering_config" json:"metering_config" jsonschema:"title=Usage and cost"`
+	TelemetryConfig TelemetryConfig `yaml:"telemetry_config" json:"telemetry_config" jsonschema:"title=Telemetry"`
+
+	path    string               // file the config was loaded from, see Save
+	layers  *layers              // what each layer set, so Save writes only the project's values
+	store   *secrets.Store       // resolves and keeps the secrets, see secrets.go
+	secrets map[string]secretRef // references the secrets were loaded from, by YAML path
 }
 
 func (c *Config) Validate() error {
@@ -25,5 +39,23 @@ func (c *Config) Validate() error {
 	if err := c.ModelsConfig.Validate(); err != nil {
 		return err
 	}
+	if err := c.MCPServerConfig.Validate(); err != nil {
+		return err
+	}
+	if err := c.APIServerConfig.Validate(); err != nil {
+		return err
+	}
+	if err := c.DiscordConfig.Validate(); err != nil {
+		return err
+	}
+	if err := c.TelegramConfig.Validate(); err != nil {
+		return err
+	}
+	if err := c.MeteringConfig.Validate(); err != nil {
+		return err
+	}
+	if err := c.TelemetryConfig.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,44 @@
+package config
+
+import "errors"
+
+// DiscordConfig runs Ene as a Discord bot. Channels map to conversations and
+// Discord users to rows of the users table.
+type DiscordConfig struct {
+	Enable      bool   `yaml:"enable" json:"enable" jsonschema:"description=Run Ene as a Discord bot"`
+	Token       string `yaml:"token" json:"token" jsonschema:"description=Bot token from the Discord developer portal,writeOnly=true"`
+	CharacterID string `yaml:"character_id" json:"character_id" jsonschema:"description=Character that answers on Discord"`
+	// Users links Discord user IDs to existing users, e.g. the owner's own
+	// account. Everyone else gets a "discord:<id>" user.
+	Users         map[string]string `yaml:"users" json:"users" jsonschema:"description=Discord user IDs linked to existing users"`
+	CommandPrefix string            `yaml:"command_prefix" json:"command_prefix" jsonschema:"description=Prefix of the join and leave commands"` // prefix of the join/leave commands
+	// VoiceSilenceMs is how long a speaker must be quiet before their
+	// utterance is transcribed.
+	VoiceSilenceMs int `yaml:"voice_silence_ms" json:"voice_silence_ms" jsonschema:"description=Silence in milliseconds that ends a voice utterance,minimum=1"`
+}
+
+func (c *DiscordConfig) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+	if c.Token == "" {
+		return errors.New("discord token is required")
+	}
+	if c.CharacterID == "" {
+		return errors.New("character_id is required")
+	}
+	if c.VoiceSilenceMs <= 0 {
+		return errors.New("voice_silence_ms must be greater than 0")
+	}
+	return nil
+}
+
+func getDefaultDiscordConfig() *DiscordConfig {
+	return &DiscordConfig{
+		Enable:         false,
+		CharacterID:    "1",
+		Users:          map[string]string{},
+		CommandPrefix:  "!",
+		VoiceSilenceMs: 800,
+	}
+}
@@ -1,13 +1,13 @@
 package embedding
 
 type GoogleEmbeddingConfig struct {
-	ModelID string `yaml:"model_id"`
-	APIKey  string `yaml:"api_key"`
+	ModelID string `yaml:"model_id" json:"model_id" jsonschema:"description=Embedding model"`
+	APIKey  string `yaml:"api_key" json:"api_key" jsonschema:"title=API key,writeOnly=true"`
 }
 
 func GetDefaultGoogleEmbeddingConfig() *GoogleEmbeddingConfig {
 	return &GoogleEmbeddingConfig{
-		ModelID: "gemini-2.0-flash",
+		ModelID: "gemini-embedding-001",
 		APIKey:  "",
 	}
 }
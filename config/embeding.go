@@ -2,7 +2,16 @@ package config
 
 import "github.com/Mirai3103/Project-Re-ENE/config/embedding"
 
+var supportedEmbeddingProviders = []string{"google"}
+
 type EmbeddingConfig struct {
-	Provider string                           `yaml:"provider"`
-	Google   *embedding.GoogleEmbeddingConfig `yaml:"google"`
+	Provider string                           `yaml:"provider" json:"provider" jsonschema:"description=Service that turns memories into vectors"`
+	Google   *embedding.GoogleEmbeddingConfig `yaml:"google" json:"google" jsonschema:"title=Google"`
+}
+
+func getDefaultEmbeddingConfig() *EmbeddingConfig {
+	return &EmbeddingConfig{
+		Provider: "google",
+		Google:   embedding.GetDefaultGoogleEmbeddingConfig(),
+	}
 }
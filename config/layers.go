@@ -0,0 +1,351 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/Mirai3103/Project-Re-ENE/secrets"
+	"gopkg.in/yaml.v3"
+)
+
+// The config is merged from these layers, each overriding the ones before:
+//
+//	default   built into the app, GetDefaultConfig
+//	system    /etc/ene/config.yaml, %ProgramData%\ene\config.yaml on Windows
+//	user      $XDG_CONFIG_HOME/ene/config.yaml, os.UserConfigDir elsewhere
+//	project   the file given to LoadConfig, config.yaml next to the app
+//	env       ENE_<SECTION>__<KEY> variables, ENE_LLM_CONFIG__GEMINI_CONFIG__MODEL
+//
+// Missing files are skipped. Env values are parsed as YAML scalars, so
+// "true" and "0.5" fill bool and number fields. Save only writes the project
+// file, keeping the keys it already had plus the ones that differ from the
+// layers below it; values that came from env are never written.
+const (
+	LayerDefault = "default"
+	LayerSystem  = "system"
+	LayerUser    = "user"
+	LayerProject = "project"
+	LayerEnv     = "env"
+)
+
+const envPrefix = "ENE_"
+
+// systemConfigFile and userConfigFile are variables so tests can move them.
+var (
+	systemConfigFile = func() string {
+		if runtime.GOOS == "windows" {
+			return filepath.Join(os.Getenv("ProgramData"), "ene", "config.yaml")
+		}
+		return "/etc/ene/config.yaml"
+	}
+	userConfigFile = func() string {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return ""
+		}
+		return filepath.Join(dir, "ene", "config.yaml")
+	}
+)
+
+// Origin tells where the effective value of one key came from.
+type Origin struct {
+	Path  string `json:"path"`
+	Value string `json:"value"`
+	Layer string `json:"layer"`
+	File  string `json:"file,omitempty"`
+}
+
+// layers keeps what Save needs to write only the project's own values, the
+// flattened values of the layers below the project, of the project file and
+// of env, keyed by dotted YAML path.
+type layers struct {
+	base    map[string]any
+	project map[string]any
+	env     map[string]any
+	origins map[string]Origin
+
+	// upgradedFrom is the version the project file was migrated from, -1
+	// when it was current; projectData is the file as read, for the backup.
+	upgradedFrom int
+	projectData  []byte
+}
+
+type layerFile struct {
+	name string
+	file string
+}
+
+// readLayers merges every layer into one YAML map. created tells whether the
+// project file was missing and no system or user file exists, the first run,
+// when LoadConfig writes a full default config to edit.
+func readLayers(projectPath string) (merged map[string]any, l *layers, created bool, err error) {
+	leaves := leafPaths()
+	merged = toYAMLMap(GetDefaultConfig())
+	l = &layers{
+		base:    map[string]any{},
+		project: map[string]any{},
+		env:     map[string]any{},
+		origins: map[string]Origin{},
+
+		upgradedFrom: -1,
+	}
+	setOrigins(l.origins, flatten(merged, leaves), LayerDefault, "")
+
+	found := false
+	for _, f := range []layerFile{{LayerSystem, systemConfigFile()}, {LayerUser, userConfigFile()}, {LayerProject, projectPath}} {
+		if f.file == "" {
+			continue
+		}
+		m, data, err := readYAMLMap(f.file)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, false, err
+		}
+		from, err := migrate(m)
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("%s: %w", f.file, err)
+		}
+		if f.name == LayerProject {
+			if from < CurrentVersion {
+				l.upgradedFrom, l.projectData = from, data
+			}
+			l.base = flatten(merged, leaves)
+			l.project = flatten(m, leaves)
+		} else {
+			found = true
+		}
+		mergeMaps(merged, m)
+		setOrigins(l.origins, flatten(m, leaves), f.name, f.file)
+	}
+	if _, err := os.Stat(projectPath); errors.Is(err, os.ErrNotExist) {
+		l.base = flatten(merged, leaves)
+		created = !found
+	}
+
+	env := envValues(os.Environ(), leaves)
+	for path, v := range env {
+		setPath(merged, path, v)
+		l.env[path] = v
+	}
+	setOrigins(l.origins, env, LayerEnv, "")
+	return merged, l, created, nil
+}
+
+func readYAMLMap(path string) (map[string]any, []byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	m := map[string]any{}
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, nil, fmt.Errorf("unmarshal %s: %w", path, err)
+	}
+	return m, data, nil
+}
+
+func toYAMLMap(v any) map[string]any {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		panic(err) // only called on Config, which always marshals
+	}
+	m := map[string]any{}
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// mergeMaps copies src into dst, recursing into maps present in both.
+func mergeMaps(dst, src map[string]any) {
+	for k, v := range src {
+		sub, ok := v.(map[string]any)
+		if old, isMap := dst[k].(map[string]any); ok && isMap {
+			mergeMaps(old, sub)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+func setPath(m map[string]any, path string, v any) {
+	keys := strings.Split(path, ".")
+	for _, k := range keys[:len(keys)-1] {
+		sub, ok := m[k].(map[string]any)
+		if !ok {
+			sub = map[string]any{}
+			m[k] = sub
+		}
+		m = sub
+	}
+	m[keys[len(keys)-1]] = v
+}
+
+// flatten lists the values of m by dotted path. Maps are walked until a path
+// is a Config field that is not a struct, so a list or a map field is one
+// value.
+func flatten(m map[string]any, leaves map[string]reflect.StructField) map[string]any {
+	out := map[string]any{}
+	var walk func(prefix string, m map[string]any)
+	walk = func(prefix string, m map[string]any) {
+		for k, v := range m {
+			path := joinPath(prefix, k)
+			if sub, ok := v.(map[string]any); ok {
+				if _, leaf := leaves[path]; !leaf {
+					walk(path, sub)
+					continue
+				}
+			}
+			out[path] = v
+		}
+	}
+	walk("", m)
+	return out
+}
+
+func setOrigins(origins map[string]Origin, values map[string]any, layer, file string) {
+	for path, v := range values {
+		origins[path] = Origin{Path: path, Value: fmt.Sprint(v), Layer: layer, File: file}
+	}
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// leafPaths lists every field of Config that holds a value rather than a
+// section, by dotted YAML path.
+func leafPaths() map[string]reflect.StructField {
+	leaves := map[string]reflect.StructField{}
+	var walk func(prefix string, t reflect.Type)
+	walk = func(prefix string, t reflect.Type) {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			key, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+			if !f.IsExported() || key == "" || key == "-" {
+				continue
+			}
+			path := joinPath(prefix, key)
+			ft := f.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				walk(path, ft)
+				continue
+			}
+			leaves[path] = f
+		}
+	}
+	walk("", reflect.TypeOf(Config{}))
+	return leaves
+}
+
+// envValues reads the ENE_ variables that name a Config field. Others, like
+// ENE_SECRETS_PASSPHRASE, are left alone.
+func envValues(environ []string, leaves map[string]reflect.StructField) map[string]any {
+	values := map[string]any{}
+	for _, kv := range environ {
+		name, raw, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(name, envPrefix) {
+			continue
+		}
+		path := strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(name, envPrefix), "__", "."))
+		if _, ok := leaves[path]; !ok {
+			continue
+		}
+		var v any
+		if err := yaml.Unmarshal([]byte(raw), &v); err != nil || v == nil {
+			v = raw
+		}
+		switch v.(type) {
+		case map[string]any, []any:
+			v = raw
+		}
+		values[path] = v
+	}
+	return values
+}
+
+// projectNode turns out into the YAML written to the project file, dropping
+// the values the project file should not hold, see the layer order above.
+func (l *layers) projectNode(out *Config) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := doc.Encode(out); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+	leaves := leafPaths()
+	var prune func(prefix string, n *yaml.Node) error
+	prune = func(prefix string, n *yaml.Node) error {
+		var kept []*yaml.Node
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			path := joinPath(prefix, key.Value)
+			if _, leaf := leaves[path]; !leaf && value.Kind == yaml.MappingNode {
+				if err := prune(path, value); err != nil {
+					return err
+				}
+				if len(value.Content) > 0 {
+					kept = append(kept, key, value)
+				}
+				continue
+			}
+			var v any
+			if err := value.Decode(&v); err != nil {
+				return err
+			}
+			projectValue, inProject := l.project[path]
+			if envValue, ok := l.env[path]; ok && fmt.Sprint(v) == fmt.Sprint(envValue) { // env "1.5" may fill a string
+				// set by env, write back what the project file had
+				if !inProject {
+					continue
+				}
+				if err := value.Encode(projectValue); err != nil {
+					return err
+				}
+				kept = append(kept, key, value)
+				continue
+			}
+			if inProject || path == "version" || !reflect.DeepEqual(v, l.base[path]) {
+				kept = append(kept, key, value)
+			}
+		}
+		n.Content = kept
+		return nil
+	}
+	return &doc, prune("", &doc)
+}
+
+// Explain loads the layers like LoadConfig and tells where each effective
+// value came from, sorted by path. Plain text secrets are redacted;
+// references like ${GEMINI_API_KEY} are shown as they are.
+func Explain(projectPath string) ([]Origin, error) {
+	_, l, _, err := readLayers(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	leaves := leafPaths()
+	origins := make([]Origin, 0, len(l.origins))
+	for _, o := range l.origins {
+		f := leaves[o.Path]
+		if strings.Contains(f.Tag.Get("jsonschema"), "writeOnly=true") && o.Value != "" && secrets.Kind(o.Value) == secrets.KindPlain {
+			o.Value = RedactedValue
+		}
+		origins = append(origins, o)
+	}
+	sort.Slice(origins, func(i, j int) bool { return origins[i].Path < origins[j].Path })
+	return origins, nil
+}
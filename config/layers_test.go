@@ -0,0 +1,140 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useLayerFiles points the system and user layers at files in a temp dir
+// and returns the dir, which also holds the project file.
+func useLayerFiles(t *testing.T, system, user, project string) string {
+	t.Helper()
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if content != "" {
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return path
+	}
+	systemPath, userPath := write("system.yaml", system), write("user.yaml", user)
+	write("config.yaml", project)
+
+	oldSystem, oldUser := systemConfigFile, userConfigFile
+	systemConfigFile = func() string { return systemPath }
+	userConfigFile = func() string { return userPath }
+	t.Cleanup(func() { systemConfigFile, userConfigFile = oldSystem, oldUser })
+	return dir
+}
+
+const layerProject = `
+llm_config:
+  gemini_config:
+    api_key: project-key
+    temperature: 0.3
+tts_config:
+  eleven_labs_config:
+    api_key: tts-key
+    model_id: eleven_flash_v2_5
+`
+
+func TestLayerPrecedence(t *testing.T) {
+	dir := useLayerFiles(t,
+		"llm_config:\n  gemini_config:\n    model: system-model\n    base_url: http://system\n",
+		"llm_config:\n  gemini_config:\n    model: user-model\n",
+		layerProject,
+	)
+	t.Setenv("ENE_LOGGER_CONFIG__LEVEL", "debug")
+	t.Setenv("ENE_TELEGRAM_CONFIG__ENABLE", "true")
+	t.Setenv("ENE_TELEGRAM_CONFIG__TOKEN", "env-token")
+	t.Setenv("ENE_NOT_A_FIELD", "ignored")
+
+	cfg, err := LoadConfig(filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := cfg.LLMConfig.GeminiConfig
+	if g.Model != "user-model" || g.BaseURL != "http://system" || g.Temperature != 0.3 || g.APIKey != "project-key" {
+		t.Errorf("gemini = %+v", g)
+	}
+	if cfg.LoggerConfig.Level != "debug" || !cfg.TelegramConfig.Enable {
+		t.Errorf("env not applied: %q, %v", cfg.LoggerConfig.Level, cfg.TelegramConfig.Enable)
+	}
+	if cfg.LoggerConfig.Mode != "console" {
+		t.Errorf("default not applied: %q", cfg.LoggerConfig.Mode)
+	}
+
+	origins, err := Explain(filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	layerOf := map[string]Origin{}
+	for _, o := range origins {
+		layerOf[o.Path] = o
+	}
+	for path, want := range map[string]string{
+		"llm_config.gemini_config.model":       LayerUser,
+		"llm_config.gemini_config.base_url":    LayerSystem,
+		"llm_config.gemini_config.temperature": LayerProject,
+		"logger_config.level":                  LayerEnv,
+		"logger_config.mode":                   LayerDefault,
+	} {
+		if got := layerOf[path].Layer; got != want {
+			t.Errorf("%s from %s, want %s", path, got, want)
+		}
+	}
+	if v := layerOf["telegram_config.token"].Value; v != RedactedValue {
+		t.Errorf("secret shown as %q", v)
+	}
+}
+
+func TestSaveWritesOnlyProjectValues(t *testing.T) {
+	dir := useLayerFiles(t, "", "llm_config:\n  gemini_config:\n    model: user-model\n", layerProject)
+	t.Setenv("ENE_LOGGER_CONFIG__LEVEL", "debug")
+	t.Setenv("ENE_LLM_CONFIG__GEMINI_CONFIG__TEMPERATURE", "0.9")
+	path := filepath.Join(dir, "config.yaml")
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.CharacterConfig.Live2DModelName = "ene"
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data := readFile(t, path)
+	for _, want := range []string{"live2d_model_name: ene", "temperature: 0.3", "api_key: project-key"} {
+		if !strings.Contains(data, want) {
+			t.Errorf("saved file lacks %q:\n%s", want, data)
+		}
+	}
+	for _, unwanted := range []string{"user-model", "debug", "0.9", "logger_config"} {
+		if strings.Contains(data, unwanted) {
+			t.Errorf("saved file has %q:\n%s", unwanted, data)
+		}
+	}
+}
+
+func TestFirstRunWritesDefaults(t *testing.T) {
+	dir := useLayerFiles(t, "", "", "")
+	path := filepath.Join(dir, "config.yaml")
+	if _, err := LoadConfig(path); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(readFile(t, path), "logger_config:") {
+		t.Error("default config not written")
+	}
+
+	// With a user file there is nothing to create, the user file is the config.
+	dir = useLayerFiles(t, "", layerProject, "")
+	if _, err := LoadConfig(filepath.Join(dir, "config.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "config.yaml")); !os.IsNotExist(err) {
+		t.Error("project file created although a user file exists")
+	}
+}
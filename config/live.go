@@ -0,0 +1,27 @@
+package config
+
+import "sync/atomic"
+
+// Live holds the running config. A change is published as a new Config and
+// never written into the one readers loaded, so a reader keeps a consistent
+// version for as long as it holds it. Load it on every use, not once.
+type Live struct {
+	current atomic.Pointer[Config]
+}
+
+func NewLive(cfg *Config) *Live {
+	l := &Live{}
+	l.current.Store(cfg)
+	return l
+}
+
+// Load returns the running config. It must not be modified, Clone it and
+// Store the copy instead.
+func (l *Live) Load() *Config {
+	return l.current.Load()
+}
+
+// Store publishes cfg as the running config.
+func (l *Live) Store(cfg *Config) {
+	l.current.Store(cfg)
+}
@@ -2,41 +2,210 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"slices"
 
 	"github.com/Mirai3103/Project-Re-ENE/config/llm"
 )
 
-var supportedLLMProviders = []string{"gemini", "openai"}
+var supportedLLMProviders = []string{"gemini", "openai", "anthropic", "ollama", "openrouter"}
 
 type LLMConfig struct {
-	Provider          string            `yaml:"provider"`
-	IsAudioSupported  bool              `yaml:"is_audio_supported"`
-	IsVisionSupported bool              `yaml:"is_vision_supported"`
-	GeminiConfig      *llm.GeminiConfig `yaml:"gemini_config"`
-	OpenAIConfig      *llm.OpenAIConfig `yaml:"openai_config"`
-	// todo: add other LLM configs
+	Provider         string                `yaml:"provider" json:"provider" jsonschema:"description=Service that generates the replies"`
+	GeminiConfig     *llm.GeminiConfig     `yaml:"gemini_config" json:"gemini_config" jsonschema:"title=Gemini"`
+	OpenAIConfig     *llm.OpenAIConfig     `yaml:"openai_config" json:"openai_config" jsonschema:"title=OpenAI compatible"`
+	AnthropicConfig  *llm.AnthropicConfig  `yaml:"anthropic_config" json:"anthropic_config" jsonschema:"title=Anthropic"`
+	OllamaConfig     *llm.OllamaConfig     `yaml:"ollama_config" json:"ollama_config" jsonschema:"title=Ollama"`
+	OpenRouterConfig *llm.OpenRouterConfig `yaml:"openrouter_config" json:"openrouter_config" jsonschema:"title=OpenRouter"`
+
+	Fallbacks      []string                  `yaml:"fallbacks" json:"fallbacks" jsonschema:"description=Providers tried in order when the main one fails"`
+	Retry          *llm.RetryConfig          `yaml:"retry" json:"retry" jsonschema:"title=Retries"`
+	CircuitBreaker *llm.CircuitBreakerConfig `yaml:"circuit_breaker" json:"circuit_breaker"`
+
+	Tasks *llm.TasksConfig `yaml:"tasks" json:"tasks" jsonschema:"description=Model per task so background work can use a cheaper one"`
 }
 
 func (c *LLMConfig) Validate() error {
-	if !slices.Contains(supportedLLMProviders, c.Provider) {
-		return errors.New("llm provider is not supported: " + c.Provider)
+	if err := c.validateProvider(c.Provider); err != nil {
+		return err
+	}
+	for i, name := range c.Fallbacks {
+		if name == c.Provider || slices.Contains(c.Fallbacks[:i], name) {
+			return fmt.Errorf("fallbacks: %s is listed twice", name)
+		}
+		if err := c.validateProvider(name); err != nil {
+			return fmt.Errorf("fallbacks: %w", err)
+		}
+	}
+	if c.Retry != nil {
+		if err := c.Retry.Validate(); err != nil {
+			return fmt.Errorf("retry: %w", err)
+		}
+	}
+	if c.CircuitBreaker != nil {
+		if err := c.CircuitBreaker.Validate(); err != nil {
+			return fmt.Errorf("circuit_breaker: %w", err)
+		}
+	}
+	for _, task := range llm.Tasks {
+		t := c.Tasks.Get(task)
+		if t == nil {
+			continue
+		}
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("tasks.%s: %w", task, err)
+		}
+		routed := c.ForTask(task)
+		if err := routed.validateProvider(routed.Provider); err != nil {
+			return fmt.Errorf("tasks.%s: %w", task, err)
+		}
+		// The Ollama plugin sends no sampling options, the model's Modelfile
+		// decides them, so a task setting them would be silently ignored.
+		if routed.Provider == "ollama" && (t.Temperature != nil || t.MaxTokens > 0) {
+			return fmt.Errorf("tasks.%s: ollama takes temperature and max_tokens from the Modelfile, leave them unset", task)
+		}
+	}
+	return nil
+}
+
+// ForTask returns the config a task runs with: its provider first, with the
+// task's model, temperature and max_tokens (only the model for Ollama, see
+// Validate), then the main provider and the fallbacks. The sections are copied, c is left as it is.
+func (c *LLMConfig) ForTask(task string) *LLMConfig {
+	t := c.Tasks.Get(task)
+	if t == nil {
+		return c
 	}
-	switch c.Provider {
+	routed := *c
+	if t.Provider != "" && t.Provider != c.Provider {
+		routed.Provider = t.Provider
+		routed.Fallbacks = []string{c.Provider}
+		for _, name := range c.Fallbacks {
+			if name != t.Provider {
+				routed.Fallbacks = append(routed.Fallbacks, name)
+			}
+		}
+	}
+	switch routed.Provider {
 	case "gemini":
+		if routed.GeminiConfig != nil {
+			g := *routed.GeminiConfig
+			overrideTask(t, &g.Model, &g.Temperature, &g.MaxTokens)
+			routed.GeminiConfig = &g
+		}
+	case "openai":
+		if routed.OpenAIConfig != nil {
+			o := *routed.OpenAIConfig
+			overrideTask(t, &o.Model, &o.Temperature, &o.MaxTokens)
+			routed.OpenAIConfig = &o
+		}
+	case "anthropic":
+		if routed.AnthropicConfig != nil {
+			a := *routed.AnthropicConfig
+			overrideTask(t, &a.Model, &a.Temperature, &a.MaxTokens)
+			routed.AnthropicConfig = &a
+		}
+	case "ollama":
+		if routed.OllamaConfig != nil {
+			o := *routed.OllamaConfig
+			if t.Model != "" {
+				o.Model = t.Model
+			}
+			routed.OllamaConfig = &o
+		}
+	case "openrouter":
+		if routed.OpenRouterConfig != nil {
+			o := *routed.OpenRouterConfig
+			overrideTask(t, &o.Model, &o.Temperature, &o.MaxTokens)
+			routed.OpenRouterConfig = &o
+		}
+	}
+	return &routed
+}
+
+func overrideTask(t *llm.TaskConfig, model *string, temperature *float32, maxTokens *int32) {
+	if t.Model != "" {
+		*model = t.Model
+	}
+	if t.Temperature != nil {
+		*temperature = *t.Temperature
+	}
+	if t.MaxTokens > 0 {
+		*maxTokens = t.MaxTokens
+	}
+}
+
+func (c *LLMConfig) validateProvider(name string) error {
+	if !slices.Contains(supportedLLMProviders, name) {
+		return errors.New("llm provider is not supported: " + name)
+	}
+	switch name {
+	case "gemini":
+		if c.GeminiConfig == nil {
+			return errors.New("gemini_config is required")
+		}
 		return c.GeminiConfig.Validate()
 	case "openai":
-		return nil
+		if c.OpenAIConfig == nil {
+			return errors.New("openai_config is required")
+		}
+		return c.OpenAIConfig.Validate()
+	case "anthropic":
+		if c.AnthropicConfig == nil {
+			return errors.New("anthropic_config is required")
+		}
+		return c.AnthropicConfig.Validate()
+	case "ollama":
+		if c.OllamaConfig == nil {
+			return errors.New("ollama_config is required")
+		}
+		return c.OllamaConfig.Validate()
+	case "openrouter":
+		if c.OpenRouterConfig == nil {
+			return errors.New("openrouter_config is required")
+		}
+		return c.OpenRouterConfig.Validate()
 	default:
 		return errors.New("llm provider is not supported")
 	}
 }
 
+// Capabilities tells what the selected provider's model accepts.
+func (c *LLMConfig) Capabilities() llm.Capabilities {
+	return c.CapabilitiesOf(c.Provider)
+}
+
+// CapabilitiesOf tells what the model of one provider accepts, the main one
+// or a fallback. It is the zero value, plain text only, when the provider
+// has no config.
+func (c *LLMConfig) CapabilitiesOf(provider string) llm.Capabilities {
+	switch {
+	case provider == "gemini" && c.GeminiConfig != nil:
+		return c.GeminiConfig.Capabilities()
+	case provider == "openai" && c.OpenAIConfig != nil:
+		return c.OpenAIConfig.Capabilities()
+	case provider == "anthropic" && c.AnthropicConfig != nil:
+		return c.AnthropicConfig.Capabilities()
+	case provider == "ollama" && c.OllamaConfig != nil:
+		return c.OllamaConfig.Capabilities()
+	case provider == "openrouter" && c.OpenRouterConfig != nil:
+		return c.OpenRouterConfig.Capabilities()
+	default:
+		return llm.Capabilities{}
+	}
+}
+
 func getDefaultLLMConfig() *LLMConfig {
 	return &LLMConfig{
-		Provider:          "gemini",
-		IsAudioSupported:  true,
-		IsVisionSupported: true,
-		GeminiConfig:      llm.GetDefaultGeminiConfig(),
+		Provider:         "gemini",
+		GeminiConfig:     llm.GetDefaultGeminiConfig(),
+		OpenAIConfig:     llm.GetDefaultOpenAIConfig(),
+		AnthropicConfig:  llm.GetDefaultAnthropicConfig(),
+		OllamaConfig:     llm.GetDefaultOllamaConfig(),
+		OpenRouterConfig: llm.GetDefaultOpenRouterConfig(),
+		Fallbacks:        []string{},
+		Retry:            llm.GetDefaultRetryConfig(),
+		CircuitBreaker:   llm.GetDefaultCircuitBreakerConfig(),
+		Tasks:            llm.GetDefaultTasksConfig(),
 	}
 }
@@ -0,0 +1,51 @@
+package llm
+
+import (
+	"errors"
+	"strings"
+)
+
+const AnthropicBaseURL = "https://api.anthropic.com/v1"
+
+type AnthropicConfig struct {
+	APIKey      string  `yaml:"api_key" json:"api_key" jsonschema:"title=API key,writeOnly=true"`
+	Model       string  `yaml:"model" json:"model" jsonschema:"description=Model name such as claude-sonnet-4-5-20250929"`
+	BaseURL     string  `yaml:"base_url" json:"base_url" jsonschema:"description=Override the API endpoint"`
+	Temperature float32 `yaml:"temperature" json:"temperature" jsonschema:"description=Sampling temperature,minimum=0,maximum=1"`
+	MaxTokens   int32   `yaml:"max_tokens" json:"max_tokens" jsonschema:"description=Longest reply in tokens. 0 uses the model default,minimum=0"`
+}
+
+func (a *AnthropicConfig) Validate() error {
+	if a.APIKey == "" {
+		return errors.New("api_key is required")
+	}
+	if a.Model == "" {
+		return errors.New("model is required")
+	}
+	if err := validateBaseURL(a.BaseURL); err != nil {
+		return err
+	}
+	if a.Temperature > 1 {
+		return errors.New("temperature must be between 0 and 1")
+	}
+	return validateSampling(a.Temperature, 0, a.MaxTokens)
+}
+
+// Capabilities follows what Anthropic's OpenAI compatible endpoint offers.
+// Its tool calls do not match the OpenAI format, so tools are off, and the
+// Claude 3 models before 3.5 Haiku take no system message.
+func (a *AnthropicConfig) Capabilities() Capabilities {
+	legacy := strings.HasPrefix(a.Model, "claude-3-opus") ||
+		strings.HasPrefix(a.Model, "claude-3-haiku") ||
+		a.Model == "claude-3-5-sonnet-20240620"
+	return Capabilities{Vision: true, SystemRole: !legacy}
+}
+
+func GetDefaultAnthropicConfig() *AnthropicConfig {
+	return &AnthropicConfig{
+		APIKey:      "",
+		Model:       "claude-sonnet-4-5-20250929",
+		BaseURL:     AnthropicBaseURL,
+		Temperature: 0.7,
+	}
+}
@@ -0,0 +1,20 @@
+package llm
+
+// Capabilities is what a provider's model accepts besides plain text. The
+// app reads them instead of asking users to tick the boxes themselves.
+type Capabilities struct {
+	Tools      bool `json:"tools"`
+	Vision     bool `json:"vision"`
+	Audio      bool `json:"audio"`
+	SystemRole bool `json:"system_role"`
+}
+
+func (g *GeminiConfig) Capabilities() Capabilities {
+	return Capabilities{Tools: true, Vision: true, Audio: true, SystemRole: true}
+}
+
+// Capabilities assumes a chat model with function calling, which OpenAI and
+// the common local servers all offer.
+func (o *OpenAIConfig) Capabilities() Capabilities {
+	return Capabilities{Tools: true, Vision: true, SystemRole: true}
+}
@@ -0,0 +1,56 @@
+package llm
+
+import "errors"
+
+// RetryConfig is how often a model is called again after a rate limit or a
+// server error, waiting twice as long each time.
+type RetryConfig struct {
+	MaxAttempts      int `yaml:"max_attempts" json:"max_attempts" jsonschema:"description=Calls per model including the first. 1 turns retries off,minimum=1"`
+	InitialBackoffMs int `yaml:"initial_backoff_ms" json:"initial_backoff_ms" jsonschema:"description=Wait before the first retry in milliseconds,minimum=0"`
+	MaxBackoffMs     int `yaml:"max_backoff_ms" json:"max_backoff_ms" jsonschema:"description=Longest wait between retries in milliseconds,minimum=0"`
+}
+
+func (r *RetryConfig) Validate() error {
+	if r.MaxAttempts < 1 {
+		return errors.New("max_attempts must be at least 1")
+	}
+	if r.InitialBackoffMs < 0 || r.MaxBackoffMs < 0 {
+		return errors.New("backoff must not be negative")
+	}
+	if r.MaxBackoffMs < r.InitialBackoffMs {
+		return errors.New("max_backoff_ms must not be less than initial_backoff_ms")
+	}
+	return nil
+}
+
+func GetDefaultRetryConfig() *RetryConfig {
+	return &RetryConfig{
+		MaxAttempts:      3,
+		InitialBackoffMs: 500,
+		MaxBackoffMs:     8000,
+	}
+}
+
+// CircuitBreakerConfig skips a model of the fallback chain that keeps
+// failing, so a turn does not wait for its retries every time.
+type CircuitBreakerConfig struct {
+	FailureThreshold int `yaml:"failure_threshold" json:"failure_threshold" jsonschema:"description=Failed calls in a row before the model is skipped. 0 turns the breaker off,minimum=0"`
+	CooldownSeconds  int `yaml:"cooldown_seconds" json:"cooldown_seconds" jsonschema:"description=How long a failing model is skipped before it is tried again,minimum=1"`
+}
+
+func (c *CircuitBreakerConfig) Validate() error {
+	if c.FailureThreshold < 0 {
+		return errors.New("failure_threshold must not be negative")
+	}
+	if c.FailureThreshold > 0 && c.CooldownSeconds <= 0 {
+		return errors.New("cooldown_seconds must be positive")
+	}
+	return nil
+}
+
+func GetDefaultCircuitBreakerConfig() *CircuitBreakerConfig {
+	return &CircuitBreakerConfig{
+		FailureThreshold: 3,
+		CooldownSeconds:  60,
+	}
+}
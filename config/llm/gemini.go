@@ -1,12 +1,28 @@
 package llm
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/invopop/jsonschema"
+)
+
+// Keys and values of safety_settings, the lower case names of the Gemini
+// harm categories and block thresholds without their prefixes.
+var (
+	GeminiHarmCategories  = []string{"harassment", "hate_speech", "sexually_explicit", "dangerous_content", "civic_integrity"}
+	GeminiBlockThresholds = []string{"block_none", "block_only_high", "block_medium_and_above", "block_low_and_above", "off"}
+)
 
 type GeminiConfig struct {
-	APIKey      string  `yaml:"api_key"`
-	Model       string  `yaml:"model"`
-	BaseURL     string  `yaml:"base_url"`
-	Temperature float32 `yaml:"temperature"`
+	APIKey         string            `yaml:"api_key" json:"api_key" jsonschema:"title=API key,writeOnly=true"`
+	Model          string            `yaml:"model" json:"model" jsonschema:"description=Model name such as gemini-2.5-flash"`
+	BaseURL        string            `yaml:"base_url" json:"base_url" jsonschema:"description=Override the API endpoint"`
+	Temperature    float32           `yaml:"temperature" json:"temperature" jsonschema:"description=Sampling temperature,minimum=0,maximum=2"`
+	TopP           float32           `yaml:"top_p" json:"top_p" jsonschema:"title=Top P,description=Nucleus sampling. 0 uses the model default,minimum=0,maximum=1"`
+	MaxTokens      int32             `yaml:"max_tokens" json:"max_tokens" jsonschema:"description=Longest reply in tokens. 0 uses the model default,minimum=0"`
+	SafetySettings map[string]string `yaml:"safety_settings" json:"safety_settings" jsonschema:"description=Block threshold per harm category"`
 }
 
 func (g *GeminiConfig) Validate() error {
@@ -16,13 +32,36 @@ func (g *GeminiConfig) Validate() error {
 	if g.Model == "" {
 		return errors.New("model is required")
 	}
+	if err := validateBaseURL(g.BaseURL); err != nil {
+		return err
+	}
+	if err := validateSampling(g.Temperature, g.TopP, g.MaxTokens); err != nil {
+		return err
+	}
+	for category, threshold := range g.SafetySettings {
+		if !slices.Contains(GeminiHarmCategories, category) {
+			return fmt.Errorf("safety_settings: unknown harm category %q", category)
+		}
+		if !slices.Contains(GeminiBlockThresholds, threshold) {
+			return fmt.Errorf("safety_settings: unknown threshold %q for %s", threshold, category)
+		}
+	}
 	return nil
 }
 
+func (GeminiConfig) JSONSchemaExtend(s *jsonschema.Schema) {
+	prop, ok := s.Properties.Get("safety_settings")
+	if !ok || prop.AdditionalProperties == nil {
+		return
+	}
+	prop.PropertyNames = &jsonschema.Schema{Enum: anySlice(GeminiHarmCategories)}
+	prop.AdditionalProperties.Enum = anySlice(GeminiBlockThresholds)
+}
+
 func GetDefaultGeminiConfig() *GeminiConfig {
 	return &GeminiConfig{
 		APIKey:      "",
-		Model:       "gemini-2.0-flash",
+		Model:       "gemini-2.5-flash",
 		Temperature: 0.7,
 	}
 }
@@ -0,0 +1,60 @@
+package llm
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// Model families Ollama runs with function calling, and the models the
+// genkit plugin sends images to. Tools are matched on the name before the
+// tag, llama3.2:3b is llama3.2; images only work for the exact names.
+var (
+	ollamaToolModels = []string{
+		"qwq", "qwen3", "qwen2.5", "qwen2.5-coder", "qwen2", "llama3.3", "llama3.2", "llama3.1",
+		"mistral", "mistral-nemo", "mistral-small", "mistral-small3.1", "mistral-large", "mixtral",
+		"command-r", "command-r-plus", "command-r7b", "command-a", "hermes3", "smollm2", "phi4-mini",
+		"granite3.1-dense", "granite3.2", "granite3.3", "granite3.2-vision", "nemotron", "nemotron-mini",
+		"athene-v2", "cogito", "firefunction-v2", "gpt-oss",
+	}
+	ollamaVisionModels = []string{"llava", "bakllava", "llava-llama3", "llava:13b", "llava:7b", "llava:latest", "gemma3:4b", "gemma3:12b", "gemma3:27b"}
+)
+
+type OllamaConfig struct {
+	ServerAddress string `yaml:"server_address" json:"server_address" jsonschema:"description=Address of the Ollama server"`
+	Model         string `yaml:"model" json:"model" jsonschema:"description=Name of a pulled model such as llama3.2"`
+	Timeout       int    `yaml:"timeout" json:"timeout" jsonschema:"description=Seconds to wait for a reply,minimum=1"`
+}
+
+func (o *OllamaConfig) Validate() error {
+	if o.ServerAddress == "" {
+		return errors.New("server_address is required")
+	}
+	if err := validateBaseURL(o.ServerAddress); err != nil {
+		return errors.New("server_address is not an http(s) URL: " + o.ServerAddress)
+	}
+	if o.Model == "" {
+		return errors.New("model is required")
+	}
+	if o.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	return nil
+}
+
+func (o *OllamaConfig) Capabilities() Capabilities {
+	family, _, _ := strings.Cut(o.Model, ":")
+	return Capabilities{
+		Tools:      slices.Contains(ollamaToolModels, family),
+		Vision:     slices.Contains(ollamaVisionModels, o.Model),
+		SystemRole: true,
+	}
+}
+
+func GetDefaultOllamaConfig() *OllamaConfig {
+	return &OllamaConfig{
+		ServerAddress: "http://127.0.0.1:11434",
+		Model:         "llama3.2",
+		Timeout:       120,
+	}
+}
@@ -1,10 +1,44 @@
 package llm
 
+import (
+	"errors"
+	"net/url"
+)
+
+// OpenAIBaseURL is the endpoint of OpenAI itself, the only compatible one
+// that always needs an API key.
+const OpenAIBaseURL = "https://api.openai.com/v1"
+
 type OpenAIConfig struct {
-	APIKey      string  `yaml:"api_key"`
-	Model       string  `yaml:"model"`
-	BaseURL     string  `yaml:"base_url"`
-	Temperature float32 `yaml:"temperature"`
+	APIKey      string  `yaml:"api_key" json:"api_key" jsonschema:"title=API key,writeOnly=true"`
+	Model       string  `yaml:"model" json:"model" jsonschema:"description=Model name"`
+	BaseURL     string  `yaml:"base_url" json:"base_url" jsonschema:"description=Endpoint of the OpenAI compatible API"`
+	Temperature float32 `yaml:"temperature" json:"temperature" jsonschema:"description=Sampling temperature,minimum=0,maximum=2"`
+	TopP        float32 `yaml:"top_p" json:"top_p" jsonschema:"title=Top P,description=Nucleus sampling. 0 uses the model default,minimum=0,maximum=1"`
+	MaxTokens   int32   `yaml:"max_tokens" json:"max_tokens" jsonschema:"description=Longest reply in tokens. 0 uses the model default,minimum=0"`
+}
+
+func (o *OpenAIConfig) Validate() error {
+	if o.Model == "" {
+		return errors.New("model is required")
+	}
+	if o.BaseURL == "" {
+		return errors.New("base_url is required")
+	}
+	if err := validateBaseURL(o.BaseURL); err != nil {
+		return err
+	}
+	// local servers like LM Studio or llama.cpp accept any key
+	if o.APIKey == "" && o.IsOpenAI() {
+		return errors.New("api_key is required")
+	}
+	return validateSampling(o.Temperature, o.TopP, o.MaxTokens)
+}
+
+// IsOpenAI tells whether the endpoint is OpenAI's own API.
+func (o *OpenAIConfig) IsOpenAI() bool {
+	u, err := url.Parse(o.BaseURL)
+	return err == nil && u.Host == "api.openai.com"
 }
 
 func GetDefaultOpenAIConfig() *OpenAIConfig {
@@ -12,6 +46,6 @@ func GetDefaultOpenAIConfig() *OpenAIConfig {
 		APIKey:      "",
 		Model:       "gpt-4o-mini",
 		Temperature: 0.7,
-		BaseURL:     "https://api.openai.com/v1",
+		BaseURL:     OpenAIBaseURL,
 	}
 }
@@ -0,0 +1,51 @@
+package llm
+
+import "errors"
+
+const OpenRouterBaseURL = "https://openrouter.ai/api/v1"
+
+// OpenRouterConfig is a preset of the OpenAI compatible provider, with the
+// endpoint filled in and models named vendor/model.
+type OpenRouterConfig struct {
+	APIKey      string  `yaml:"api_key" json:"api_key" jsonschema:"title=API key,writeOnly=true"`
+	Model       string  `yaml:"model" json:"model" jsonschema:"description=Model name such as openai/gpt-4o-mini"`
+	Temperature float32 `yaml:"temperature" json:"temperature" jsonschema:"description=Sampling temperature,minimum=0,maximum=2"`
+	TopP        float32 `yaml:"top_p" json:"top_p" jsonschema:"title=Top P,description=Nucleus sampling. 0 uses the model default,minimum=0,maximum=1"`
+	MaxTokens   int32   `yaml:"max_tokens" json:"max_tokens" jsonschema:"description=Longest reply in tokens. 0 uses the model default,minimum=0"`
+}
+
+func (o *OpenRouterConfig) Validate() error {
+	if o.APIKey == "" {
+		return errors.New("api_key is required")
+	}
+	if o.Model == "" {
+		return errors.New("model is required")
+	}
+	return validateSampling(o.Temperature, o.TopP, o.MaxTokens)
+}
+
+// OpenAI returns the settings the compatible provider sends to OpenRouter.
+func (o *OpenRouterConfig) OpenAI() *OpenAIConfig {
+	return &OpenAIConfig{
+		APIKey:      o.APIKey,
+		Model:       o.Model,
+		BaseURL:     OpenRouterBaseURL,
+		Temperature: o.Temperature,
+		TopP:        o.TopP,
+		MaxTokens:   o.MaxTokens,
+	}
+}
+
+// Capabilities assumes a model with tool support. OpenRouter refuses requests
+// with tools for models without it, so pick one from its tools filter.
+func (o *OpenRouterConfig) Capabilities() Capabilities {
+	return Capabilities{Tools: true, Vision: true, SystemRole: true}
+}
+
+func GetDefaultOpenRouterConfig() *OpenRouterConfig {
+	return &OpenRouterConfig{
+		APIKey:      "",
+		Model:       "openai/gpt-4o-mini",
+		Temperature: 0.7,
+	}
+}
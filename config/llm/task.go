@@ -0,0 +1,63 @@
+package llm
+
+import "errors"
+
+// The tasks the agent gives the LLM: the conversation itself, pulling
+// memories out of it, and condensing it into a summary.
+const (
+	TaskChat       = "chat"
+	TaskExtraction = "extraction"
+	TaskSummary    = "summary"
+)
+
+var Tasks = []string{TaskChat, TaskExtraction, TaskSummary}
+
+// TaskConfig sends one task to its own model. Empty fields keep what the
+// main provider's config says, so an empty task is the main model.
+type TaskConfig struct {
+	Provider    string   `yaml:"provider" json:"provider" jsonschema:"description=Provider for this task. Empty uses the main provider"`
+	Model       string   `yaml:"model" json:"model" jsonschema:"description=Model for this task. Empty uses the model of the provider's config"`
+	Temperature *float32 `yaml:"temperature,omitempty" json:"temperature,omitempty" jsonschema:"description=Sampling temperature for this task. Unset uses the provider's,minimum=0,maximum=2"`
+	MaxTokens   int32    `yaml:"max_tokens" json:"max_tokens" jsonschema:"description=Longest reply in tokens for this task. 0 uses the provider's,minimum=0"`
+}
+
+func (t *TaskConfig) Validate() error {
+	if t.Temperature != nil && (*t.Temperature < 0 || *t.Temperature > 2) {
+		return errors.New("temperature must be between 0 and 2")
+	}
+	if t.MaxTokens < 0 {
+		return errors.New("max_tokens must not be negative")
+	}
+	return nil
+}
+
+type TasksConfig struct {
+	Chat       *TaskConfig `yaml:"chat" json:"chat" jsonschema:"description=Replies in the conversation"`
+	Extraction *TaskConfig `yaml:"extraction" json:"extraction" jsonschema:"description=Memories pulled out of a conversation in the background"`
+	Summary    *TaskConfig `yaml:"summary" json:"summary" jsonschema:"description=Summaries of long conversations"`
+}
+
+// Get returns the config of a task, nil when it has none.
+func (t *TasksConfig) Get(task string) *TaskConfig {
+	if t == nil {
+		return nil
+	}
+	switch task {
+	case TaskChat:
+		return t.Chat
+	case TaskExtraction:
+		return t.Extraction
+	case TaskSummary:
+		return t.Summary
+	default:
+		return nil
+	}
+}
+
+func GetDefaultTasksConfig() *TasksConfig {
+	return &TasksConfig{
+		Chat:       &TaskConfig{},
+		Extraction: &TaskConfig{},
+		Summary:    &TaskConfig{},
+	}
+}
@@ -0,0 +1,39 @@
+package llm
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+func validateBaseURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("base_url is not an http(s) URL: %q", raw)
+	}
+	return nil
+}
+
+func validateSampling(temperature, topP float32, maxTokens int32) error {
+	if temperature < 0 || temperature > 2 {
+		return errors.New("temperature must be between 0 and 2")
+	}
+	if topP < 0 || topP > 1 {
+		return errors.New("top_p must be between 0 and 1")
+	}
+	if maxTokens < 0 {
+		return errors.New("max_tokens must not be negative")
+	}
+	return nil
+}
+
+func anySlice[T any](values []T) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
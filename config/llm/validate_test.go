@@ -0,0 +1,111 @@
+package llm
+
+import "testing"
+
+func TestGeminiValidate(t *testing.T) {
+	valid := func() *GeminiConfig {
+		g := GetDefaultGeminiConfig()
+		g.APIKey = "key"
+		return g
+	}
+	if err := valid().Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for name, edit := range map[string]func(*GeminiConfig){
+		"no key":        func(g *GeminiConfig) { g.APIKey = "" },
+		"no model":      func(g *GeminiConfig) { g.Model = "" },
+		"bad url":       func(g *GeminiConfig) { g.BaseURL = "proxy.example.com" },
+		"hot":           func(g *GeminiConfig) { g.Temperature = 2.5 },
+		"top_p":         func(g *GeminiConfig) { g.TopP = 1.5 },
+		"max_tokens":    func(g *GeminiConfig) { g.MaxTokens = -1 },
+		"bad category":  func(g *GeminiConfig) { g.SafetySettings = map[string]string{"spam": "block_none"} },
+		"bad threshold": func(g *GeminiConfig) { g.SafetySettings = map[string]string{"harassment": "never"} },
+	} {
+		g := valid()
+		edit(g)
+		if err := g.Validate(); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
+
+func TestOpenAIValidate(t *testing.T) {
+	o := GetDefaultOpenAIConfig()
+	if err := o.Validate(); err == nil {
+		t.Error("OpenAI without a key accepted")
+	}
+	o.APIKey = "key"
+	if err := o.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	local := &OpenAIConfig{Model: "qwen3", BaseURL: "http://localhost:1234/v1", Temperature: 0.7}
+	if err := local.Validate(); err != nil {
+		t.Errorf("local server without a key: %v", err)
+	}
+	local.BaseURL = ""
+	if err := local.Validate(); err == nil {
+		t.Error("missing base_url accepted")
+	}
+}
+
+func TestAnthropicValidate(t *testing.T) {
+	a := GetDefaultAnthropicConfig()
+	if err := a.Validate(); err == nil {
+		t.Error("Anthropic without a key accepted")
+	}
+	a.APIKey = "key"
+	if err := a.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	a.Temperature = 1.5
+	if err := a.Validate(); err == nil {
+		t.Error("temperature above 1 accepted")
+	}
+}
+
+func TestOllamaValidate(t *testing.T) {
+	if err := GetDefaultOllamaConfig().Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for name, o := range map[string]*OllamaConfig{
+		"no address": {Model: "llama3.2", Timeout: 30},
+		"bad url":    {ServerAddress: "localhost:11434", Model: "llama3.2", Timeout: 30},
+		"no model":   {ServerAddress: "http://localhost:11434", Timeout: 30},
+		"no timeout": {ServerAddress: "http://localhost:11434", Model: "llama3.2"},
+	} {
+		if err := o.Validate(); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	for name, tc := range map[string]struct {
+		got, want Capabilities
+	}{
+		"gemini":            {GetDefaultGeminiConfig().Capabilities(), Capabilities{Tools: true, Vision: true, Audio: true, SystemRole: true}},
+		"claude":            {GetDefaultAnthropicConfig().Capabilities(), Capabilities{Vision: true, SystemRole: true}},
+		"claude 3 opus":     {(&AnthropicConfig{Model: "claude-3-opus-20240229"}).Capabilities(), Capabilities{Vision: true}},
+		"ollama tagged":     {(&OllamaConfig{Model: "llama3.2:3b"}).Capabilities(), Capabilities{Tools: true, SystemRole: true}},
+		"ollama vision":     {(&OllamaConfig{Model: "llava"}).Capabilities(), Capabilities{Vision: true, SystemRole: true}},
+		"ollama plain":      {(&OllamaConfig{Model: "phi3"}).Capabilities(), Capabilities{SystemRole: true}},
+		"openrouter preset": {GetDefaultOpenRouterConfig().Capabilities(), Capabilities{Tools: true, Vision: true, SystemRole: true}},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("%s: %+v, want %+v", name, tc.got, tc.want)
+		}
+	}
+}
+
+func TestOpenRouterPreset(t *testing.T) {
+	o := GetDefaultOpenRouterConfig()
+	if err := o.Validate(); err == nil {
+		t.Error("OpenRouter without a key accepted")
+	}
+	o.APIKey = "key"
+	compat := o.OpenAI()
+	if err := compat.Validate(); err != nil || compat.BaseURL != OpenRouterBaseURL || compat.IsOpenAI() {
+		t.Errorf("preset = %+v, %v", compat, err)
+	}
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Mirai3103/Project-Re-ENE/config/llm"
+)
+
+func TestLLMFallbacksValidate(t *testing.T) {
+	valid := func() *LLMConfig {
+		c := getDefaultLLMConfig()
+		c.GeminiConfig.APIKey = "key"
+		c.Fallbacks = []string{"ollama"}
+		return c
+	}
+	if err := valid().Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for name, edit := range map[string]func(*LLMConfig){
+		"unknown":     func(c *LLMConfig) { c.Fallbacks = []string{"cohere"} },
+		"main again":  func(c *LLMConfig) { c.Fallbacks = []string{"gemini"} },
+		"twice":       func(c *LLMConfig) { c.Fallbacks = []string{"ollama", "ollama"} },
+		"invalid":     func(c *LLMConfig) { c.Fallbacks = []string{"openrouter"} }, // no key
+		"no attempts": func(c *LLMConfig) { c.Retry.MaxAttempts = 0 },
+		"backoff":     func(c *LLMConfig) { c.Retry.MaxBackoffMs = 10 },
+		"no cooldown": func(c *LLMConfig) { c.CircuitBreaker.CooldownSeconds = 0 },
+	} {
+		c := valid()
+		edit(c)
+		if err := c.Validate(); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
+
+func TestForTask(t *testing.T) {
+	c := getDefaultLLMConfig()
+	c.GeminiConfig.APIKey = "key"
+	c.Fallbacks = []string{"openrouter", "ollama"}
+	cold := float32(0.2)
+	c.Tasks.Extraction = &llm.TaskConfig{Provider: "ollama", Model: "qwen2.5:3b"}
+	c.Tasks.Summary = &llm.TaskConfig{Model: "gemini-2.5-flash-lite", Temperature: &cold, MaxTokens: 300}
+
+	if chat := c.ForTask(llm.TaskChat); !reflect.DeepEqual(chat, c) {
+		t.Errorf("empty chat task changed the config: %+v", chat)
+	}
+	extraction := c.ForTask(llm.TaskExtraction)
+	if extraction.Provider != "ollama" || extraction.OllamaConfig.Model != "qwen2.5:3b" {
+		t.Errorf("extraction = %s %+v", extraction.Provider, extraction.OllamaConfig)
+	}
+	if fmt.Sprint(extraction.Fallbacks) != "[gemini openrouter]" {
+		t.Errorf("extraction fallbacks = %v", extraction.Fallbacks)
+	}
+	summary := c.ForTask(llm.TaskSummary).GeminiConfig
+	if summary.Model != "gemini-2.5-flash-lite" || summary.Temperature != 0.2 || summary.MaxTokens != 300 {
+		t.Errorf("summary = %+v", summary)
+	}
+	if c.GeminiConfig.Model != "gemini-2.5-flash" || c.OllamaConfig.Model != "llama3.2" {
+		t.Error("ForTask changed the main config")
+	}
+
+	hot := float32(1.5)
+	c.Tasks.Chat = &llm.TaskConfig{Provider: "anthropic", Temperature: &hot}
+	c.AnthropicConfig.APIKey = "key"
+	if err := c.Validate(); err == nil {
+		t.Error("temperature above Anthropic's limit accepted")
+	}
+	c.Tasks.Chat = nil
+	c.OpenRouterConfig.APIKey = "key"
+
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for name, task := range map[string]*llm.TaskConfig{
+		"temperature": {Provider: "ollama", Temperature: &cold},
+		"max_tokens":  {Provider: "ollama", MaxTokens: 300},
+	} {
+		c.Tasks.Extraction = task
+		if err := c.Validate(); err == nil {
+			t.Errorf("ollama task with %s accepted, the plugin would drop it", name)
+		}
+	}
+}
@@ -1,25 +1,45 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"path/filepath"
 
+	"github.com/Mirai3103/Project-Re-ENE/secrets"
 	"gopkg.in/yaml.v3"
 )
 
 func GetDefaultConfig() *Config {
 	return &Config{
+		Version:         CurrentVersion,
 		LLMConfig:       *getDefaultLLMConfig(),
 		TTSConfig:       *getDefaultTTSConfig(),
+		ASRConfig:       *GetDefaultASRConfig(),
 		LoggerConfig:    *getDefaultLoggerConfig(),
 		CharacterConfig: *getDefaultCharacterConfig(),
 		AgentConfig:     *getDefaultAgentConfig(),
 		ModelsConfig:    *getDefaultModelsConfig(),
+		EmbeddingConfig: *getDefaultEmbeddingConfig(),
+		MCPServerConfig: *getDefaultMCPServerConfig(),
+		APIServerConfig: *getDefaultAPIServerConfig(),
+		DiscordConfig:   *getDefaultDiscordConfig(),
+		TelegramConfig:  *getDefaultTelegramConfig(),
+		MeteringConfig:  *getDefaultMeteringConfig(),
+		TelemetryConfig: *getDefaultTelemetryConfig(),
 	}
 }
 
+// LoadConfig merges the config layers, see layers.go, with configPath as the
+// project file. On the first run, when no config file exists anywhere, it
+// writes the default config to configPath to be edited.
 func LoadConfig(configPath string) (*Config, error) {
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+	merged, l, created, err := readLayers(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if created {
 		defaultConfig := GetDefaultConfig()
 		data, err := yaml.Marshal(defaultConfig)
 		if err != nil {
@@ -28,32 +48,100 @@ func LoadConfig(configPath string) (*Config, error) {
 		if err := os.WriteFile(configPath, data, 0644); err != nil {
 			return nil, fmt.Errorf("write default config: %w", err)
 		}
-		fmt.Println("Created default config file:", configPath)
-		return defaultConfig, nil
+		fmt.Fprintln(os.Stderr, "Created default config file:", configPath)
+		l.project = flatten(toYAMLMap(defaultConfig), leafPaths())
+	} else {
+		fmt.Fprintln(os.Stderr, "Loading config from:", configPath)
 	}
 
-	// nếu file có → đọc
-	fmt.Println("Loading config from:", configPath)
-	data, err := os.ReadFile(configPath)
+	data, err := yaml.Marshal(merged)
 	if err != nil {
-		return nil, fmt.Errorf("read config: %w", err)
+		return nil, fmt.Errorf("marshal config: %w", err)
 	}
-
 	var cfg Config
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("unmarshal config: %w", err)
 	}
+	cfg.path = configPath
+	cfg.layers = l
+	cfg.store = secrets.Open(filepath.Dir(configPath))
+	if err := cfg.resolveSecrets(); err != nil {
+		return nil, err
+	}
+	if created {
+		return &cfg, nil // nothing to validate before the keys are filled in
+	}
+	if l.upgradedFrom >= 0 {
+		if err := backupConfig(configPath, l.upgradedFrom, l.projectData); err != nil {
+			return nil, err
+		}
+		if err := cfg.Save(); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(os.Stderr, "Upgraded config from version %d to %d\n", l.upgradedFrom, CurrentVersion)
+	}
 
 	return &cfg, cfg.Validate()
 }
 
+// PersistConfig writes cfg to configPath. Secrets loaded from a reference
+// are written back as that reference, not as the key.
 func PersistConfig(cfg *Config, configPath string) error {
-	data, err := yaml.Marshal(cfg)
+	out, err := cfg.Clone()
+	if err != nil {
+		return err
+	}
+	if err := cfg.restoreSecrets(out); err != nil {
+		return err
+	}
+	var doc any = out
+	if cfg.layers != nil {
+		node, err := cfg.layers.projectNode(out)
+		if err != nil {
+			return err
+		}
+		doc = node
+	}
+	data, err := yaml.Marshal(doc)
 	if err != nil {
 		return fmt.Errorf("marshal config: %w", err)
 	}
 	if err := os.WriteFile(configPath, data, 0644); err != nil {
 		return fmt.Errorf("write config: %w", err)
 	}
+	if cfg.layers != nil {
+		m := map[string]any{}
+		if err := yaml.Unmarshal(data, &m); err != nil {
+			return fmt.Errorf("unmarshal config: %w", err)
+		}
+		cfg.layers.project = flatten(m, leafPaths())
+	}
 	return nil
 }
+
+// Save writes the config back to the file it was loaded from.
+func (c *Config) Save() error {
+	if c.path == "" {
+		return errors.New("config was not loaded from a file")
+	}
+	return PersistConfig(c, c.path)
+}
+
+// Clone returns a deep copy, so a patch can be tried without touching the
+// running config. It goes through YAML, which also drops anything that would
+// not survive a save.
+func (c *Config) Clone() (*Config, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+	var clone Config
+	if err := yaml.Unmarshal(data, &clone); err != nil {
+		return nil, fmt.Errorf("unmarshal config: %w", err)
+	}
+	clone.path = c.path
+	clone.layers = c.layers
+	clone.store = c.store
+	clone.secrets = maps.Clone(c.secrets)
+	return &clone, nil
+}
@@ -2,15 +2,23 @@ package config
 
 import (
 	"errors"
+	"fmt"
+	"log/slog"
 	"slices"
 )
 
 var supportedLoggerModes = []string{"console", "file"}
 
 type LoggerConfig struct {
-	Mode     string `yaml:"mode"`
-	Level    string `yaml:"level"`
-	FilePath string `yaml:"file_path"`
+	Mode          string            `yaml:"mode" json:"mode" jsonschema:"description=Write logs to the console or to a file"`
+	Level         string            `yaml:"level" json:"level" jsonschema:"description=Lowest level that is logged"`
+	FilePath      string            `yaml:"file_path" json:"file_path" jsonschema:"description=Log file of file mode with one JSON record per line"`
+	MaxSizeMB     int               `yaml:"max_size_mb" json:"max_size_mb" jsonschema:"description=Size at which the log file is rotated,minimum=1"`
+	MaxAgeDays    int               `yaml:"max_age_days" json:"max_age_days" jsonschema:"description=Rotated files older than this are deleted. 0 keeps them,minimum=0"`
+	MaxBackups    int               `yaml:"max_backups" json:"max_backups" jsonschema:"description=Number of rotated files kept. 0 keeps them all,minimum=0"`
+	RotateDaily   bool              `yaml:"rotate_daily" json:"rotate_daily" jsonschema:"description=Also start a new file every day"`
+	Packages      map[string]string `yaml:"packages" json:"packages" jsonschema:"description=Level per package such as agent or package/elevenlabs that overrides level"`
+	RedactPrompts bool              `yaml:"redact_prompts" json:"redact_prompts" jsonschema:"description=Log only the length of prompts and replies and transcripts"`
 }
 
 func (c *LoggerConfig) Validate() error {
@@ -26,14 +34,43 @@ func (c *LoggerConfig) Validate() error {
 	if c.FilePath == "" && c.Mode == "file" {
 		return errors.New("file_path is required")
 	}
+	if c.Mode == "file" && c.MaxSizeMB <= 0 {
+		return errors.New("max_size_mb must be positive")
+	}
+	if c.MaxAgeDays < 0 || c.MaxBackups < 0 {
+		return errors.New("max_age_days and max_backups must not be negative")
+	}
+	if _, err := ParseLogLevel(c.Level); err != nil {
+		return err
+	}
+	for pkg, level := range c.Packages {
+		if _, err := ParseLogLevel(level); err != nil {
+			return fmt.Errorf("packages.%s: %w", pkg, err)
+		}
+	}
 	return nil
 }
 
+// ParseLogLevel reads one of supportedLogLevels.
+func ParseLogLevel(level string) (slog.Level, error) {
+	var l slog.Level
+	if !slices.Contains(supportedLogLevels, level) {
+		return l, fmt.Errorf("level %q is not supported", level)
+	}
+	err := l.UnmarshalText([]byte(level))
+	return l, err
+}
+
 func getDefaultLoggerConfig() *LoggerConfig {
 
 	return &LoggerConfig{
-		Mode:     "console",
-		Level:    "info",
-		FilePath: "logs/app.log",
+		Mode:          "console",
+		Level:         "info",
+		FilePath:      "logs/app.log",
+		MaxSizeMB:     10,
+		MaxAgeDays:    14,
+		MaxBackups:    5,
+		RotateDaily:   true,
+		RedactPrompts: true,
 	}
 }
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"errors"
+	"slices"
+)
+
+var supportedMCPServerTransports = []string{"stdio", "http"}
+
+// MCPServerConfig exposes Ene herself as an MCP server so editors and scripts
+// can use her memory and persona. The http transport runs next to the window
+// when enabled; stdio only makes sense headless, started with the -mcp flag.
+type MCPServerConfig struct {
+	Enable      bool   `yaml:"enable" json:"enable" jsonschema:"description=Expose Ene as an MCP server"`
+	Transport   string `yaml:"transport" json:"transport" jsonschema:"description=How MCP clients connect"`                                 // stdio | http
+	Address     string `yaml:"address" json:"address" jsonschema:"description=Listen address of the http transport"`                        // listen address of the http transport
+	BearerToken string `yaml:"bearer_token" json:"bearer_token" jsonschema:"description=Required on http requests when set,writeOnly=true"` // required on http requests when set
+	UserID      string `yaml:"user_id" json:"user_id" jsonschema:"description=User the conversations and facts belong to"`                  // user the conversations and facts belong to
+	CharacterID string `yaml:"character_id" json:"character_id" jsonschema:"description=Character the MCP tools act as"`
+}
+
+func (c *MCPServerConfig) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+	if !slices.Contains(supportedMCPServerTransports, c.Transport) {
+		return errors.New("mcp server transport is not supported: " + c.Transport)
+	}
+	if c.Transport == "http" && c.Address == "" {
+		return errors.New("address is required")
+	}
+	if c.UserID == "" || c.CharacterID == "" {
+		return errors.New("user_id and character_id are required")
+	}
+	return nil
+}
+
+func getDefaultMCPServerConfig() *MCPServerConfig {
+	return &MCPServerConfig{
+		Enable:      false,
+		Transport:   "http",
+		Address:     "127.0.0.1:8765",
+		UserID:      "huuhoang",
+		CharacterID: "1",
+	}
+}
@@ -1,7 +1,74 @@
 package config
 
-import "dario.cat/mergo"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
 
-func MergeConfig(dst *Config, patch *Config) error {
-	return mergo.Merge(dst, patch, mergo.WithOverride)
+// MergeConfig applies patch to dst. The patch is a JSON merge patch (RFC 7386)
+// keyed like the config JSON: keys it leaves out keep their value, so a
+// patch can set a flag to false, a number to 0 or a string to "", and null
+// removes a map entry or resets a field. Secrets the patch sends back as
+// RedactedValue keep their current value.
+func MergeConfig(dst *Config, patch json.RawMessage) error {
+	current, err := json.Marshal(dst)
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+	var doc, changes any
+	if err := json.Unmarshal(current, &doc); err != nil {
+		return fmt.Errorf("unmarshal config: %w", err)
+	}
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return fmt.Errorf("unmarshal patch: %w", err)
+	}
+	if _, ok := changes.(map[string]any); !ok {
+		return errors.New("patch must be a JSON object")
+	}
+	merged, err := json.Marshal(mergePatch(doc, changes))
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+	var next Config
+	if err := json.Unmarshal(merged, &next); err != nil {
+		return fmt.Errorf("apply patch: %w", err)
+	}
+
+	previous := map[string]string{}
+	for _, f := range secretFields(dst) {
+		previous[f.path] = f.value.String()
+	}
+	for _, f := range secretFields(&next) {
+		if f.value.String() == RedactedValue {
+			f.value.SetString(previous[f.path])
+		}
+	}
+	next.path = dst.path
+	next.layers = dst.layers
+	next.store = dst.store
+	next.secrets = dst.secrets
+	*dst = next
+	return nil
+}
+
+// mergePatch applies patch to target as RFC 7386 describes. Objects are
+// merged key by key, anything else replaces the target.
+func mergePatch(target, patch any) any {
+	changes, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	doc, ok := target.(map[string]any)
+	if !ok {
+		doc = map[string]any{}
+	}
+	for key, value := range changes {
+		if value == nil {
+			delete(doc, key)
+			continue
+		}
+		doc[key] = mergePatch(doc[key], value)
+	}
+	return doc
 }
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMergeConfigTurnsFlagOff(t *testing.T) {
+	cfg := GetDefaultConfig()
+	cfg.AgentConfig.ToolsConfig.MCP.Enable = true
+	cfg.TelegramConfig.PollTimeout = 30
+
+	if err := MergeConfig(cfg, json.RawMessage(`{"agent_config": {"tools_config": {"mcp": {"enable": false}}}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.AgentConfig.ToolsConfig.MCP.Enable {
+		t.Error("mcp.enable is still true")
+	}
+	if cfg.TelegramConfig.PollTimeout != 30 {
+		t.Errorf("poll_timeout = %d, fields missing from the patch must keep their value", cfg.TelegramConfig.PollTimeout)
+	}
+}
+
+func TestMergeConfigClearsString(t *testing.T) {
+	cfg := GetDefaultConfig()
+	cfg.TTSConfig.ElevenLabsConfig.VoiceID = "voice"
+	cfg.TTSConfig.ElevenLabsConfig.APIKey = "tts-key"
+	cfg.TTSConfig.ElevenLabsConfig.ModelID = "eleven_flash_v2_5"
+
+	patch := json.RawMessage(`{"tts_config": {"eleven_labs_config": {"voice_id": "", "api_key": ""}}}`)
+	if err := MergeConfig(cfg, patch); err != nil {
+		t.Fatal(err)
+	}
+	el := cfg.TTSConfig.ElevenLabsConfig
+	if el.VoiceID != "" || el.APIKey != "" {
+		t.Errorf("voice_id = %q, api_key = %q, want both cleared", el.VoiceID, el.APIKey)
+	}
+	if el.ModelID != "eleven_flash_v2_5" {
+		t.Errorf("model_id = %q, fields missing from the patch must keep their value", el.ModelID)
+	}
+}
+
+func TestMergeConfigNullRemovesMapEntry(t *testing.T) {
+	cfg := GetDefaultConfig()
+	if _, ok := cfg.AgentConfig.ToolsConfig.Permissions.Tools["googleSearch"]; !ok {
+		t.Fatal("the default config has no googleSearch rule")
+	}
+
+	patch := json.RawMessage(`{"agent_config": {"tools_config": {"permissions": {"tools": {"googleSearch": null, "fs_*": "deny"}}}}}`)
+	if err := MergeConfig(cfg, patch); err != nil {
+		t.Fatal(err)
+	}
+	tools := cfg.AgentConfig.ToolsConfig.Permissions.Tools
+	if _, ok := tools["googleSearch"]; ok {
+		t.Error("googleSearch rule was not removed")
+	}
+	if tools["fs_*"] != "deny" || tools["browserHistory"] != "ask" {
+		t.Errorf("tools = %v", tools)
+	}
+}
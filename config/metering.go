@@ -0,0 +1,65 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MeteringConfig is the usage accounting of the paid services: every LLM,
+// TTS, ASR and embedding call is recorded with an estimated cost.
+type MeteringConfig struct {
+	Enable        bool             `yaml:"enable" json:"enable" jsonschema:"description=Record the usage and estimated cost of every call"`
+	MonthlyBudget float64          `yaml:"monthly_budget" json:"monthly_budget" jsonschema:"description=Estimated spend per calendar month after which calls are refused. 0 has no cap,minimum=0"`
+	Prices        map[string]Price `yaml:"prices" json:"prices" jsonschema:"description=Prices by provider/model such as gemini/gemini-2.5-flash or by provider for all its models"`
+}
+
+// Price is what a provider charges, in the currency of the budget. Only the
+// units a service bills are set; calls without a price cost nothing.
+type Price struct {
+	InputPerMillionTokens  float64 `yaml:"input_per_million_tokens" json:"input_per_million_tokens" jsonschema:"minimum=0"`
+	OutputPerMillionTokens float64 `yaml:"output_per_million_tokens" json:"output_per_million_tokens" jsonschema:"minimum=0"`
+	PerThousandCharacters  float64 `yaml:"per_thousand_characters" json:"per_thousand_characters" jsonschema:"minimum=0"`
+	PerMinute              float64 `yaml:"per_minute" json:"per_minute" jsonschema:"description=Per minute of audio,minimum=0"`
+	PerCall                float64 `yaml:"per_call" json:"per_call" jsonschema:"minimum=0"`
+}
+
+func (c *MeteringConfig) Validate() error {
+	if c.MonthlyBudget < 0 {
+		return errors.New("monthly_budget must not be negative")
+	}
+	for key, p := range c.Prices {
+		if p.InputPerMillionTokens < 0 || p.OutputPerMillionTokens < 0 || p.PerThousandCharacters < 0 || p.PerMinute < 0 || p.PerCall < 0 {
+			return fmt.Errorf("prices.%s: prices must not be negative", key)
+		}
+	}
+	return nil
+}
+
+// PriceOf finds the price of a model, falling back to its provider's.
+func (c *MeteringConfig) PriceOf(provider, model string) (Price, bool) {
+	if p, ok := c.Prices[provider+"/"+model]; ok {
+		return p, true
+	}
+	p, ok := c.Prices[provider]
+	return p, ok
+}
+
+// The default prices are the list prices in USD when they were added, to be
+// adjusted to the plan in use.
+func getDefaultMeteringConfig() *MeteringConfig {
+	return &MeteringConfig{
+		Enable:        true,
+		MonthlyBudget: 0,
+		Prices: map[string]Price{
+			"gemini/gemini-2.5-flash":              {InputPerMillionTokens: 0.30, OutputPerMillionTokens: 2.50},
+			"gemini/gemini-2.5-flash-lite":         {InputPerMillionTokens: 0.10, OutputPerMillionTokens: 0.40},
+			"gemini/gemini-2.5-pro":                {InputPerMillionTokens: 1.25, OutputPerMillionTokens: 10},
+			"openai/gpt-4o-mini":                   {InputPerMillionTokens: 0.15, OutputPerMillionTokens: 0.60},
+			"anthropic/claude-sonnet-4-5-20250929": {InputPerMillionTokens: 3, OutputPerMillionTokens: 15},
+			"anthropic/claude-haiku-4-5-20251001":  {InputPerMillionTokens: 1, OutputPerMillionTokens: 5},
+			"elevenlabs/eleven_flash_v2_5":         {PerThousandCharacters: 0.05},
+			"elevenlabs/eleven_multilingual_v2":    {PerThousandCharacters: 0.10},
+			"elevenlabs/scribe_v1":                 {PerMinute: 0.0067},
+		},
+	}
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// CurrentVersion is the config format this build reads and writes. Bump it
+// together with a new entry in migrations.
+const CurrentVersion = 3
+
+// A migration upgrades a config file by one version. It works on the raw
+// YAML map so it can still read keys the Config struct no longer has.
+type migration func(m map[string]any) error
+
+// migrations[i] upgrades version i to i+1.
+var migrations = []migration{
+	migrateV0,
+	migrateV1,
+	migrateV2,
+}
+
+// migrateV0 only stamps the version. Files from before the version key use
+// the same keys as version 1, and the sections they leave out come from the
+// layers below the project, so nothing is added here.
+func migrateV0(m map[string]any) error {
+	return nil
+}
+
+// migrateV1 keeps the model Gemini users were getting. Before version 2 the
+// gemini model key was ignored and gemini-2.5-flash always used, while the
+// default file said gemini-2.0-flash.
+func migrateV1(m map[string]any) error {
+	llm, _ := m["llm_config"].(map[string]any)
+	gemini, _ := llm["gemini_config"].(map[string]any)
+	if gemini != nil && gemini["model"] == "gemini-2.0-flash" {
+		gemini["model"] = "gemini-2.5-flash"
+	}
+	return nil
+}
+
+// migrateV2 drops is_audio_supported and is_vision_supported. What the model
+// accepts now comes from the provider, see LLMConfig.Capabilities.
+func migrateV2(m map[string]any) error {
+	if llm, ok := m["llm_config"].(map[string]any); ok {
+		delete(llm, "is_audio_supported")
+		delete(llm, "is_vision_supported")
+	}
+	return nil
+}
+
+// versionOf reads the version key, missing in files older than version 1.
+func versionOf(m map[string]any) (int, error) {
+	v, ok := m["version"]
+	if !ok || v == nil {
+		return 0, nil
+	}
+	n, ok := v.(int)
+	if !ok || n < 0 {
+		return 0, fmt.Errorf("version must be a positive number, got %v", v)
+	}
+	return n, nil
+}
+
+// migrate upgrades m in place to CurrentVersion and returns the version it
+// started from.
+func migrate(m map[string]any) (int, error) {
+	from, err := versionOf(m)
+	if err != nil {
+		return 0, err
+	}
+	if from > CurrentVersion {
+		return from, fmt.Errorf("config version %d is newer than this app supports (%d)", from, CurrentVersion)
+	}
+	for v := from; v < CurrentVersion; v++ {
+		if err := migrations[v](m); err != nil {
+			return from, fmt.Errorf("migrate config from version %d: %w", v, err)
+		}
+		m["version"] = v + 1
+	}
+	return from, nil
+}
+
+// backupConfig keeps a copy of a file before it is upgraded, as
+// config.yaml.v0.bak. An existing backup of the same version is kept, it
+// holds the file as it was before the first attempt.
+func backupConfig(path string, version int, data []byte) error {
+	backup := fmt.Sprintf("%s.v%d.bak", path, version)
+	if _, err := os.Stat(backup); err == nil {
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.WriteFile(backup, data, 0644); err != nil {
+		return fmt.Errorf("backup config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMigrationsCoverEveryVersion(t *testing.T) {
+	if len(migrations) != CurrentVersion {
+		t.Fatalf("%d migrations for version %d", len(migrations), CurrentVersion)
+	}
+}
+
+func TestMigrateV0(t *testing.T) {
+	m := map[string]any{
+		"llm_config": map[string]any{"provider": "gemini"},
+		"asr_config": map[string]any{"provider": "elevenlabs", "input_device": "mic"},
+	}
+	from, err := migrate(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != 0 || m["version"] != CurrentVersion {
+		t.Errorf("from %d to %v", from, m["version"])
+	}
+	if m["asr_config"].(map[string]any)["input_device"] != "mic" {
+		t.Error("existing section replaced")
+	}
+	if len(m) != 3 {
+		t.Errorf("sections added to the file: %v", m)
+	}
+}
+
+// An upgraded project file must not hide what the layers below it set.
+func TestMigrateV0KeepsLowerLayers(t *testing.T) {
+	dir := useLayerFiles(t, "", "telegram_config:\n  poll_timeout: 99\n", layerProject)
+	path := filepath.Join(dir, "config.yaml")
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.TelegramConfig.PollTimeout != 99 {
+		t.Errorf("poll_timeout = %d, want the user value 99", cfg.TelegramConfig.PollTimeout)
+	}
+	origins, err := Explain(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, o := range origins {
+		if o.Path == "telegram_config.poll_timeout" && (o.Layer != LayerUser || o.Value != "99") {
+			t.Errorf("poll_timeout = %s from %s, want 99 from %s", o.Value, o.Layer, LayerUser)
+		}
+	}
+	if strings.Contains(readFile(t, path), "telegram_config") {
+		t.Error("telegram_config written to the project file")
+	}
+}
+
+func TestMigrateRejectsNewerVersion(t *testing.T) {
+	if _, err := migrate(map[string]any{"version": CurrentVersion + 1}); err == nil {
+		t.Error("newer version accepted")
+	}
+	if _, err := migrate(map[string]any{"version": "two"}); err == nil {
+		t.Error("non numeric version accepted")
+	}
+}
+
+func TestMigrateCurrentVersionIsUntouched(t *testing.T) {
+	m := map[string]any{"version": CurrentVersion}
+	from, err := migrate(m)
+	if err != nil || from != CurrentVersion || len(m) != 1 {
+		t.Errorf("from %d, %v, %v", from, m, err)
+	}
+}
+
+func TestLoadUpgradesOldFile(t *testing.T) {
+	dir := useLayerFiles(t, "", "", layerProject)
+	path := filepath.Join(dir, "config.yaml")
+
+	if _, err := LoadConfig(path); err != nil {
+		t.Fatal(err)
+	}
+	if backup := readFile(t, path+".v0.bak"); backup != layerProject {
+		t.Errorf("backup = %q", backup)
+	}
+	var upgraded map[string]any
+	if err := yaml.Unmarshal([]byte(readFile(t, path)), &upgraded); err != nil {
+		t.Fatal(err)
+	}
+	if upgraded["version"] != CurrentVersion {
+		t.Errorf("version = %v", upgraded["version"])
+	}
+	if _, ok := upgraded["embedding_config"]; ok {
+		t.Error("embedding_config written although the file did not have it")
+	}
+
+	// A second load finds nothing to upgrade.
+	if err := os.Remove(path + ".v0.bak"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path + ".v0.bak"); !os.IsNotExist(err) {
+		t.Error("current file upgraded again")
+	}
+	if !strings.Contains(readFile(t, path), "api_key: project-key") {
+		t.Error("project values lost")
+	}
+}
+
+func TestMigrateV1(t *testing.T) {
+	gemini := map[string]any{"model": "gemini-2.0-flash"}
+	m := map[string]any{"version": 1, "llm_config": map[string]any{"gemini_config": gemini}}
+	if _, err := migrate(m); err != nil {
+		t.Fatal(err)
+	}
+	if gemini["model"] != "gemini-2.5-flash" || m["version"] != CurrentVersion {
+		t.Errorf("model %v, version %v", gemini["model"], m["version"])
+	}
+
+	// A model picked on purpose is kept, and files without the section pass.
+	gemini = map[string]any{"model": "gemini-2.5-pro"}
+	if err := migrateV1(map[string]any{"llm_config": map[string]any{"gemini_config": gemini}}); err != nil || gemini["model"] != "gemini-2.5-pro" {
+		t.Errorf("model %v, %v", gemini["model"], err)
+	}
+	if err := migrateV1(map[string]any{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMigrateV2(t *testing.T) {
+	llm := map[string]any{"provider": "gemini", "is_audio_supported": true, "is_vision_supported": false}
+	if err := migrateV2(map[string]any{"llm_config": llm}); err != nil {
+		t.Fatal(err)
+	}
+	if len(llm) != 1 {
+		t.Errorf("llm_config = %v", llm)
+	}
+}
@@ -6,7 +6,22 @@ import (
 )
 
 type ModelsConfig struct {
-	ModelDir string `yaml:"model_dir"`
+	ModelDir string `yaml:"model_dir" json:"model_dir" jsonschema:"description=Folder holding the Live2D models"`
+	// EmotionMappings overrides, per model folder name, which motion and
+	// expression play for each emotion tag. Emotions without an entry are
+	// matched by name against the model's motion groups and expressions.
+	EmotionMappings map[string]map[string]EmotionMapping `yaml:"emotion_mappings" json:"emotion_mappings" jsonschema:"description=Per model overrides of the motion and expression played for each emotion"`
+	// Limits for imported model archives, counted on the unpacked files. Zero
+	// uses the default.
+	MaxImportSizeMB int `yaml:"max_import_size_mb" json:"max_import_size_mb" jsonschema:"title=Max import size (MB),description=Largest unpacked size of an imported model. 0 uses the default,minimum=0"`
+	MaxImportFileMB int `yaml:"max_import_file_mb" json:"max_import_file_mb" jsonschema:"title=Max import file size (MB),description=Largest single file of an imported model. 0 uses the default,minimum=0"`
+	MaxImportFiles  int `yaml:"max_import_files" json:"max_import_files" jsonschema:"description=Most files an imported model may contain. 0 uses the default,minimum=0"`
+}
+
+type EmotionMapping struct {
+	MotionGroup string `yaml:"motion_group" json:"motion_group" jsonschema:"description=Motion group played for the emotion"`
+	MotionIndex int    `yaml:"motion_index" json:"motion_index" jsonschema:"description=Motion inside the group,minimum=0"`
+	Expression  string `yaml:"expression" json:"expression" jsonschema:"description=Expression set for the emotion"`
 }
 
 func (m *ModelsConfig) Validate() error {
@@ -16,11 +31,45 @@ func (m *ModelsConfig) Validate() error {
 	if err := os.MkdirAll(m.ModelDir, 0755); err != nil {
 		return errors.New("failed to create model dir")
 	}
+	if m.MaxImportSizeMB < 0 || m.MaxImportFileMB < 0 || m.MaxImportFiles < 0 {
+		return errors.New("max_import_size_mb, max_import_file_mb and max_import_files cannot be negative")
+	}
+	for model, mappings := range m.EmotionMappings {
+		for emotion, mapping := range mappings {
+			if mapping.MotionGroup == "" && mapping.Expression == "" {
+				return errors.New("emotion mapping " + model + "/" + emotion + " needs a motion_group or an expression")
+			}
+			if mapping.MotionIndex < 0 {
+				return errors.New("emotion mapping " + model + "/" + emotion + " has a negative motion_index")
+			}
+		}
+	}
 	return nil
 }
 
+// ImportLimits returns the archive limits in bytes, with defaults for unset
+// values.
+func (m *ModelsConfig) ImportLimits() (totalBytes, fileBytes int64, files int) {
+	def := getDefaultModelsConfig()
+	totalMB, fileMB, files := m.MaxImportSizeMB, m.MaxImportFileMB, m.MaxImportFiles
+	if totalMB == 0 {
+		totalMB = def.MaxImportSizeMB
+	}
+	if fileMB == 0 {
+		fileMB = def.MaxImportFileMB
+	}
+	if files == 0 {
+		files = def.MaxImportFiles
+	}
+	return int64(totalMB) << 20, int64(fileMB) << 20, files
+}
+
 func getDefaultModelsConfig() *ModelsConfig {
 	return &ModelsConfig{
-		ModelDir: "./resources/live2d",
+		ModelDir:        "./resources/live2d",
+		EmotionMappings: map[string]map[string]EmotionMapping{},
+		MaxImportSizeMB: 512,
+		MaxImportFileMB: 256,
+		MaxImportFiles:  2000,
 	}
 }
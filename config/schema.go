@@ -0,0 +1,156 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/invopop/jsonschema"
+)
+
+var supportedLogLevels = []string{"debug", "info", "warn", "error"}
+
+// Schema returns the JSON Schema of the config file, for settings forms that
+// render themselves. Keys follow the YAML names, which the JSON of GetConfig
+// and PatchConfig uses too. Secrets are marked writeOnly and every field
+// carries its default.
+func Schema() ([]byte, error) {
+	return schemaOnce()
+}
+
+var schemaOnce = sync.OnceValues(func() ([]byte, error) {
+	r := &jsonschema.Reflector{
+		FieldNameTag:               "yaml",
+		DoNotReference:             true,
+		ExpandedStruct:             true,
+		RequiredFromJSONSchemaTags: true, // Validate decides what is required
+	}
+	s := r.Reflect(&Config{})
+	s.Title = "Ene config"
+	addTitles(s)
+	addDefaults(s, reflect.ValueOf(GetDefaultConfig()))
+	return json.MarshalIndent(s, "", "  ")
+})
+
+// addTitles names every property after its key when the field has no title
+// tag, "api_key" becomes "API key".
+func addTitles(s *jsonschema.Schema) {
+	if s == nil {
+		return
+	}
+	if s.Properties != nil {
+		for pair := s.Properties.Oldest(); pair != nil; pair = pair.Next() {
+			if pair.Value.Title == "" {
+				pair.Value.Title = titleOf(pair.Key)
+			}
+			addTitles(pair.Value)
+		}
+	}
+	addTitles(s.Items)
+	addTitles(s.AdditionalProperties)
+}
+
+var acronyms = map[string]string{
+	"api": "API", "url": "URL", "id": "ID", "ids": "IDs", "llm": "LLM", "tts": "TTS",
+	"asr": "ASR", "mcp": "MCP", "mb": "MB", "ms": "(ms)",
+}
+
+func titleOf(key string) string {
+	words := strings.Split(key, "_")
+	for i, w := range words {
+		if a, ok := acronyms[w]; ok {
+			words[i] = a
+		} else if i == 0 {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// addDefaults copies the values of the default config into the schema.
+// Sections without a default, like an unset provider, are left alone and
+// secrets never get one.
+func addDefaults(s *jsonschema.Schema, v reflect.Value) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		if !s.WriteOnly {
+			s.Default = v.Interface()
+		}
+		return
+	}
+	if s.Properties == nil {
+		return
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+		if prop, ok := s.Properties.Get(key); ok {
+			addDefaults(prop, v.Field(i))
+		}
+	}
+}
+
+func setEnum[T any](s *jsonschema.Schema, key string, values []T) {
+	prop, ok := s.Properties.Get(key)
+	if !ok {
+		return
+	}
+	prop.Enum = enumOf(values)
+}
+
+func enumOf[T any](values []T) []any {
+	enum := make([]any, len(values))
+	for i, v := range values {
+		enum[i] = v
+	}
+	return enum
+}
+
+func (LLMConfig) JSONSchemaExtend(s *jsonschema.Schema) {
+	setEnum(s, "provider", supportedLLMProviders)
+	if prop, ok := s.Properties.Get("fallbacks"); ok && prop.Items != nil {
+		prop.Items.Enum = enumOf(supportedLLMProviders)
+	}
+	if tasks, ok := s.Properties.Get("tasks"); ok && tasks.Properties != nil {
+		for pair := tasks.Properties.Oldest(); pair != nil; pair = pair.Next() {
+			setEnum(pair.Value, "provider", append([]string{""}, supportedLLMProviders...))
+		}
+	}
+}
+
+func (TTSConfig) JSONSchemaExtend(s *jsonschema.Schema) {
+	setEnum(s, "provider", supportedTTSProviders)
+}
+
+func (ASRConfig) JSONSchemaExtend(s *jsonschema.Schema) {
+	setEnum(s, "provider", supportedASRProviders)
+}
+
+func (EmbeddingConfig) JSONSchemaExtend(s *jsonschema.Schema) {
+	setEnum(s, "provider", supportedEmbeddingProviders)
+}
+
+func (LoggerConfig) JSONSchemaExtend(s *jsonschema.Schema) {
+	setEnum(s, "mode", supportedLoggerModes)
+	setEnum(s, "level", supportedLogLevels)
+	if prop, ok := s.Properties.Get("packages"); ok && prop.AdditionalProperties != nil {
+		prop.AdditionalProperties.Enum = enumOf(supportedLogLevels)
+	}
+}
+
+func (TelemetryConfig) JSONSchemaExtend(s *jsonschema.Schema) {
+	setEnum(s, "exporter", supportedTelemetryExporters)
+}
+
+func (MCPServerConfig) JSONSchemaExtend(s *jsonschema.Schema) {
+	setEnum(s, "transport", supportedMCPServerTransports)
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Mirai3103/Project-Re-ENE/config/llm"
+)
+
+type schemaNode struct {
+	Title      string                `json:"title"`
+	Enum       []any                 `json:"enum"`
+	Default    any                   `json:"default"`
+	WriteOnly  bool                  `json:"writeOnly"`
+	Properties map[string]schemaNode `json:"properties"`
+}
+
+func loadSchema(t *testing.T) schemaNode {
+	t.Helper()
+	data, err := Schema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s schemaNode
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func prop(t *testing.T, s schemaNode, keys ...string) schemaNode {
+	t.Helper()
+	for _, k := range keys {
+		next, ok := s.Properties[k]
+		if !ok {
+			t.Fatalf("missing property %v", keys)
+		}
+		s = next
+	}
+	return s
+}
+
+func TestSchemaEnumsAndDefaults(t *testing.T) {
+	s := loadSchema(t)
+
+	provider := prop(t, s, "llm_config", "provider")
+	if len(provider.Enum) != len(supportedLLMProviders) || provider.Default != "gemini" {
+		t.Errorf("llm provider = %+v", provider)
+	}
+	if got := prop(t, s, "llm_config", "tasks", "summary", "provider").Enum; len(got) != len(supportedLLMProviders)+1 {
+		t.Errorf("task providers = %v", got)
+	}
+	mode := prop(t, s, "logger_config", "mode")
+	if mode.Default != "console" || len(mode.Enum) != len(supportedLoggerModes) {
+		t.Errorf("logger mode = %+v", mode)
+	}
+	if got := prop(t, s, "logger_config", "level").Enum; len(got) != len(supportedLogLevels) {
+		t.Errorf("log levels = %v", got)
+	}
+}
+
+func TestSchemaSecretsAreWriteOnly(t *testing.T) {
+	s := loadSchema(t)
+
+	for _, keys := range [][]string{
+		{"llm_config", "gemini_config", "api_key"},
+		{"tts_config", "eleven_labs_config", "api_key"},
+		{"discord_config", "token"},
+	} {
+		p := prop(t, s, keys...)
+		if !p.WriteOnly {
+			t.Errorf("%v is not writeOnly", keys)
+		}
+		if p.Default != nil {
+			t.Errorf("%v has a default", keys)
+		}
+	}
+}
+
+func TestSchemaTitles(t *testing.T) {
+	var walk func(path string, n schemaNode)
+	walk = func(path string, n schemaNode) {
+		for k, p := range n.Properties {
+			if p.Title == "" {
+				t.Errorf("%s.%s has no title", path, k)
+			}
+			walk(path+"."+k, p)
+		}
+	}
+	walk("", loadSchema(t))
+
+	if got := titleOf("base_url"); got != "Base URL" {
+		t.Errorf("titleOf(base_url) = %q", got)
+	}
+}
+
+// TestSchemaKeysMatchJSON checks that the config the UI reads as JSON has
+// the keys the schema describes, so a form built from it finds its values.
+func TestSchemaKeysMatchJSON(t *testing.T) {
+	cfg := GetDefaultConfig()
+	temperature := float32(0.5) // omitted while unset
+	for _, task := range []*llm.TaskConfig{cfg.LLMConfig.Tasks.Chat, cfg.LLMConfig.Tasks.Extraction, cfg.LLMConfig.Tasks.Summary} {
+		task.Temperature = &temperature
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		t.Fatal(err)
+	}
+	var walk func(path string, n schemaNode, v map[string]any)
+	walk = func(path string, n schemaNode, v map[string]any) {
+		for k, p := range n.Properties {
+			value, ok := v[k]
+			if !ok {
+				t.Errorf("%s.%s is in the schema but not in the JSON", path, k)
+				continue
+			}
+			if object, ok := value.(map[string]any); ok && len(p.Properties) > 0 {
+				walk(path+"."+k, p, object)
+			}
+		}
+	}
+	walk("", loadSchema(t), values)
+}
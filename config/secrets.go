@@ -0,0 +1,183 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Mirai3103/Project-Re-ENE/secrets"
+)
+
+// RedactedValue stands in for a secret in configs sent to the UI. A patch
+// that sends it back leaves the secret as it was.
+const RedactedValue = "********"
+
+// secretRef remembers the reference a secret was loaded from and the value
+// it resolved to, so Save writes the reference back instead of the key.
+type secretRef struct {
+	ref   string
+	value string
+}
+
+// SecretInfo describes one secret field without its value.
+type SecretInfo struct {
+	Path   string `json:"path"`
+	Source string `json:"source"` // plain, env, file or keyring
+	Set    bool   `json:"set"`
+}
+
+type secretField struct {
+	path  string
+	value reflect.Value
+}
+
+// secretFields lists the string fields tagged writeOnly, the same ones the
+// schema marks as secrets, by their dotted YAML path.
+func secretFields(c *Config) []secretField {
+	var fields []secretField
+	var walk func(prefix string, v reflect.Value)
+	walk = func(prefix string, v reflect.Value) {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return
+		}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			key, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+			if !f.IsExported() || key == "" || key == "-" {
+				continue
+			}
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			if f.Type.Kind() == reflect.String && strings.Contains(f.Tag.Get("jsonschema"), "writeOnly=true") {
+				fields = append(fields, secretField{path: path, value: v.Field(i)})
+				continue
+			}
+			walk(path, v.Field(i))
+		}
+	}
+	walk("", reflect.ValueOf(c))
+	return fields
+}
+
+// resolveSecrets replaces ${ENV}, file: and keyring: references with the
+// secrets they point at and remembers the references for Save.
+func (c *Config) resolveSecrets() error {
+	c.secrets = map[string]secretRef{}
+	for _, f := range secretFields(c) {
+		ref := f.value.String()
+		if secrets.Kind(ref) == secrets.KindPlain {
+			continue
+		}
+		value, err := c.store.Resolve(ref)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.path, err)
+		}
+		c.secrets[f.path] = secretRef{ref: ref, value: value}
+		f.value.SetString(value)
+	}
+	return nil
+}
+
+// restoreSecrets puts the references back into out, a copy of c about to be
+// written. A changed keyring secret is saved to the store first; a changed
+// env or file secret can't be written back, so it is saved in plain text.
+func (c *Config) restoreSecrets(out *Config) error {
+	for _, f := range secretFields(out) {
+		ref, ok := c.secrets[f.path]
+		if !ok {
+			continue
+		}
+		value := f.value.String()
+		switch {
+		case value == ref.value:
+		case value != "" && secrets.Kind(ref.ref) == secrets.KindKeyring && c.store != nil:
+			if err := c.store.Set(secrets.KeyringName(ref.ref), value); err != nil {
+				return err
+			}
+			c.secrets[f.path] = secretRef{ref: ref.ref, value: value}
+		default:
+			delete(c.secrets, f.path)
+			continue
+		}
+		f.value.SetString(ref.ref)
+	}
+	return nil
+}
+
+func (c *Config) fromEnv(path string) bool {
+	return c.layers != nil && c.layers.origins[path].Layer == LayerEnv
+}
+
+// Secrets tells where each secret comes from, for the settings screen.
+func (c *Config) Secrets() []SecretInfo {
+	var infos []SecretInfo
+	for _, f := range secretFields(c) {
+		source := secrets.KindPlain
+		if ref, ok := c.secrets[f.path]; ok {
+			source = secrets.Kind(ref.ref)
+		}
+		infos = append(infos, SecretInfo{Path: f.path, Source: source, Set: f.value.String() != ""})
+	}
+	return infos
+}
+
+// SecretValues lists the secrets that are set, for logs to hide.
+func (c *Config) SecretValues() []string {
+	var values []string
+	for _, f := range secretFields(c) {
+		if v := f.value.String(); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// Redacted returns a copy of the config with every secret replaced by
+// RedactedValue, safe to hand to the frontend.
+func (c *Config) Redacted() (*Config, error) {
+	out, err := c.Clone()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range secretFields(out) {
+		if f.value.String() != "" {
+			f.value.SetString(RedactedValue)
+		}
+	}
+	return out, nil
+}
+
+// MigrateSecrets moves the plain text secrets into the secret store, the OS
+// keyring or the encrypted file, and saves the config with keyring:
+// references in their place. It returns how many were moved.
+func (c *Config) MigrateSecrets() (int, error) {
+	if c.store == nil {
+		return 0, errors.New("config was not loaded from a file")
+	}
+	moved := 0
+	for _, f := range secretFields(c) {
+		value := f.value.String()
+		if _, ok := c.secrets[f.path]; ok || value == "" || c.fromEnv(f.path) {
+			continue
+		}
+		if err := c.store.Set(f.path, value); err != nil {
+			return moved, err
+		}
+		c.secrets[f.path] = secretRef{ref: secrets.KeyringRef(f.path), value: value}
+		moved++
+	}
+	if moved == 0 {
+		return 0, nil
+	}
+	return moved, c.Save()
+}
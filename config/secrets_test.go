@@ -0,0 +1,149 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+// writeConfig saves a valid default config with the given secrets and
+// returns its path.
+func writeConfig(t *testing.T, llmKey, ttsKey string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	def := GetDefaultConfig()
+	def.LLMConfig.GeminiConfig.APIKey = llmKey
+	def.TTSConfig.ElevenLabsConfig.APIKey = ttsKey
+	def.TTSConfig.ElevenLabsConfig.ModelID = "eleven_flash_v2_5"
+	def.ModelsConfig.ModelDir = t.TempDir()
+	if err := PersistConfig(def, path); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestLoadResolvesSecretReferences(t *testing.T) {
+	t.Setenv("ENE_TEST_LLM_KEY", "llm-from-env")
+	path := writeConfig(t, "${ENE_TEST_LLM_KEY}", "file:tts.key")
+	if err := os.WriteFile(filepath.Join(filepath.Dir(path), "tts.key"), []byte("tts-from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.LLMConfig.GeminiConfig.APIKey != "llm-from-env" || cfg.TTSConfig.ElevenLabsConfig.APIKey != "tts-from-file" {
+		t.Fatalf("secrets not resolved: %q, %q", cfg.LLMConfig.GeminiConfig.APIKey, cfg.TTSConfig.ElevenLabsConfig.APIKey)
+	}
+
+	cfg.CharacterConfig.Live2DModelName = "ene"
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data := readFile(t, path)
+	if !strings.Contains(data, "${ENE_TEST_LLM_KEY}") || !strings.Contains(data, "file:tts.key") {
+		t.Error("references not written back")
+	}
+	if strings.Contains(data, "llm-from-env") || strings.Contains(data, "tts-from-file") {
+		t.Error("resolved secret written to the file")
+	}
+
+	sources := map[string]string{}
+	for _, s := range cfg.Secrets() {
+		sources[s.Path] = s.Source
+	}
+	if sources["llm_config.gemini_config.api_key"] != "env" || sources["tts_config.eleven_labs_config.api_key"] != "file" {
+		t.Errorf("sources = %v", sources)
+	}
+}
+
+func TestLoadFailsOnUnsetVariable(t *testing.T) {
+	path := writeConfig(t, "${ENE_TEST_UNSET_KEY}", "tts-key")
+	if _, err := LoadConfig(path); err == nil || !strings.Contains(err.Error(), "llm_config.gemini_config.api_key") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg, err := LoadConfig(writeConfig(t, "llm-key", "tts-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	red, err := cfg.Redacted()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if red.LLMConfig.GeminiConfig.APIKey != RedactedValue || red.DiscordConfig.Token != "" {
+		t.Errorf("redacted = %q, %q", red.LLMConfig.GeminiConfig.APIKey, red.DiscordConfig.Token)
+	}
+	if cfg.LLMConfig.GeminiConfig.APIKey != "llm-key" {
+		t.Error("running config redacted")
+	}
+
+	// the redacted config sent back as a patch keeps the secrets
+	patch, err := json.Marshal(red)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := MergeConfig(cfg, patch); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.LLMConfig.GeminiConfig.APIKey != "llm-key" || cfg.TTSConfig.ElevenLabsConfig.APIKey != "tts-key" {
+		t.Errorf("secrets after merging the redacted config: %q, %q", cfg.LLMConfig.GeminiConfig.APIKey, cfg.TTSConfig.ElevenLabsConfig.APIKey)
+	}
+}
+
+func TestMigrateSecrets(t *testing.T) {
+	keyring.MockInit()
+	path := writeConfig(t, "llm-key", "tts-key")
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	moved, err := cfg.MigrateSecrets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved != 2 {
+		t.Errorf("moved = %d, want 2", moved)
+	}
+	data := readFile(t, path)
+	if strings.Contains(data, "llm-key") || !strings.Contains(data, "keyring:llm_config.gemini_config.api_key") {
+		t.Fatalf("config file after migration:\n%s", data)
+	}
+
+	// A key changed later goes to the keyring, not the file.
+	cfg.LLMConfig.GeminiConfig.APIKey = "new-llm-key"
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(readFile(t, path), "new-llm-key") {
+		t.Error("changed secret written to the file")
+	}
+
+	again, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.LLMConfig.GeminiConfig.APIKey != "new-llm-key" || again.TTSConfig.ElevenLabsConfig.APIKey != "tts-key" {
+		t.Errorf("reloaded secrets = %q, %q", again.LLMConfig.GeminiConfig.APIKey, again.TTSConfig.ElevenLabsConfig.APIKey)
+	}
+	if moved, _ := again.MigrateSecrets(); moved != 0 {
+		t.Errorf("second migration moved %d", moved)
+	}
+}
@@ -0,0 +1,61 @@
+package config
+
+import "errors"
+
+// TelegramConfig runs Ene as a Telegram bot. Each chat is its own
+// conversation.
+type TelegramConfig struct {
+	Enable bool   `yaml:"enable" json:"enable" jsonschema:"description=Run Ene as a Telegram bot"`
+	Token  string `yaml:"token" json:"token" jsonschema:"description=Bot token from BotFather,writeOnly=true"`
+	// APIURL points at the Bot API, change it for a local Bot API server.
+	APIURL      string `yaml:"api_url" json:"api_url" jsonschema:"title=API URL,description=Bot API endpoint. Change it for a local Bot API server"`
+	CharacterID string `yaml:"character_id" json:"character_id" jsonschema:"description=Character that answers on Telegram"`
+	// AllowedUsers lists the Telegram user IDs the bot answers. Empty means
+	// nobody, so a leaked bot name cannot spend the API budget.
+	AllowedUsers []int64 `yaml:"allowed_users" json:"allowed_users" jsonschema:"description=Telegram user IDs the bot answers. Empty means nobody"`
+	// Users links Telegram user IDs to existing users. Everyone else gets a
+	// "telegram:<id>" user.
+	Users        map[int64]string `yaml:"users" json:"users" jsonschema:"description=Telegram user IDs linked to existing users"`
+	VoiceReplies bool             `yaml:"voice_replies" json:"voice_replies" jsonschema:"description=Also answer with a voice message"`        // also answer with a voice message
+	PollTimeout  int              `yaml:"poll_timeout" json:"poll_timeout" jsonschema:"description=Long polling timeout in seconds,minimum=1"` // long polling timeout in seconds
+}
+
+func (c *TelegramConfig) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+	if c.Token == "" {
+		return errors.New("telegram token is required")
+	}
+	if c.APIURL == "" {
+		return errors.New("telegram api_url is required")
+	}
+	if c.CharacterID == "" {
+		return errors.New("character_id is required")
+	}
+	if c.PollTimeout <= 0 {
+		return errors.New("poll_timeout must be greater than 0")
+	}
+	return nil
+}
+
+func (c *TelegramConfig) IsAllowed(userID int64) bool {
+	for _, id := range c.AllowedUsers {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func getDefaultTelegramConfig() *TelegramConfig {
+	return &TelegramConfig{
+		Enable:       false,
+		APIURL:       "https://api.telegram.org",
+		CharacterID:  "1",
+		AllowedUsers: []int64{},
+		Users:        map[int64]string{},
+		VoiceReplies: true,
+		PollTimeout:  30,
+	}
+}
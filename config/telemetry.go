@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+	"slices"
+)
+
+var supportedTelemetryExporters = []string{"otlp", "file"}
+
+// TelemetryConfig is the OpenTelemetry export of traces and metrics. Changes
+// take effect on restart. Collector headers, such as an API key, are read
+// from OTEL_EXPORTER_OTLP_HEADERS so they stay out of the file.
+type TelemetryConfig struct {
+	Enable          bool    `yaml:"enable" json:"enable" jsonschema:"description=Export traces and metrics of every turn"`
+	Exporter        string  `yaml:"exporter" json:"exporter" jsonschema:"description=Send to an OTLP collector over HTTP or write JSON lines to files"`
+	Endpoint        string  `yaml:"endpoint" json:"endpoint" jsonschema:"description=OTLP/HTTP collector address such as localhost:4318"`
+	Insecure        bool    `yaml:"insecure" json:"insecure" jsonschema:"description=Use plain HTTP for the collector"`
+	Directory       string  `yaml:"directory" json:"directory" jsonschema:"description=Folder of traces.jsonl and metrics.jsonl in file mode"`
+	ServiceName     string  `yaml:"service_name" json:"service_name" jsonschema:"description=service.name of the exported data"`
+	SampleRatio     float64 `yaml:"sample_ratio" json:"sample_ratio" jsonschema:"description=Share of turns that are traced,minimum=0,maximum=1"`
+	MetricsInterval int     `yaml:"metrics_interval" json:"metrics_interval" jsonschema:"description=Seconds between two metric exports,minimum=1"`
+}
+
+func (c *TelemetryConfig) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+	if !slices.Contains(supportedTelemetryExporters, c.Exporter) {
+		return errors.New("exporter is not supported")
+	}
+	if c.Exporter == "otlp" && c.Endpoint == "" {
+		return errors.New("endpoint is required")
+	}
+	if c.Exporter == "file" && c.Directory == "" {
+		return errors.New("directory is required")
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return errors.New("sample_ratio must be between 0 and 1")
+	}
+	if c.MetricsInterval <= 0 {
+		return errors.New("metrics_interval must be positive")
+	}
+	return nil
+}
+
+func getDefaultTelemetryConfig() *TelemetryConfig {
+	return &TelemetryConfig{
+		Enable:          false,
+		Exporter:        "file",
+		Endpoint:        "localhost:4318",
+		Insecure:        true,
+		Directory:       "telemetry",
+		ServiceName:     "ene",
+		SampleRatio:     1,
+		MetricsInterval: 30,
+	}
+}
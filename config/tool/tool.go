@@ -1,28 +1,135 @@
 package tools
 
+import (
+	"errors"
+	"path"
+	"slices"
+
+	"github.com/invopop/jsonschema"
+)
+
 type ToolConfig struct {
-	GoogleSearch   GoogleSearchToolConfig   `yaml:"google_search"`
-	MCP            MCPToolConfig            `yaml:"mcp"`
-	BrowserHistory BrowserHistoryToolConfig `yaml:"browser_history"`
+	GoogleSearch   GoogleSearchToolConfig   `yaml:"google_search" json:"google_search" jsonschema:"title=Google search"`
+	MCP            MCPToolConfig            `yaml:"mcp" json:"mcp" jsonschema:"title=MCP servers"`
+	BrowserHistory BrowserHistoryToolConfig `yaml:"browser_history" json:"browser_history" jsonschema:"title=Browser history"`
+	Permissions    PermissionConfig         `yaml:"permissions" json:"permissions" jsonschema:"title=Tool permissions"`
 }
 
 type GoogleSearchToolConfig struct {
-	APIKey         string `yaml:"api_key"`
-	SearchEngineID string `yaml:"search_engine_id"`
-	BaseURL        string `yaml:"base_url"`
-	Num            int    `yaml:"num"`
-	Lang           string `yaml:"lang"`
-	Enable         bool   `yaml:"enable"`
+	APIKey         string `yaml:"api_key" json:"api_key" jsonschema:"title=API key,writeOnly=true"`
+	SearchEngineID string `yaml:"search_engine_id" json:"search_engine_id" jsonschema:"description=Programmable Search Engine ID"`
+	BaseURL        string `yaml:"base_url" json:"base_url" jsonschema:"description=Override the API endpoint"`
+	Num            int    `yaml:"num" json:"num" jsonschema:"description=Results per search,minimum=1"`
+	Lang           string `yaml:"lang" json:"lang" jsonschema:"description=Result language"`
+	Enable         bool   `yaml:"enable" json:"enable" jsonschema:"description=Let the agent search the web"`
+}
+
+func (c *GoogleSearchToolConfig) Validate() error {
+	if c.Enable && c.Num < 1 {
+		return errors.New("google_search num must be at least 1")
+	}
+	return nil
 }
 
 type BrowserHistoryToolConfig struct {
-	Enable            bool   `yaml:"enable"`
-	ChromeProfilePath string `yaml:"chrome_profile_path"`
+	Enable            bool   `yaml:"enable" json:"enable" jsonschema:"description=Let the agent read the browser history"`
+	ChromeProfilePath string `yaml:"chrome_profile_path" json:"chrome_profile_path" jsonschema:"description=Chromium profile folder to read the history from"`
 }
 
 type MCPToolConfig struct {
-	ConfigPath string `yaml:"config_path"`
-	Enable     bool   `yaml:"enable"`
+	ConfigPath string `yaml:"config_path" json:"config_path" jsonschema:"description=MCP servers file in the mcpServers format"`
+	Enable     bool   `yaml:"enable" json:"enable" jsonschema:"description=Connect to the MCP servers in the file"`
+}
+
+type ToolPermission string
+
+const (
+	PermissionAllow ToolPermission = "allow"
+	PermissionAsk   ToolPermission = "ask"
+	PermissionDeny  ToolPermission = "deny"
+)
+
+var supportedToolPermissions = []ToolPermission{PermissionAllow, PermissionAsk, PermissionDeny}
+
+// PermissionConfig decides whether a tool call runs directly, waits for the
+// user to confirm it, or is never offered to the model.
+type PermissionConfig struct {
+	Default ToolPermission `yaml:"default" json:"default" jsonschema:"description=Policy for tools without a rule"`
+	// Tools maps a tool name to its policy. Keys may be glob patterns such as
+	// "filesystem_*" to cover every tool of an MCP server.
+	Tools          map[string]ToolPermission `yaml:"tools" json:"tools" jsonschema:"description=Policy per tool name. Glob patterns such as filesystem_* are allowed"`
+	ConfirmTimeout int                       `yaml:"confirm_timeout" json:"confirm_timeout" jsonschema:"description=Seconds to wait for the user before rejecting a call,minimum=0"` // seconds to wait for the user before rejecting
+	// Unattended replaces ask when nobody can answer the confirmation, as in
+	// headless, bot and API runs. It is allow or deny and defaults to deny.
+	Unattended ToolPermission `yaml:"unattended" json:"unattended" jsonschema:"description=Policy for ask tools when no window or terminal can confirm them"`
+}
+
+// JSONSchemaExtend lists the policies in the config schema.
+func (PermissionConfig) JSONSchemaExtend(s *jsonschema.Schema) {
+	enum := make([]any, len(supportedToolPermissions))
+	for i, p := range supportedToolPermissions {
+		enum[i] = p
+	}
+	if prop, ok := s.Properties.Get("default"); ok {
+		prop.Enum = enum
+	}
+	if prop, ok := s.Properties.Get("tools"); ok && prop.AdditionalProperties != nil {
+		prop.AdditionalProperties.Enum = enum
+	}
+	if prop, ok := s.Properties.Get("unattended"); ok {
+		prop.Enum = []any{PermissionAllow, PermissionDeny}
+	}
+}
+
+func (c *PermissionConfig) Validate() error {
+	if c.Default != "" && !slices.Contains(supportedToolPermissions, c.Default) {
+		return errors.New("tool permission is not supported: " + string(c.Default))
+	}
+	for pattern, permission := range c.Tools {
+		if !slices.Contains(supportedToolPermissions, permission) {
+			return errors.New("tool permission is not supported: " + string(permission))
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.New("invalid tool pattern: " + pattern)
+		}
+	}
+	if c.ConfirmTimeout < 0 {
+		return errors.New("confirm_timeout must not be negative")
+	}
+	if c.Unattended != "" && c.Unattended != PermissionAllow && c.Unattended != PermissionDeny {
+		return errors.New("unattended tool permission must be allow or deny: " + string(c.Unattended))
+	}
+	return nil
+}
+
+// ResolveUnattended returns the policy for an ask tool that nobody can confirm.
+func (c *PermissionConfig) ResolveUnattended() ToolPermission {
+	if c.Unattended == PermissionAllow {
+		return PermissionAllow
+	}
+	return PermissionDeny
+}
+
+// Resolve returns the policy for a tool. An exact name wins over a pattern,
+// and tools matching nothing fall back to Default, which itself defaults to ask.
+func (c *PermissionConfig) Resolve(toolName string) ToolPermission {
+	if permission, ok := c.Tools[toolName]; ok {
+		return permission
+	}
+	// pick the longest matching pattern so "fs_read*" beats "fs_*"
+	var best string
+	for pattern := range c.Tools {
+		if matched, _ := path.Match(pattern, toolName); matched && len(pattern) > len(best) {
+			best = pattern
+		}
+	}
+	if best != "" {
+		return c.Tools[best]
+	}
+	if c.Default == "" {
+		return PermissionAsk
+	}
+	return c.Default
 }
 
 func getDefaultMCPToolConfig() *MCPToolConfig {
@@ -42,9 +149,23 @@ func getDefaultGoogleSearchToolConfig() *GoogleSearchToolConfig {
 	}
 }
 
+func getDefaultPermissionConfig() *PermissionConfig {
+	return &PermissionConfig{
+		Default: PermissionAsk,
+		Tools: map[string]ToolPermission{
+			"googleSearch":            PermissionAllow,
+			"browserHistory":          PermissionAsk,
+			"browserHistoryByKeyword": PermissionAsk,
+		},
+		ConfirmTimeout: 60,
+		Unattended:     PermissionDeny,
+	}
+}
+
 func GetDefaultToolConfig() *ToolConfig {
 	return &ToolConfig{
 		GoogleSearch: *getDefaultGoogleSearchToolConfig(),
 		MCP:          *getDefaultMCPToolConfig(),
+		Permissions:  *getDefaultPermissionConfig(),
 	}
 }
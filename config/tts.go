@@ -10,8 +10,8 @@ import (
 var supportedTTSProviders = []string{"elevenlabs"}
 
 type TTSConfig struct {
-	Provider         string                `yaml:"provider"`
-	ElevenLabsConfig *tts.ElevenLabsConfig `yaml:"eleven_labs_config"`
+	Provider         string                `yaml:"provider" json:"provider" jsonschema:"description=Speech synthesis service"`
+	ElevenLabsConfig *tts.ElevenLabsConfig `yaml:"eleven_labs_config" json:"eleven_labs_config" jsonschema:"title=ElevenLabs"`
 }
 
 func (c *TTSConfig) Validate() error {
@@ -5,9 +5,12 @@ import (
 )
 
 type ElevenLabsConfig struct {
-	APIKey  string `yaml:"api_key"`
-	ModelID string `yaml:"model_id"`
-	VoiceID string `yaml:"voice_id"`
+	APIKey  string `yaml:"api_key" json:"api_key" jsonschema:"title=API key,writeOnly=true"`
+	ModelID string `yaml:"model_id" json:"model_id" jsonschema:"description=Speech model such as eleven_flash_v2_5"`
+	VoiceID string `yaml:"voice_id" json:"voice_id" jsonschema:"description=Voice used for the character"`
+	// Timestamps asks for character timings along with the audio, used for
+	// lip-sync visemes.
+	Timestamps bool `yaml:"timestamps" json:"timestamps" jsonschema:"description=Request character timings for lip-sync"`
 }
 
 func (e *ElevenLabsConfig) Validate() error {
@@ -25,8 +28,9 @@ func (e *ElevenLabsConfig) Validate() error {
 
 func GetDefaultElevenLabsConfig() *ElevenLabsConfig {
 	return &ElevenLabsConfig{
-		APIKey:  "",
-		ModelID: "",
-		VoiceID: "21m00Tcm4TlvDq8ikWAM",
+		APIKey:     "",
+		ModelID:    "",
+		VoiceID:    "21m00Tcm4TlvDq8ikWAM",
+		Timestamps: true,
 	}
 }
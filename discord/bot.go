@@ -0,0 +1,197 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/package/ogg"
+	"github.com/Mirai3103/Project-Re-ENE/package/transcode"
+	"github.com/Mirai3103/Project-Re-ENE/package/utils"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+)
+
+// maxMessageLength is Discord's limit for one message.
+const maxMessageLength = 2000
+
+// Agent is what the bot needs from agent.Agent.
+type Agent interface {
+	Chat(ctx context.Context, input *agent.FlowInput) (string, error)
+	InferSpeak(ctx context.Context, input *agent.FlowInput) (chan agent.SpeakResponse, error)
+}
+
+// UserStore keeps a users row for every Discord user Ene talks to.
+type UserStore interface {
+	UpsertUser(ctx context.Context, arg store.UpsertUserParams) error
+}
+
+// OpusEncoder turns TTS audio into 20 ms Opus frames.
+type OpusEncoder func(ctx context.Context, audio []byte) ([][]byte, error)
+
+// Bot answers mentions and direct messages, and talks in voice channels it
+// is asked to join.
+type Bot struct {
+	cfg     *config.DiscordConfig
+	gateway Gateway
+	agent   Agent
+	users   UserStore
+	encode  OpusEncoder
+	logger  *slog.Logger
+
+	mu     sync.Mutex
+	voices map[string]*voiceSession // by guild ID
+}
+
+func New(cfg *config.Config, ag *agent.Agent, store *store.Queries, logger *slog.Logger) *Bot {
+	return newBot(&cfg.DiscordConfig, nil, ag, store, EncodeOpusFrames, logger)
+}
+
+func newBot(cfg *config.DiscordConfig, gateway Gateway, ag Agent, users UserStore, encode OpusEncoder, logger *slog.Logger) *Bot {
+	return &Bot{
+		cfg:     cfg,
+		gateway: gateway,
+		agent:   ag,
+		users:   users,
+		encode:  encode,
+		logger:  logger.With("component", "discord"),
+		voices:  make(map[string]*voiceSession),
+	}
+}
+
+// Start connects to Discord and handles messages until ctx is done.
+func (b *Bot) Start(ctx context.Context) error {
+	if b.gateway == nil {
+		gateway, err := NewGateway(b.cfg.Token)
+		if err != nil {
+			return err
+		}
+		b.gateway = gateway
+	}
+	b.gateway.OnMessage(func(m Message) {
+		go b.handleMessage(ctx, m)
+	})
+	if err := b.gateway.Open(); err != nil {
+		return err
+	}
+	b.logger.Info("Discord bot connected", "bot_user_id", b.gateway.BotUserID())
+	go func() {
+		<-ctx.Done()
+		b.Stop()
+	}()
+	return nil
+}
+
+func (b *Bot) Stop() {
+	b.mu.Lock()
+	voices := b.voices
+	b.voices = make(map[string]*voiceSession)
+	b.mu.Unlock()
+	for _, v := range voices {
+		v.close()
+	}
+	if b.gateway != nil {
+		b.gateway.Close()
+	}
+}
+
+func (b *Bot) handleMessage(ctx context.Context, m Message) {
+	botID := b.gateway.BotUserID()
+	if m.AuthorIsBot || m.AuthorID == botID {
+		return
+	}
+
+	content := strings.TrimSpace(m.Content)
+	if command, ok := strings.CutPrefix(content, b.cfg.CommandPrefix); ok && b.cfg.CommandPrefix != "" && !m.IsDM() {
+		switch strings.ToLower(strings.TrimSpace(command)) {
+		case "join":
+			b.joinVoice(ctx, m)
+			return
+		case "leave":
+			b.leaveVoice(m)
+			return
+		}
+	}
+
+	if !m.IsDM() && !mentions(m, botID) {
+		return
+	}
+	text := stripMention(content, botID)
+	if text == "" {
+		return
+	}
+
+	userID, err := b.ensureUser(ctx, m.AuthorID, m.AuthorName)
+	if err != nil {
+		b.logger.Error("Cannot save discord user", "user_id", m.AuthorID, "error", err)
+		return
+	}
+	b.gateway.Typing(m.ChannelID)
+
+	reply, err := b.agent.Chat(ctx, &agent.FlowInput{
+		Text:           text,
+		ConversationID: conversationID(m.ChannelID),
+		UserID:         userID,
+		CharacterID:    b.cfg.CharacterID,
+	})
+	if err != nil {
+		b.logger.Error("Discord chat failed", "channel_id", m.ChannelID, "error", err)
+		b.gateway.Reply(m.ChannelID, m.ID, "...")
+		return
+	}
+	for i, part := range utils.SplitMessage(reply, maxMessageLength) {
+		replyTo := m.ID
+		if i > 0 {
+			replyTo = ""
+		}
+		if err := b.gateway.Reply(m.ChannelID, replyTo, part); err != nil {
+			b.logger.Error("Cannot send discord reply", "channel_id", m.ChannelID, "error", err)
+			return
+		}
+	}
+}
+
+// ensureUser maps a Discord user to a users row and keeps its name fresh.
+// An empty name leaves the stored one alone.
+func (b *Bot) ensureUser(ctx context.Context, discordID, name string) (string, error) {
+	if userID, ok := b.cfg.Users[discordID]; ok {
+		return userID, nil
+	}
+	params := store.UpsertUserParams{ID: "discord:" + discordID}
+	if name != "" {
+		params.Name = utils.Ptr(name)
+	}
+	return params.ID, b.users.UpsertUser(ctx, params)
+}
+
+func conversationID(channelID string) string {
+	return "discord:" + channelID
+}
+
+func mentions(m Message, userID string) bool {
+	for _, id := range m.MentionIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func stripMention(content, userID string) string {
+	content = strings.ReplaceAll(content, "<@"+userID+">", "")
+	content = strings.ReplaceAll(content, "<@!"+userID+">", "")
+	return strings.TrimSpace(content)
+}
+
+// EncodeOpusFrames converts TTS audio to the 20 ms stereo Opus frames a
+// voice connection sends.
+func EncodeOpusFrames(ctx context.Context, audio []byte) ([][]byte, error) {
+	data, err := transcode.ToOggOpus(ctx, audio, 2)
+	if err != nil {
+		return nil, err
+	}
+	return ogg.ReadOpusPackets(bytes.NewReader(data))
+}
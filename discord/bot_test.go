@@ -0,0 +1,320 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/package/ogg"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+)
+
+const botID = "999"
+
+type reply struct {
+	channelID, messageID, content string
+}
+
+type fakeGateway struct {
+	mu           sync.Mutex
+	replies      []reply
+	typing       []string
+	voiceChannel string
+	joined       []string
+	conn         *fakeVoice
+}
+
+func (g *fakeGateway) Open() error             { return nil }
+func (g *fakeGateway) Close() error            { return nil }
+func (g *fakeGateway) BotUserID() string       { return botID }
+func (g *fakeGateway) OnMessage(func(Message)) {}
+func (g *fakeGateway) Typing(channelID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.typing = append(g.typing, channelID)
+	return nil
+}
+
+func (g *fakeGateway) Reply(channelID, messageID, content string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.replies = append(g.replies, reply{channelID, messageID, content})
+	return nil
+}
+
+func (g *fakeGateway) UserVoiceChannel(guildID, userID string) (string, error) {
+	return g.voiceChannel, nil
+}
+
+func (g *fakeGateway) JoinVoice(guildID, channelID string) (VoiceConnection, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.joined = append(g.joined, channelID)
+	return g.conn, nil
+}
+
+type fakeVoice struct {
+	packets    chan VoicePacket
+	onSpeaking func(userID string, ssrc uint32)
+	sent       chan []byte
+	closed     chan struct{}
+	once       sync.Once
+}
+
+func newFakeVoice() *fakeVoice {
+	return &fakeVoice{
+		packets: make(chan VoicePacket, 64),
+		sent:    make(chan []byte, 64),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (v *fakeVoice) Packets() <-chan VoicePacket { return v.packets }
+func (v *fakeVoice) OnSpeaking(handler func(userID string, ssrc uint32)) {
+	v.onSpeaking = handler
+}
+func (v *fakeVoice) Speaking(bool) error { return nil }
+func (v *fakeVoice) SendOpus(ctx context.Context, frame []byte) error {
+	v.sent <- frame
+	return nil
+}
+func (v *fakeVoice) Disconnect() error {
+	v.once.Do(func() { close(v.closed) })
+	return nil
+}
+
+type fakeAgent struct {
+	mu     sync.Mutex
+	inputs []*agent.FlowInput
+	reply  string
+}
+
+func (a *fakeAgent) Chat(ctx context.Context, input *agent.FlowInput) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.inputs = append(a.inputs, input)
+	return a.reply, nil
+}
+
+func (a *fakeAgent) InferSpeak(ctx context.Context, input *agent.FlowInput) (chan agent.SpeakResponse, error) {
+	a.mu.Lock()
+	a.inputs = append(a.inputs, input)
+	a.mu.Unlock()
+	ch := make(chan agent.SpeakResponse, 1)
+	ch <- agent.SpeakResponse{Text: a.reply, AudioBuffer: []byte("mp3")}
+	close(ch)
+	return ch, nil
+}
+
+func (a *fakeAgent) lastInput() *agent.FlowInput {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.inputs) == 0 {
+		return nil
+	}
+	return a.inputs[len(a.inputs)-1]
+}
+
+type fakeUsers struct {
+	mu      sync.Mutex
+	upserts []store.UpsertUserParams
+}
+
+func (u *fakeUsers) UpsertUser(ctx context.Context, arg store.UpsertUserParams) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.upserts = append(u.upserts, arg)
+	return nil
+}
+
+func newTestBot(t *testing.T) (*Bot, *fakeGateway, *fakeAgent, *fakeUsers) {
+	t.Helper()
+	cfg := &config.DiscordConfig{
+		Enable:         true,
+		Token:          "token",
+		CharacterID:    "1",
+		Users:          map[string]string{"42": "huuhoang"},
+		CommandPrefix:  "!",
+		VoiceSilenceMs: 50,
+	}
+	gateway := &fakeGateway{conn: newFakeVoice()}
+	ag := &fakeAgent{reply: "xin chào"}
+	users := &fakeUsers{}
+	encode := func(ctx context.Context, audio []byte) ([][]byte, error) {
+		return [][]byte{[]byte("frame-1"), []byte("frame-2")}, nil
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return newBot(cfg, gateway, ag, users, encode, logger), gateway, ag, users
+}
+
+func TestMentionIsAnswered(t *testing.T) {
+	bot, gateway, ag, users := newTestBot(t)
+	bot.handleMessage(context.Background(), Message{
+		ID:         "m1",
+		ChannelID:  "c1",
+		GuildID:    "g1",
+		AuthorID:   "7",
+		AuthorName: "Takane",
+		Content:    "<@999> hôm nay thế nào?",
+		MentionIDs: []string{botID},
+	})
+
+	input := ag.lastInput()
+	if input == nil {
+		t.Fatal("agent was not called")
+	}
+	if input.Text != "hôm nay thế nào?" {
+		t.Errorf("text = %q, mention should be stripped", input.Text)
+	}
+	if input.ConversationID != "discord:c1" || input.UserID != "discord:7" || input.CharacterID != "1" {
+		t.Errorf("unexpected input %+v", input)
+	}
+	if len(users.upserts) != 1 || users.upserts[0].ID != "discord:7" || *users.upserts[0].Name != "Takane" {
+		t.Errorf("upserts = %+v", users.upserts)
+	}
+	if len(gateway.replies) != 1 || gateway.replies[0] != (reply{"c1", "m1", "xin chào"}) {
+		t.Errorf("replies = %+v", gateway.replies)
+	}
+	if len(gateway.typing) != 1 {
+		t.Errorf("typing indicator not sent")
+	}
+}
+
+func TestIgnoredMessages(t *testing.T) {
+	bot, gateway, ag, _ := newTestBot(t)
+	for _, m := range []Message{
+		{ID: "1", ChannelID: "c1", GuildID: "g1", AuthorID: "7", Content: "no mention here"},
+		{ID: "2", ChannelID: "c1", GuildID: "g1", AuthorID: "8", AuthorIsBot: true, Content: "<@999> hi", MentionIDs: []string{botID}},
+		{ID: "3", ChannelID: "c1", GuildID: "g1", AuthorID: botID, Content: "<@999> hi", MentionIDs: []string{botID}},
+		{ID: "4", ChannelID: "c1", GuildID: "g1", AuthorID: "7", Content: "<@999>", MentionIDs: []string{botID}},
+	} {
+		bot.handleMessage(context.Background(), m)
+	}
+	if len(ag.inputs) != 0 || len(gateway.replies) != 0 {
+		t.Errorf("expected no answers, got inputs=%d replies=%+v", len(ag.inputs), gateway.replies)
+	}
+}
+
+func TestDirectMessageUsesMappedUser(t *testing.T) {
+	bot, _, ag, users := newTestBot(t)
+	bot.handleMessage(context.Background(), Message{
+		ID: "m1", ChannelID: "dm1", AuthorID: "42", AuthorName: "Hoàng", Content: "ê Ene",
+	})
+	input := ag.lastInput()
+	if input == nil || input.UserID != "huuhoang" || input.ConversationID != "discord:dm1" {
+		t.Fatalf("unexpected input %+v", input)
+	}
+	if len(users.upserts) != 0 {
+		t.Errorf("mapped users must not be upserted, got %+v", users.upserts)
+	}
+}
+
+func TestLongReplyIsSplit(t *testing.T) {
+	bot, gateway, ag, _ := newTestBot(t)
+	ag.reply = strings.Repeat("chữ ", 1200)
+	bot.handleMessage(context.Background(), Message{ID: "m1", ChannelID: "dm1", AuthorID: "7", Content: "kể chuyện đi"})
+
+	if len(gateway.replies) != 3 {
+		t.Fatalf("got %d parts, want 3", len(gateway.replies))
+	}
+	var joined []string
+	for i, r := range gateway.replies {
+		if n := len([]rune(r.content)); n > maxMessageLength {
+			t.Errorf("part %d has %d runes", i, n)
+		}
+		if (i == 0) != (r.messageID == "m1") {
+			t.Errorf("only the first part should be a reply, part %d replies to %q", i, r.messageID)
+		}
+		joined = append(joined, r.content)
+	}
+	if strings.Join(joined, " ") != strings.TrimSpace(ag.reply) {
+		t.Error("parts do not add up to the reply")
+	}
+}
+
+func TestJoinRequiresVoiceChannel(t *testing.T) {
+	bot, gateway, _, _ := newTestBot(t)
+	bot.handleMessage(context.Background(), Message{ID: "m1", ChannelID: "c1", GuildID: "g1", AuthorID: "7", Content: "!join"})
+	if len(gateway.joined) != 0 {
+		t.Errorf("joined %v without the user in voice", gateway.joined)
+	}
+	if len(gateway.replies) != 1 {
+		t.Errorf("expected a hint reply, got %+v", gateway.replies)
+	}
+}
+
+func TestVoiceUtterance(t *testing.T) {
+	bot, gateway, ag, _ := newTestBot(t)
+	gateway.voiceChannel = "v1"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bot.handleMessage(ctx, Message{ID: "m1", ChannelID: "c1", GuildID: "g1", AuthorID: "7", Content: "!join"})
+	if len(gateway.joined) != 1 || gateway.joined[0] != "v1" {
+		t.Fatalf("joined = %v", gateway.joined)
+	}
+
+	conn := gateway.conn
+	conn.onSpeaking("7", 1234)
+	var spoken [][]byte
+	for i := 0; i < minUtteranceFrames; i++ {
+		frame := []byte{0xfc, byte(i), 0xff, 0xfe}
+		spoken = append(spoken, frame)
+		conn.packets <- VoicePacket{SSRC: 1234, Opus: frame}
+	}
+
+	for _, want := range []string{"frame-1", "frame-2"} {
+		select {
+		case got := <-conn.sent:
+			if string(got) != want {
+				t.Errorf("sent %q, want %q", got, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("no voice reply")
+		}
+	}
+
+	input := ag.lastInput()
+	if input.ConversationID != "discord:v1" || input.UserID != "discord:7" {
+		t.Errorf("unexpected input %+v", input)
+	}
+	packets, err := ogg.ReadOpusPackets(bytes.NewReader(input.Audio))
+	if err != nil {
+		t.Fatalf("audio is not Ogg Opus: %v", err)
+	}
+	if len(packets) != len(spoken) {
+		t.Errorf("got %d packets, want %d", len(packets), len(spoken))
+	}
+
+	bot.handleMessage(ctx, Message{ID: "m2", ChannelID: "c1", GuildID: "g1", AuthorID: "7", Content: "!leave"})
+	select {
+	case <-conn.closed:
+	case <-time.After(time.Second):
+		t.Fatal("voice connection not closed on leave")
+	}
+}
+
+func TestShortNoiseIsDropped(t *testing.T) {
+	bot, gateway, ag, _ := newTestBot(t)
+	gateway.voiceChannel = "v1"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bot.handleMessage(ctx, Message{ID: "m1", ChannelID: "c1", GuildID: "g1", AuthorID: "7", Content: "!join"})
+
+	conn := gateway.conn
+	conn.onSpeaking("7", 1)
+	for i := 0; i < 3; i++ {
+		conn.packets <- VoicePacket{SSRC: 1, Opus: []byte{0xfc, 1, 2, 3}}
+	}
+	time.Sleep(300 * time.Millisecond)
+	if ag.lastInput() != nil {
+		t.Error("short noise should not reach the agent")
+	}
+}
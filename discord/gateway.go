@@ -0,0 +1,52 @@
+package discord
+
+import "context"
+
+// Message is a text message seen by the bot.
+type Message struct {
+	ID          string
+	ChannelID   string
+	GuildID     string // empty for direct messages
+	AuthorID    string
+	AuthorName  string
+	AuthorIsBot bool
+	Content     string
+	MentionIDs  []string
+}
+
+func (m Message) IsDM() bool {
+	return m.GuildID == ""
+}
+
+// VoicePacket is one Opus frame received from a speaker.
+type VoicePacket struct {
+	SSRC uint32
+	Opus []byte
+}
+
+// Gateway is the part of Discord the bot talks to. It is an interface so the
+// bot logic can be tested without a network connection.
+type Gateway interface {
+	Open() error
+	Close() error
+	// BotUserID is valid once Open has returned.
+	BotUserID() string
+	OnMessage(handler func(Message))
+	Reply(channelID, messageID, content string) error
+	Typing(channelID string) error
+	// UserVoiceChannel returns the voice channel a user is in, or "".
+	UserVoiceChannel(guildID, userID string) (string, error)
+	JoinVoice(guildID, channelID string) (VoiceConnection, error)
+}
+
+// VoiceConnection is a joined voice channel.
+type VoiceConnection interface {
+	// Packets is closed when the connection ends.
+	Packets() <-chan VoicePacket
+	// OnSpeaking tells which user an SSRC belongs to.
+	OnSpeaking(handler func(userID string, ssrc uint32))
+	Speaking(speaking bool) error
+	// SendOpus queues one 20 ms Opus frame, the connection paces them.
+	SendOpus(ctx context.Context, frame []byte) error
+	Disconnect() error
+}
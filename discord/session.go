@@ -0,0 +1,161 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// sessionGateway implements Gateway with discordgo.
+type sessionGateway struct {
+	session *discordgo.Session
+}
+
+func NewGateway(token string) (Gateway, error) {
+	session, err := discordgo.New("Bot " + token)
+	if err != nil {
+		return nil, err
+	}
+	session.Identify.Intents = discordgo.IntentsGuildMessages |
+		discordgo.IntentsDirectMessages |
+		discordgo.IntentsGuildVoiceStates |
+		discordgo.IntentMessageContent
+	return &sessionGateway{session: session}, nil
+}
+
+func (g *sessionGateway) Open() error {
+	return g.session.Open()
+}
+
+func (g *sessionGateway) Close() error {
+	return g.session.Close()
+}
+
+func (g *sessionGateway) BotUserID() string {
+	if g.session.State == nil || g.session.State.User == nil {
+		return ""
+	}
+	return g.session.State.User.ID
+}
+
+func (g *sessionGateway) OnMessage(handler func(Message)) {
+	g.session.AddHandler(func(_ *discordgo.Session, m *discordgo.MessageCreate) {
+		if m.Author == nil {
+			return
+		}
+		name := m.Author.GlobalName
+		if name == "" {
+			name = m.Author.Username
+		}
+		mentions := make([]string, 0, len(m.Mentions))
+		for _, u := range m.Mentions {
+			mentions = append(mentions, u.ID)
+		}
+		handler(Message{
+			ID:          m.ID,
+			ChannelID:   m.ChannelID,
+			GuildID:     m.GuildID,
+			AuthorID:    m.Author.ID,
+			AuthorName:  name,
+			AuthorIsBot: m.Author.Bot,
+			Content:     m.Content,
+			MentionIDs:  mentions,
+		})
+	})
+}
+
+func (g *sessionGateway) Reply(channelID, messageID, content string) error {
+	var err error
+	if messageID == "" {
+		_, err = g.session.ChannelMessageSend(channelID, content)
+	} else {
+		_, err = g.session.ChannelMessageSendReply(channelID, content, &discordgo.MessageReference{
+			MessageID: messageID,
+			ChannelID: channelID,
+		})
+	}
+	return err
+}
+
+func (g *sessionGateway) Typing(channelID string) error {
+	return g.session.ChannelTyping(channelID)
+}
+
+func (g *sessionGateway) UserVoiceChannel(guildID, userID string) (string, error) {
+	state, err := g.session.State.VoiceState(guildID, userID)
+	if errors.Is(err, discordgo.ErrStateNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return state.ChannelID, nil
+}
+
+func (g *sessionGateway) JoinVoice(guildID, channelID string) (VoiceConnection, error) {
+	vc, err := g.session.ChannelVoiceJoin(guildID, channelID, false, false)
+	if err != nil {
+		return nil, err
+	}
+	conn := &voiceConnection{vc: vc, packets: make(chan VoicePacket, 64), done: make(chan struct{})}
+	go conn.forward()
+	return conn, nil
+}
+
+type voiceConnection struct {
+	vc      *discordgo.VoiceConnection
+	packets chan VoicePacket
+	done    chan struct{}
+	once    sync.Once
+}
+
+// forward copies discordgo packets until Disconnect. discordgo never closes
+// OpusRecv, so the done channel ends the loop.
+func (c *voiceConnection) forward() {
+	defer close(c.packets)
+	for {
+		select {
+		case <-c.done:
+			return
+		case p, ok := <-c.vc.OpusRecv:
+			if !ok {
+				return
+			}
+			select {
+			case c.packets <- VoicePacket{SSRC: p.SSRC, Opus: p.Opus}:
+			case <-c.done:
+				return
+			}
+		}
+	}
+}
+
+func (c *voiceConnection) Packets() <-chan VoicePacket {
+	return c.packets
+}
+
+func (c *voiceConnection) OnSpeaking(handler func(userID string, ssrc uint32)) {
+	c.vc.AddHandler(func(_ *discordgo.VoiceConnection, vs *discordgo.VoiceSpeakingUpdate) {
+		handler(vs.UserID, uint32(vs.SSRC))
+	})
+}
+
+func (c *voiceConnection) Speaking(speaking bool) error {
+	return c.vc.Speaking(speaking)
+}
+
+func (c *voiceConnection) SendOpus(ctx context.Context, frame []byte) error {
+	select {
+	case c.vc.OpusSend <- frame:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *voiceConnection) Disconnect() error {
+	c.once.Do(func() { close(c.done) })
+	return c.vc.Disconnect()
+}
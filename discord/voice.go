@@ -0,0 +1,236 @@
+package discord
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/package/ogg"
+)
+
+const (
+	opusFrameSamples = 960 // 20 ms at 48 kHz
+	// utterances shorter than this are coughs and key clicks
+	minUtteranceFrames = 15
+	voiceFlushInterval = 100 * time.Millisecond
+)
+
+// voiceSession listens to one voice channel. Each speaker's packets are
+// buffered until they go quiet, then the utterance goes to the agent and the
+// answer is spoken back into the channel.
+type voiceSession struct {
+	bot       *Bot
+	guildID   string
+	channelID string
+	conn      VoiceConnection
+	cancel    context.CancelFunc
+	silence   time.Duration
+
+	mu         sync.Mutex
+	speakers   map[uint32]string // SSRC -> Discord user ID
+	utterances map[uint32]*utterance
+
+	// speakMu keeps answers from talking over each other.
+	speakMu sync.Mutex
+}
+
+type utterance struct {
+	packets  [][]byte
+	lastSeen time.Time
+}
+
+func (b *Bot) joinVoice(ctx context.Context, m Message) {
+	channelID, err := b.gateway.UserVoiceChannel(m.GuildID, m.AuthorID)
+	if err != nil {
+		b.logger.Error("Cannot look up voice state", "user_id", m.AuthorID, "error", err)
+		return
+	}
+	if channelID == "" {
+		b.gateway.Reply(m.ChannelID, m.ID, "Vào kênh voice trước rồi gọi mình nhé.")
+		return
+	}
+
+	b.mu.Lock()
+	existing := b.voices[m.GuildID]
+	b.mu.Unlock()
+	if existing != nil {
+		if existing.channelID == channelID {
+			return
+		}
+		existing.close()
+	}
+
+	conn, err := b.gateway.JoinVoice(m.GuildID, channelID)
+	if err != nil {
+		b.logger.Error("Cannot join voice channel", "channel_id", channelID, "error", err)
+		b.gateway.Reply(m.ChannelID, m.ID, "Mình không vào được kênh voice.")
+		return
+	}
+	sessionCtx, cancel := context.WithCancel(ctx)
+	v := &voiceSession{
+		bot:        b,
+		guildID:    m.GuildID,
+		channelID:  channelID,
+		conn:       conn,
+		cancel:     cancel,
+		silence:    time.Duration(b.cfg.VoiceSilenceMs) * time.Millisecond,
+		speakers:   make(map[uint32]string),
+		utterances: make(map[uint32]*utterance),
+	}
+	conn.OnSpeaking(v.setSpeaker)
+
+	b.mu.Lock()
+	b.voices[m.GuildID] = v
+	b.mu.Unlock()
+
+	b.logger.Info("Joined voice channel", "guild_id", m.GuildID, "channel_id", channelID)
+	go v.listen(sessionCtx)
+}
+
+func (b *Bot) leaveVoice(m Message) {
+	b.mu.Lock()
+	v := b.voices[m.GuildID]
+	delete(b.voices, m.GuildID)
+	b.mu.Unlock()
+	if v != nil {
+		v.close()
+		b.logger.Info("Left voice channel", "guild_id", m.GuildID, "channel_id", v.channelID)
+	}
+}
+
+func (v *voiceSession) close() {
+	v.cancel()
+	v.conn.Disconnect()
+}
+
+func (v *voiceSession) setSpeaker(userID string, ssrc uint32) {
+	v.mu.Lock()
+	v.speakers[ssrc] = userID
+	v.mu.Unlock()
+}
+
+func (v *voiceSession) listen(ctx context.Context) {
+	ticker := time.NewTicker(voiceFlushInterval)
+	defer ticker.Stop()
+	packets := v.conn.Packets()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case p, ok := <-packets:
+			if !ok {
+				return
+			}
+			v.add(p, time.Now())
+		case now := <-ticker.C:
+			for ssrc, packets := range v.flush(now) {
+				go v.respond(ctx, ssrc, packets)
+			}
+		}
+	}
+}
+
+func (v *voiceSession) add(p VoicePacket, now time.Time) {
+	// 3 bytes is the Opus silence frame Discord sends when someone stops.
+	if len(p.Opus) <= 3 {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	u := v.utterances[p.SSRC]
+	if u == nil {
+		u = &utterance{}
+		v.utterances[p.SSRC] = u
+	}
+	u.packets = append(u.packets, p.Opus)
+	u.lastSeen = now
+}
+
+// flush removes and returns the utterances whose speaker has been silent
+// long enough.
+func (v *voiceSession) flush(now time.Time) map[uint32][][]byte {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	var done map[uint32][][]byte
+	for ssrc, u := range v.utterances {
+		if now.Sub(u.lastSeen) < v.silence {
+			continue
+		}
+		delete(v.utterances, ssrc)
+		if len(u.packets) < minUtteranceFrames {
+			continue
+		}
+		if done == nil {
+			done = make(map[uint32][][]byte)
+		}
+		done[ssrc] = u.packets
+	}
+	return done
+}
+
+func (v *voiceSession) respond(ctx context.Context, ssrc uint32, packets [][]byte) {
+	b := v.bot
+	v.mu.Lock()
+	discordID := v.speakers[ssrc]
+	v.mu.Unlock()
+	if discordID == "" || discordID == b.gateway.BotUserID() {
+		return
+	}
+
+	audio, err := ogg.EncodeOpus(packets, 2, opusFrameSamples)
+	if err != nil {
+		b.logger.Error("Cannot wrap voice packets", "error", err)
+		return
+	}
+	userID, err := b.ensureUser(ctx, discordID, "")
+	if err != nil {
+		b.logger.Error("Cannot save discord user", "user_id", discordID, "error", err)
+		return
+	}
+
+	responses, err := b.agent.InferSpeak(ctx, &agent.FlowInput{
+		Audio:          audio,
+		ConversationID: conversationID(v.channelID),
+		UserID:         userID,
+		CharacterID:    b.cfg.CharacterID,
+	})
+	if err != nil {
+		b.logger.Error("Discord voice turn failed", "channel_id", v.channelID, "error", err)
+		return
+	}
+
+	v.speakMu.Lock()
+	defer v.speakMu.Unlock()
+	for response := range responses {
+		if response.Err != nil {
+			b.logger.Error("Discord voice turn failed", "channel_id", v.channelID, "error", response.Err)
+			return
+		}
+		if len(response.AudioBuffer) == 0 {
+			continue
+		}
+		frames, err := b.encode(ctx, response.AudioBuffer)
+		if err != nil {
+			b.logger.Error("Cannot encode voice reply", "error", err)
+			continue
+		}
+		if err := v.speak(ctx, frames); err != nil {
+			b.logger.Warn("Voice reply interrupted", "error", err)
+			return
+		}
+	}
+}
+
+func (v *voiceSession) speak(ctx context.Context, frames [][]byte) error {
+	if err := v.conn.Speaking(true); err != nil {
+		return err
+	}
+	defer v.conn.Speaking(false)
+	for _, frame := range frames {
+		if err := v.conn.SendOpus(ctx, frame); err != nil {
+			return err
+		}
+	}
+	return nil
+}
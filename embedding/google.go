@@ -2,38 +2,35 @@ package embedding
 
 import (
 	"context"
+	"time"
+	"unicode/utf8"
 
 	"github.com/Mirai3103/Project-Re-ENE/config/embedding"
+	"github.com/Mirai3103/Project-Re-ENE/metering"
 	"github.com/Mirai3103/Project-Re-ENE/package/utils"
+	"github.com/Mirai3103/Project-Re-ENE/telemetry"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/genai"
 )
 
 type googleGeminiModel struct {
 	cfg    *embedding.GoogleEmbeddingConfig
 	client *genai.Client
+	meter  *metering.Meter
 }
 
-func newGoogleGeminiModel(ctx context.Context, cfg *embedding.GoogleEmbeddingConfig) (Model, error) {
+func newGoogleGeminiModel(ctx context.Context, cfg *embedding.GoogleEmbeddingConfig, meter *metering.Meter) (Model, error) {
 	client, err := genai.NewClient(ctx, &genai.ClientConfig{
 		APIKey: cfg.APIKey,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return &googleGeminiModel{cfg: cfg, client: client}, nil
+	return &googleGeminiModel{cfg: cfg, client: client, meter: meter}, nil
 }
 
 func (m *googleGeminiModel) Get(ctx context.Context, text string) ([]float32, error) {
-	contents := []*genai.Content{
-		genai.NewContentFromText(text, genai.RoleUser),
-	}
-	result, err := m.client.Models.EmbedContent(ctx,
-		m.cfg.ModelID,
-		contents,
-		&genai.EmbedContentConfig{
-			OutputDimensionality: utils.Ptr(int32(1536)),
-		},
-	)
+	result, err := m.embed(ctx, []string{text})
 	if err != nil {
 		return nil, err
 	}
@@ -41,10 +38,30 @@ func (m *googleGeminiModel) Get(ctx context.Context, text string) ([]float32, er
 }
 
 func (m *googleGeminiModel) Gets(ctx context.Context, texts []string) ([][]float32, error) {
+	result, err := m.embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	embeddings := make([][]float32, len(result.Embeddings))
+	for i, embedding := range result.Embeddings {
+		embeddings[i] = embedding.Values
+	}
+	return embeddings, nil
+}
+
+func (m *googleGeminiModel) embed(ctx context.Context, texts []string) (*genai.EmbedContentResponse, error) {
+	if err := m.meter.Allow(ctx); err != nil {
+		return nil, err
+	}
 	contents := make([]*genai.Content, len(texts))
+	characters := 0
 	for i, text := range texts {
 		contents[i] = genai.NewContentFromText(text, genai.RoleUser)
+		characters += utf8.RuneCountInString(text)
 	}
+	ctx, call := telemetry.StartCall(ctx, telemetry.KindEmbedding, "google", m.cfg.ModelID)
+	call.SetAttributes(attribute.Int("ene.inputs", len(texts)), attribute.Int("ene.characters", characters))
+	start := time.Now()
 	result, err := m.client.Models.EmbedContent(ctx,
 		m.cfg.ModelID,
 		contents,
@@ -52,12 +69,14 @@ func (m *googleGeminiModel) Gets(ctx context.Context, texts []string) ([][]float
 			OutputDimensionality: utils.Ptr(int32(1536)),
 		},
 	)
-	if err != nil {
-		return nil, err
-	}
-	embeddings := make([][]float32, len(result.Embeddings))
-	for i, embedding := range result.Embeddings {
-		embeddings[i] = embedding.Values
-	}
-	return embeddings, nil
+	call.End(err)
+	m.meter.Record(ctx, metering.Event{
+		Kind:       metering.KindEmbedding,
+		Provider:   "google",
+		Model:      m.cfg.ModelID,
+		Characters: characters,
+		Latency:    time.Since(start),
+		Err:        err,
+	})
+	return result, err
 }
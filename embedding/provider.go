@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/metering"
 )
 
 type Model interface {
@@ -12,10 +13,10 @@ type Model interface {
 	Gets(ctx context.Context, texts []string) ([][]float32, error)
 }
 
-func New(ctx context.Context, cfg *config.Config) (Model, error) {
+func New(ctx context.Context, cfg *config.Config, meter *metering.Meter) (Model, error) {
 	switch cfg.EmbeddingConfig.Provider {
 	case "google":
-		return newGoogleGeminiModel(ctx, cfg.EmbeddingConfig.Google)
+		return newGoogleGeminiModel(ctx, cfg.EmbeddingConfig.Google, meter)
 	default:
 		return nil, fmt.Errorf("embedding provider not found")
 	}
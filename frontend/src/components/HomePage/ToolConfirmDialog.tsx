@@ -0,0 +1,37 @@
+import { Button } from "@/components/ui/button";
+import type { ToolConfirmRequest } from "@wailsbindings/agent";
+
+interface ToolConfirmDialogProps {
+  requests: ToolConfirmRequest[];
+  onAnswer: (id: string, approved: boolean) => void;
+}
+
+/**
+ * ToolConfirmDialog Component
+ * Asks the user to approve tool calls whose policy is "ask"
+ */
+export function ToolConfirmDialog({ requests, onAnswer }: ToolConfirmDialogProps) {
+  if (requests.length === 0) return null;
+
+  return (
+    <div className="absolute bottom-4 left-4 z-50 flex flex-col gap-3 max-w-sm">
+      {requests.map((req) => (
+        <div key={req.id} className="bg-card text-card-foreground rounded-xl border p-4 shadow-lg">
+          <p className="text-sm font-semibold">Ene muốn dùng công cụ {req.tool_name}</p>
+          {req.description && <p className="text-xs text-muted-foreground mt-1">{req.description}</p>}
+          <pre className="text-xs bg-muted rounded-md p-2 mt-2 max-h-32 overflow-auto whitespace-pre-wrap break-all">
+            {req.arguments}
+          </pre>
+          <div className="flex justify-end gap-2 mt-3">
+            <Button size="sm" variant="outline" onClick={() => onAnswer(req.id, false)}>
+              Từ chối
+            </Button>
+            <Button size="sm" onClick={() => onAnswer(req.id, true)}>
+              Cho phép
+            </Button>
+          </div>
+        </div>
+      ))}
+    </div>
+  );
+}
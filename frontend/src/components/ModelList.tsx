@@ -17,6 +17,7 @@ interface ModelListProps {
     model: (Live2dModel & { data: ILive2DModel }) | undefined
   ) => void;
   isLoading?: boolean;
+  onActivateModel?: (model: Live2dModel) => void;
 }
 
 export default function ModelList({
@@ -24,6 +25,7 @@ export default function ModelList({
   selectedModel,
   onSelectModel,
   isLoading,
+  onActivateModel,
 }: ModelListProps) {
   return (
     <Card className="border-2 border-primary/20 bg-card/50 backdrop-blur-sm relative overflow-hidden">
@@ -48,6 +50,7 @@ export default function ModelList({
               model={model}
               setSelectedModel={onSelectModel}
               selectedModel={selectedModel}
+              onActivate={onActivateModel}
             />
           ))
         ) : (
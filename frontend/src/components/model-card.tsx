@@ -1,16 +1,18 @@
 import { ModelService, Model as Live2dModel } from "@wailsbindings/services";
-import { Badge, CheckCircle, Download } from "lucide-react";
+import { AlertTriangle, Badge, CheckCircle, Download } from "lucide-react";
 import { useQuery } from "@/lib/query";
 import type { ILive2DModel } from "@/types/models";
 interface ModelCardProps {
   model: Live2dModel;
   setSelectedModel: (model: Live2dModel & { data: ILive2DModel }) => void;
   selectedModel: Live2dModel | undefined;
+  onActivate?: (model: Live2dModel) => void;
 }
 export default function ModelCard({
   model,
   setSelectedModel,
   selectedModel,
+  onActivate,
 }: ModelCardProps) {
   const { data, error, isLoading } = useQuery({
     queryKey: ["models", model.id],
@@ -37,6 +39,13 @@ export default function ModelCard({
     >
       <div className="flex items-start gap-4">
         {/* Thumbnail */}
+        {model.thumbnail && (
+          <img
+            src={"/models" + model.thumbnail}
+            alt={model.name}
+            className="h-16 w-16 shrink-0 rounded-lg bg-muted object-contain"
+          />
+        )}
 
         {/* Info */}
         <div className="flex-1 min-w-0">
@@ -58,7 +67,34 @@ export default function ModelCard({
             <span>{data?.Groups?.length} motions</span>
             <span>•</span>
             <span>{data?.FileReferences?.Expressions?.length} expressions</span>
+            {model.version === 2 && (
+              <>
+                <span>•</span>
+                <span>Cubism 2</span>
+              </>
+            )}
           </div>
+          {!model.valid && (
+            <p
+              className="mt-1 flex items-center gap-1 text-xs text-destructive"
+              title={model.problems?.join("\n")}
+            >
+              <AlertTriangle className="h-3 w-3" />
+              {model.problems?.length} problem(s) found
+            </p>
+          )}
+          {model.valid && !model.is_active && onActivate && (
+            <button
+              type="button"
+              onClick={(e) => {
+                e.stopPropagation();
+                onActivate(model);
+              }}
+              className="mt-2 text-xs font-medium text-primary hover:underline"
+            >
+              Set active
+            </button>
+          )}
         </div>
       </div>
     </div>
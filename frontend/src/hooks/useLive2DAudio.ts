@@ -23,6 +23,10 @@ export function useLive2DAudio(
         console.log("play-audio", data.Text);
         const url = base64ToBlobUrl(data.Base64);
         onSpeakingTextChange(data.Text);
+        if (data.Motion) {
+          if (data.Motion.expression) modelRef.current?.expression(data.Motion.expression);
+          if (data.Motion.group) modelRef.current?.motion(data.Motion.group, data.Motion.index);
+        }
 
         await new Promise((resolve) => {
           modelRef.current?.speak(url, {
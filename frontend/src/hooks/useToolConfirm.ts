@@ -0,0 +1,34 @@
+import { useCallback, useEffect, useState } from "react";
+import { Events } from "@wailsio/runtime";
+import type { ToolConfirmRequest } from "@wailsbindings/agent";
+import { ToolService } from "@wailsbindings/services";
+
+export function useToolConfirm() {
+  const [requests, setRequests] = useState<ToolConfirmRequest[]>([]);
+
+  useEffect(() => {
+    ToolService.GetPendingConfirmations().then((pending) => {
+      setRequests(pending.filter(Boolean) as ToolConfirmRequest[]);
+    });
+
+    const offRequest = Events.On("tool:confirm", ({ data }: { data: ToolConfirmRequest }) => {
+      setRequests((prev) => [...prev, data]);
+    });
+    // the backend closes a request on answer, timeout or cancellation
+    const offClosed = Events.On("tool:confirm-closed", ({ data }: { data: string }) => {
+      setRequests((prev) => prev.filter((r) => r.id !== data));
+    });
+
+    return () => {
+      offRequest();
+      offClosed();
+    };
+  }, []);
+
+  const answer = useCallback(async (id: string, approved: boolean) => {
+    setRequests((prev) => prev.filter((r) => r.id !== id));
+    await ToolService.AnswerToolConfirmation(id, approved);
+  }, []);
+
+  return { requests, answer };
+}
@@ -1,5 +1,6 @@
 import { useQuery } from "@/lib/query";
 import { ConfigService } from "@wailsbindings/services";
+import { describeReload, migrateSecrets } from "@/utils/config";
 import React from "react";
 import { z } from "zod";
 import { useForm } from "react-hook-form";
@@ -88,25 +89,25 @@ export default function ElevenLabsConfig() {
     queryFn: () => ConfigService.GetConfig(),
   });
 
-  const elevenLabsConfig = config?.ASRConfig.ElevenLabsConfig;
+  const elevenLabsConfig = config?.asr_config.eleven_labs_config;
 
   const form = useForm<ElevenLabsConfigFormValues>({
     resolver: zodResolver(elevenLabsConfigSchema),
     defaultValues: {
-      name: elevenLabsConfig?.Name || "",
-      apiKey: elevenLabsConfig?.APIKey || "",
-      modelId: elevenLabsConfig?.ModelID || "scribe_v1",
-      languageCode: elevenLabsConfig?.LanguageCode || "auto",
+      name: elevenLabsConfig?.name || "",
+      apiKey: elevenLabsConfig?.api_key || "",
+      modelId: elevenLabsConfig?.model_id || "scribe_v1",
+      languageCode: elevenLabsConfig?.language_code || "auto",
     },
   });
 
   React.useEffect(() => {
     if (elevenLabsConfig) {
       form.reset({
-        name: elevenLabsConfig.Name || "",
-        apiKey: elevenLabsConfig.APIKey || "",
-        modelId: elevenLabsConfig.ModelID || "scribe_v1",
-        languageCode: elevenLabsConfig.LanguageCode || "auto",
+        name: elevenLabsConfig.name || "",
+        apiKey: elevenLabsConfig.api_key || "",
+        modelId: elevenLabsConfig.model_id || "scribe_v1",
+        languageCode: elevenLabsConfig.language_code || "auto",
       });
     }
   }, [elevenLabsConfig, form]);
@@ -114,19 +115,19 @@ export default function ElevenLabsConfig() {
   async function onSubmit(data: ElevenLabsConfigFormValues) {
     try {
       setIsSaving(true);
-      await ConfigService.PatchConfig({
-        ASRConfig: {
-          ElevenLabsConfig: {
-            Name: data.name || "",
-            APIKey: data.apiKey,
-            ModelID: data.modelId,
-            LanguageCode: data.languageCode || "auto",
+      const result = await ConfigService.PatchConfig({
+        asr_config: {
+          eleven_labs_config: {
+            name: data.name || "",
+            api_key: data.apiKey,
+            model_id: data.modelId,
+            language_code: data.languageCode || "auto",
           },
         },
-      } as any);
+      });
 
       await refetch();
-      alert("Configuration saved successfully!");
+      alert(describeReload(result));
     } catch (error) {
       console.error("Failed to save config:", error);
       alert("Failed to save configuration. Please try again.");
@@ -260,7 +261,21 @@ export default function ElevenLabsConfig() {
                           />
                         </FormControl>
                         <FormDescription>
-                          Your ElevenLabs API key for authentication
+                          Your ElevenLabs API key for authentication.{" "}
+                          <button
+                            type="button"
+                            className="text-primary hover:underline font-medium"
+                            onClick={async () => {
+                              try {
+                                alert(await migrateSecrets());
+                              } catch (error) {
+                                console.error("Failed to migrate keys:", error);
+                                alert("Failed to move the keys. Please try again.");
+                              }
+                            }}
+                          >
+                            Store keys in the keyring
+                          </button>
                         </FormDescription>
                         <FormMessage />
                       </FormItem>
@@ -29,6 +29,7 @@ import {
 } from "lucide-react";
 import { useQuery } from "@/lib/query";
 import { RecorderService, ConfigService } from "@wailsbindings/services";
+import { describeReload } from "@/utils/config";
 // Mock data for providers
 const asrProviders = [
   {
@@ -87,18 +88,11 @@ const asrProviders = [
   },
 ];
 
-// Mock input devices
-const inputDevices = [
-  { id: "default", name: "Default Microphone" },
-  { id: "device-1", name: "Built-in Microphone" },
-  { id: "device-2", name: "USB Microphone (Blue Yeti)" },
-  { id: "device-3", name: "Wireless Headset" },
-];
-
 export default function AsrPage() {
   const [, navigate] = useLocation();
   const [selectedProvider, setSelectedProvider] = useState("elevenlabs");
   const [selectedDevice, setSelectedDevice] = useState("default");
+  const [isSaving, setIsSaving] = useState(false);
   const {
     data: availableInputDevices,
     error: availableInputDevicesError,
@@ -111,13 +105,14 @@ export default function AsrPage() {
     data: config,
     error: configError,
     isLoading: configIsLoading,
+    refetch,
   } = useQuery({
     queryKey: ["configs"],
     queryFn: () => ConfigService.GetConfig(),
   });
   const defaultInputDevice = React.useMemo(() => {
     if (configIsLoading || availableInputDevicesIsLoading) return null;
-    const name = config?.ASRConfig.InputDevice;
+    const name = config?.asr_config.input_device;
     const defaultName = availableInputDevices?.find(
       (device) => device.IsDefault
     )?.Name;
@@ -127,12 +122,36 @@ export default function AsrPage() {
       defaultName
     );
   }, [availableInputDevices, config]);
-  console.log(defaultInputDevice);
   React.useEffect(() => {
     if (defaultInputDevice) {
       setSelectedDevice(defaultInputDevice);
     }
   }, [defaultInputDevice]);
+  React.useEffect(() => {
+    if (config?.asr_config.provider) {
+      setSelectedProvider(config.asr_config.provider);
+    }
+  }, [config]);
+
+  async function onSave() {
+    try {
+      setIsSaving(true);
+      const result = await ConfigService.PatchConfig({
+        asr_config: {
+          provider: selectedProvider,
+          input_device: selectedDevice,
+        },
+      });
+
+      await refetch();
+      alert(describeReload(result));
+    } catch (error) {
+      console.error("Failed to save config:", error);
+      alert("Failed to save configuration. Please try again.");
+    } finally {
+      setIsSaving(false);
+    }
+  }
 
   return (
     <AuroraBackground showRadialGradient className="overflow-auto">
@@ -240,23 +259,28 @@ export default function AsrPage() {
               {/* Save Button */}
               <div className="flex gap-3 pt-4 border-t border-border/50">
                 <RippleButton
-                  onClick={() => {
-                    console.log("Save config:", {
-                      selectedProvider,
-                      selectedDevice,
-                    });
-                    alert("Configuration saved! (Mock implementation)");
-                  }}
+                  onClick={onSave}
+                  disabled={isSaving}
                   className="flex-1 md:flex-initial bg-primary text-primary-foreground hover:bg-primary/90"
                 >
-                  <CheckCircle className="h-5 w-5 mr-2" />
-                  Save Configuration
+                  {isSaving ? (
+                    <>
+                      <div className="h-5 w-5 mr-2 animate-spin rounded-full border-2 border-primary-foreground border-t-transparent" />
+                      Saving...
+                    </>
+                  ) : (
+                    <>
+                      <CheckCircle className="h-5 w-5 mr-2" />
+                      Save Configuration
+                    </>
+                  )}
                 </RippleButton>
                 <RippleButton
                   variant="outline"
+                  disabled={isSaving}
                   onClick={() => {
-                    setSelectedProvider("elevenlabs");
-                    setSelectedDevice("default");
+                    setSelectedProvider(config?.asr_config.provider || "elevenlabs");
+                    setSelectedDevice(defaultInputDevice || "default");
                   }}
                   className="bg-secondary/50 hover:bg-secondary"
                 >
@@ -1,3 +1,4 @@
+import React from "react";
 import { useForm } from "react-hook-form";
 import { zodResolver } from "@hookform/resolvers/zod";
 import * as z from "zod";
@@ -29,58 +30,85 @@ import { Textarea } from "@/components/ui/textarea";
 import { RippleButton } from "@/components/ui/shadcn-io/ripple-button";
 import { AuroraBackground } from "@/components/ui/shadcn-io/aurora-background";
 import { useLocation } from "wouter";
+import { useQuery } from "@/lib/query";
+import { ConfigService, ModelService } from "@wailsbindings/services";
+import { describeReload } from "@/utils/config";
 
 import { ArrowLeft, User, Sparkles } from "lucide-react";
 
-// Mock data for Live2D models
-const live2dModels = [
-  { id: "hiyori_pro_t11", name: "Hiyori Pro T11", preview: "Current model" },
-  { id: "shizuku_model", name: "Shizuku", preview: "Alternative character" },
-  { id: "miku_model", name: "Miku", preview: "Vocaloid style" },
-  { id: "ene_model", name: "Ene", preview: "Default character" },
-];
-
-// Zod schema for form validation
+// Zod schema for form validation, keyed like config.character_config
 const characterConfigSchema = z.object({
   live2d_model_name: z.string().min(1, "Please select a Live2D model"),
-  character_name: z
-    .string()
-    .min(1, "Character name is required")
-    .max(50, "Character name must be less than 50 characters"),
-  user_name: z
-    .string()
-    .min(1, "User name is required")
-    .max(50, "User name must be less than 50 characters"),
-  persona_prompt: z
-    .string()
-    .min(10, "Persona prompt must be at least 10 characters")
-    .max(1000, "Persona prompt must be less than 1000 characters"),
+  character_name: z.string().min(1, "Character name is required"),
+  user_name: z.string().min(1, "User name is required"),
+  persona_prompt: z.string(),
 });
 
 type CharacterConfigFormValues = z.infer<typeof characterConfigSchema>;
 
-// Mock default values
-const defaultValues: CharacterConfigFormValues = {
-  live2d_model_name: "hiyori_pro_t11",
-  character_name: "Ene",
-  user_name: "Master",
-  persona_prompt:
-    "You are Ene, a cheerful and energetic AI assistant with a playful personality. You love helping your master with tasks and enjoy casual conversations. You occasionally use cute expressions and are always enthusiastic about learning new things.",
+const emptyValues: CharacterConfigFormValues = {
+  live2d_model_name: "",
+  character_name: "",
+  user_name: "",
+  persona_prompt: "",
 };
 
 export default function Character() {
   const [, navigate] = useLocation();
+  const [isSaving, setIsSaving] = React.useState(false);
+
+  const { data: config, refetch } = useQuery({
+    queryKey: ["configs"],
+    queryFn: () => ConfigService.GetConfig(),
+  });
+  const { data: models } = useQuery({
+    queryKey: ["models"],
+    queryFn: () => ModelService.GetModelList(),
+  });
+
+  const characterConfig = config?.character_config;
 
   const form = useForm<CharacterConfigFormValues>({
     resolver: zodResolver(characterConfigSchema),
-    defaultValues,
+    defaultValues: emptyValues,
   });
 
-  const onSubmit = (data: CharacterConfigFormValues) => {
-    console.log("Form submitted:", data);
-    // TODO: Integrate with backend API to save configuration
-    alert("Configuration saved! (Mock implementation)");
-  };
+  React.useEffect(() => {
+    if (characterConfig) {
+      form.reset({
+        live2d_model_name: characterConfig.live2d_model_name || "",
+        character_name: characterConfig.character_name || "",
+        user_name: characterConfig.user_name || "",
+        persona_prompt: characterConfig.persona_prompt || "",
+      });
+    }
+  }, [characterConfig, form]);
+
+  async function onSubmit(data: CharacterConfigFormValues) {
+    try {
+      setIsSaving(true);
+      // switching models goes through the model service, which checks the
+      // model and tells the viewer to load it
+      if (data.live2d_model_name !== characterConfig?.live2d_model_name) {
+        await ModelService.SetActiveModel(data.live2d_model_name);
+      }
+      const result = await ConfigService.PatchConfig({
+        character_config: {
+          character_name: data.character_name,
+          user_name: data.user_name,
+          persona_prompt: data.persona_prompt,
+        },
+      });
+
+      await refetch();
+      alert(describeReload(result));
+    } catch (error) {
+      console.error("Failed to save config:", error);
+      alert("Failed to save configuration. Please try again.");
+    } finally {
+      setIsSaving(false);
+    }
+  }
 
   return (
     <AuroraBackground showRadialGradient className="overflow-auto">
@@ -147,7 +175,7 @@ export default function Character() {
                         </FormLabel>
                         <Select
                           onValueChange={field.onChange}
-                          defaultValue={field.value}
+                          value={field.value}
                         >
                           <FormControl>
                             <SelectTrigger className="bg-background/50 backdrop-blur-sm border-2 w-full  hover:border-primary/50 transition-colors">
@@ -155,22 +183,23 @@ export default function Character() {
                             </SelectTrigger>
                           </FormControl>
                           <SelectContent>
-                            {live2dModels.map((model) => (
-                              <SelectItem key={model.id} value={model.id}>
+                            {(models ?? []).map((model) => (
+                              <SelectItem
+                                key={model.id}
+                                value={model.id}
+                                disabled={!model.valid}
+                              >
                                 <div className="flex flex-col">
                                   <span className="font-medium">
                                     {model.name}
                                   </span>
-                                  {/* <span className="text-xs text-muted-foreground">
-                                    {model.preview}
-                                  </span> */}
                                 </div>
                               </SelectItem>
                             ))}
                           </SelectContent>
                         </Select>
                         <FormDescription>
-                          Choose the 3D avatar model for your character
+                          Choose the Live2D model for your character
                         </FormDescription>
                         <FormMessage />
                       </FormItem>
@@ -243,7 +272,6 @@ export default function Character() {
                         </FormControl>
                         <FormDescription>
                           Define the character's personality and behavior
-                          (10-1000 characters)
                         </FormDescription>
                         <FormMessage />
                       </FormItem>
@@ -254,16 +282,27 @@ export default function Character() {
                   <div className="flex gap-3 pt-4">
                     <RippleButton
                       type="submit"
+                      disabled={isSaving}
                       className="flex-1 bg-primary text-primary-foreground hover:bg-primary/90 h-12 text-base font-semibold"
                     >
-                      <Sparkles className="h-5 w-5 mr-2" />
-                      Save Configuration
+                      {isSaving ? (
+                        <>
+                          <div className="h-5 w-5 mr-2 animate-spin rounded-full border-2 border-primary-foreground border-t-transparent" />
+                          Saving...
+                        </>
+                      ) : (
+                        <>
+                          <Sparkles className="h-5 w-5 mr-2" />
+                          Save Configuration
+                        </>
+                      )}
                     </RippleButton>
                     <RippleButton
                       type="button"
                       variant="outline"
                       onClick={() => form.reset()}
                       className="bg-secondary/50 hover:bg-secondary"
+                      disabled={isSaving}
                     >
                       Reset
                     </RippleButton>
@@ -283,7 +322,7 @@ export default function Character() {
                 <div>
                   <span className="text-muted-foreground">Model:</span>
                   <p className="font-medium text-foreground mt-1">
-                    {live2dModels.find(
+                    {models?.find(
                       (m) => m.id === form.watch("live2d_model_name")
                     )?.name || "Not selected"}
                   </p>
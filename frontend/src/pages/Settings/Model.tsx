@@ -26,6 +26,15 @@ export default function Model() {
     refetch();
   };
 
+  const handleActivate = async (model: Live2dModel) => {
+    try {
+      await ModelService.SetActiveModel(model.id);
+    } catch (err) {
+      console.error("SetActiveModel failed", err);
+    }
+    refetch();
+  };
+
   return (
     <AuroraBackground showRadialGradient className="overflow-auto">
       <div className="min-h-screen w-full max-w-[1600px] p-4 py-10 font-sans selection:bg-primary/20">
@@ -67,6 +76,7 @@ export default function Model() {
               selectedModel={selectedModel}
               onSelectModel={setSelectedModel}
               isLoading={isLoading}
+              onActivateModel={handleActivate}
             />
           </div>
 
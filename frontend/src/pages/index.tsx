@@ -5,8 +5,11 @@ import type { InternalModel, Live2DModel } from "@laffy1309/pixi-live2d-lipsyncp
 import { Live2DCanvas } from "@/components/HomePage/Live2DCanvas";
 import { ChatPanel } from "@/components/HomePage/ChatPanel";
 import { useLive2DAudio } from "@/hooks/useLive2DAudio";
+import { useToolConfirm } from "@/hooks/useToolConfirm";
+import { ToolConfirmDialog } from "@/components/HomePage/ToolConfirmDialog";
 import { useVoiceRecording } from "@/hooks/useVoiceRecording";
 import { InvokeWithText } from "@wailsbindings/services/appservice";
+import { stripEmotionTags } from "@/utils/emotion";
 import { GetChatHistory } from "@wailsbindings/services/chatservice";
 import type { ChatMessage } from "@/types/chat";
 import { useQuery } from "@/lib/query";
@@ -74,7 +77,7 @@ export default function HomePage() {
         return {
           id: crypto.randomUUID(),
           role: item!.Role,
-          text: content.content.map(item => item.text).join(" "),
+          text: stripEmotionTags(content.content.map(item => item.text).join(" ")),
           timestamp: new Date(item!.CreatedAt),
         }
        });
@@ -97,6 +100,7 @@ export default function HomePage() {
     
   }, [refetch]);
   useLive2DAudio(modelRef, onSpeakingTextChange);
+  const { requests: toolRequests, answer: answerToolRequest } = useToolConfirm();
 
   const handleModelReady = (model: Live2DModel<InternalModel>) => {
     modelRef.current = model;
@@ -133,6 +137,8 @@ export default function HomePage() {
         onSendMessage={handleSendMessage}
         streamingMessage={streamingMessage}
       />
+
+      <ToolConfirmDialog requests={toolRequests} onAnswer={answerToolRequest} />
     </div>
   );
 }
@@ -14,6 +14,7 @@ export interface Live2DModelRef {
     onError?: () => void;
   }) => void;
   motion: (group: string, index: number) => void;
+  expression: (name: string) => void;
   scale: {
     set: (scale: number) => void;
   };
@@ -0,0 +1,42 @@
+import type { ReloadResult } from "@wailsbindings/providers";
+import { ConfigService } from "@wailsbindings/services";
+
+/**
+ * Turn the result of ConfigService.PatchConfig into a message for the user
+ * @param result - Per subsystem outcome returned by the backend
+ * @returns One line per subsystem that changed
+ */
+export function describeReload(result: ReloadResult | null): string {
+  if (!result) return "Configuration saved.";
+  const lines = (result.subsystems ?? [])
+    .filter((s) => s.status !== "unchanged")
+    .map((s) => {
+      switch (s.status) {
+        case "reloaded":
+          return `${s.name}: applied`;
+        case "updated":
+          return `${s.name}: updated`;
+        case "restart_required":
+          return `${s.name}: saved, restart the app to apply`;
+        case "failed":
+          return `${s.name}: failed - ${s.error}`;
+        default:
+          return `${s.name}: not applied`;
+      }
+    });
+  const head = result.applied
+    ? "Configuration saved."
+    : "Configuration was not saved.";
+  return [head, ...lines].join("\n");
+}
+
+/**
+ * Move every plain text API key out of config.yaml into the OS keyring
+ * @returns A message for the user
+ */
+export async function migrateSecrets(): Promise<string> {
+  const moved = await ConfigService.MigrateSecrets();
+  return moved > 0
+    ? `Moved ${moved} key(s) out of config.yaml.`
+    : "No plain text keys left in config.yaml.";
+}
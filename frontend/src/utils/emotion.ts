@@ -0,0 +1,9 @@
+/**
+ * Remove the inline emotion tags ("[happy]") the agent puts before sentences
+ * @param text - Assistant message text as stored in the history
+ * @returns Text without tags
+ */
+export function stripEmotionTags(text: string): string {
+  if (!text.includes("[")) return text;
+  return text.replace(/\[[A-Za-z_]{2,20}\]/g, "").replace(/\s+/g, " ").trim();
+}
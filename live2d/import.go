@@ -0,0 +1,242 @@
+package live2d
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+var (
+	ErrNotModelArchive = errors.New("not a valid model zip file")
+	ErrUnsafePath      = errors.New("zip entry points outside the model folder")
+	ErrArchiveTooLarge = errors.New("model archive is too large")
+)
+
+// ImportLimits caps what an archive may unpack to. Sizes are checked against
+// the bytes actually written, the sizes in zip headers can lie.
+type ImportLimits struct {
+	MaxTotalBytes int64
+	MaxFileBytes  int64
+	MaxFiles      int
+}
+
+// ImportZip extracts the model folder of an archive into root and returns the
+// name it was installed under. The folder is unpacked into a staging
+// directory first and renamed into place, so a failed import leaves nothing
+// behind. Hotkeys of a VTube Studio model are written into its settings so
+// the viewer can play them, and the thumbnail is made before the folder
+// goes live, when the model has an image to make it from. An existing model with the same name is kept and
+// the new one gets a numbered name.
+func ImportZip(r *zip.Reader, root string, limits ImportLimits) (string, error) {
+	prefix, name, err := findModelFolder(r)
+	if err != nil {
+		return "", err
+	}
+
+	entries, err := selectEntries(r, prefix, limits)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return "", err
+	}
+	staging, err := os.MkdirTemp(root, ".import-*")
+	if err != nil {
+		return "", err
+	}
+	if err := extract(entries, staging, limits); err != nil {
+		os.RemoveAll(staging)
+		return "", err
+	}
+	if err := writeVTubeSettings(staging); err != nil {
+		os.RemoveAll(staging)
+		return "", fmt.Errorf("merge vtube studio hotkeys: %w", err)
+	}
+	if info, err := Inspect(staging); err == nil {
+		// a model without a picture is still a model
+		MakeThumbnail(staging, info)
+	}
+
+	name, err = installStaging(staging, root, name)
+	if err != nil {
+		os.RemoveAll(staging)
+		return "", err
+	}
+	return name, nil
+}
+
+// entry is a file to extract and its path inside the model folder.
+type entry struct {
+	file *zip.File
+	rel  string
+}
+
+// findModelFolder returns the folder holding the shallowest settings file,
+// as a zip path prefix ending in "/" (empty for the archive root), and the
+// name to install it under. Cubism 3 settings win over Cubism 2 ones at the
+// same depth.
+func findModelFolder(r *zip.Reader) (prefix, name string, err error) {
+	best, bestLegacy := -1, false
+	for _, f := range r.File {
+		p := zipPath(f.Name)
+		if !IsModelFile(path.Base(p)) || f.FileInfo().IsDir() {
+			continue
+		}
+		depth, legacy := strings.Count(p, "/"), isModel2File(p)
+		if best != -1 && (depth > best || depth == best && (legacy || !bestLegacy)) {
+			continue
+		}
+		best, bestLegacy = depth, legacy
+		dir := path.Dir(p)
+		if dir == "." {
+			// settings at the archive root, name the folder after the file
+			prefix = ""
+			name = fileStem(p)
+		} else {
+			prefix = dir + "/"
+			name = path.Base(dir)
+		}
+	}
+	if best == -1 {
+		return "", "", ErrNotModelArchive
+	}
+	if _, err := ModelDir("", name); err != nil {
+		return "", "", ErrNotModelArchive
+	}
+	return prefix, name, nil
+}
+
+// selectEntries picks the entries under prefix and rejects the archive if any
+// of them is unsafe or the declared sizes are already over the limits.
+func selectEntries(r *zip.Reader, prefix string, limits ImportLimits) ([]entry, error) {
+	var entries []entry
+	var total uint64
+	for _, f := range r.File {
+		p := zipPath(f.Name)
+		if !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		rel := strings.TrimPrefix(p, prefix)
+		if rel == "" {
+			continue
+		}
+		if strings.HasPrefix(p, "/") || !filepath.IsLocal(filepath.FromSlash(rel)) {
+			return nil, fmt.Errorf("%w: %s", ErrUnsafePath, f.Name)
+		}
+		mode := f.Mode()
+		if mode&os.ModeSymlink != 0 {
+			return nil, fmt.Errorf("%w: %s is a symlink", ErrUnsafePath, f.Name)
+		}
+		if mode.IsDir() {
+			continue
+		}
+		if !mode.IsRegular() {
+			return nil, fmt.Errorf("%w: %s is not a regular file", ErrUnsafePath, f.Name)
+		}
+
+		if limits.MaxFileBytes > 0 && f.UncompressedSize64 > uint64(limits.MaxFileBytes) {
+			return nil, fmt.Errorf("%w: %s", ErrArchiveTooLarge, f.Name)
+		}
+		total += f.UncompressedSize64
+		if limits.MaxTotalBytes > 0 && total > uint64(limits.MaxTotalBytes) {
+			return nil, ErrArchiveTooLarge
+		}
+		entries = append(entries, entry{file: f, rel: rel})
+		if limits.MaxFiles > 0 && len(entries) > limits.MaxFiles {
+			return nil, fmt.Errorf("%w: more than %d files", ErrArchiveTooLarge, limits.MaxFiles)
+		}
+	}
+	return entries, nil
+}
+
+func extract(entries []entry, dir string, limits ImportLimits) error {
+	var written int64
+	for _, e := range entries {
+		out := filepath.Join(dir, filepath.FromSlash(e.rel))
+		if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+			return err
+		}
+		budget := limits.MaxTotalBytes - written
+		if limits.MaxTotalBytes <= 0 {
+			budget = -1
+		}
+		if limits.MaxFileBytes > 0 && (budget < 0 || limits.MaxFileBytes < budget) {
+			budget = limits.MaxFileBytes
+		}
+		n, err := extractFile(e.file, out, budget)
+		written += n
+		if err != nil {
+			return fmt.Errorf("extract %s: %w", e.file.Name, err)
+		}
+	}
+	return nil
+}
+
+// extractFile copies one entry, failing once more than budget bytes come
+// out of it. A negative budget means no limit.
+func extractFile(f *zip.File, out string, budget int64) (int64, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	dst, err := os.OpenFile(out, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0644)
+	if err != nil {
+		return 0, err
+	}
+	var src io.Reader = rc
+	if budget >= 0 {
+		src = io.LimitReader(rc, budget+1)
+	}
+	n, err := io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return n, err
+	}
+	if budget >= 0 && n > budget {
+		return n, ErrArchiveTooLarge
+	}
+	return n, nil
+}
+
+// installStaging renames the staging folder to name, or to name-2, name-3, …
+// when that is taken.
+func installStaging(staging, root, name string) (string, error) {
+	for i := 1; i < 1000; i++ {
+		candidate := name
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%d", name, i)
+		}
+		dest := filepath.Join(root, candidate)
+		if _, err := os.Lstat(dest); err == nil {
+			continue
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		if err := os.Rename(staging, dest); err != nil {
+			// a folder created meanwhile fails with EEXIST or ENOTEMPTY
+			// depending on the platform
+			if errors.Is(err, os.ErrExist) || errors.Is(err, syscall.ENOTEMPTY) {
+				continue
+			}
+			return "", err
+		}
+		return candidate, nil
+	}
+	return "", fmt.Errorf("too many models named %s", name)
+}
+
+// zipPath normalizes an entry name; some Windows tools write backslashes.
+func zipPath(name string) string {
+	return strings.ReplaceAll(name, `\`, "/")
+}
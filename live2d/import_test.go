@@ -0,0 +1,252 @@
+package live2d
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type zipEntry struct {
+	name    string
+	content string
+	mode    os.FileMode
+}
+
+func buildZip(t *testing.T, entries ...zipEntry) *zip.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		if e.mode != 0 {
+			hdr.SetMode(e.mode)
+		}
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(e.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return openZip(t, buf.Bytes())
+}
+
+func openZip(t *testing.T, data []byte) *zip.Reader {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return zr
+}
+
+var testLimits = ImportLimits{MaxTotalBytes: 1 << 20, MaxFileBytes: 1 << 19, MaxFiles: 10}
+
+// listDir returns every path under root, so tests can check nothing was left
+// behind.
+func listDir(t *testing.T, root string) []string {
+	t.Helper()
+	var paths []string
+	err := filepath.WalkDir(root, func(path string, _ os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != root {
+			rel, _ := filepath.Rel(root, path)
+			paths = append(paths, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func TestImportZip(t *testing.T) {
+	root := t.TempDir()
+	zr := buildZip(t,
+		zipEntry{name: "pack/"},
+		zipEntry{name: "pack/readme.txt", content: "outside the model"},
+		zipEntry{name: "pack/ene/", mode: os.ModeDir | 0755},
+		zipEntry{name: "pack/ene/ene.model3.json", content: settings},
+		zipEntry{name: "pack/ene/ene.moc3", content: "moc"},
+		zipEntry{name: `pack\ene\motions\idle.motion3.json`, content: "{}"},
+	)
+
+	name, err := ImportZip(zr, root, testLimits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "ene" {
+		t.Errorf("name = %q, want ene", name)
+	}
+	want := []string{"ene", "ene/ene.moc3", "ene/ene.model3.json", "ene/motions", "ene/motions/idle.motion3.json"}
+	if got := listDir(t, root); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("files = %v, want %v", got, want)
+	}
+}
+
+func TestImportZipAtRoot(t *testing.T) {
+	root := t.TempDir()
+	zr := buildZip(t,
+		zipEntry{name: "Ene.model3.json", content: settings},
+		zipEntry{name: "ene.moc3", content: "moc"},
+	)
+	name, err := ImportZip(zr, root, testLimits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "Ene" {
+		t.Errorf("name = %q, want Ene", name)
+	}
+	if _, err := os.Stat(filepath.Join(root, "Ene", "ene.moc3")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestImportZipMakesThumbnail(t *testing.T) {
+	texture := filepath.Join(t.TempDir(), "texture.png")
+	writePNG(t, texture, 512, 512)
+	data, err := os.ReadFile(texture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := t.TempDir()
+	zr := buildZip(t,
+		zipEntry{name: "ene/ene.model3.json", content: settings},
+		zipEntry{name: "ene/ene.moc3", content: "moc"},
+		zipEntry{name: "ene/ene.1024/texture_00.png", content: string(data)},
+	)
+	name, err := ImportZip(zr, root, testLimits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Thumbnail(filepath.Join(root, name)); got != ThumbnailFile {
+		t.Errorf("Thumbnail() = %q after import, want %q", got, ThumbnailFile)
+	}
+}
+
+func TestImportZipNameCollision(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "ene", "ene.model3.json"), "old")
+	writeFile(t, filepath.Join(root, "ene-2", "ene.model3.json"), "old")
+
+	zr := buildZip(t, zipEntry{name: "ene/ene.model3.json", content: settings})
+	name, err := ImportZip(zr, root, testLimits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "ene-3" {
+		t.Errorf("name = %q, want ene-3", name)
+	}
+	old, _ := os.ReadFile(filepath.Join(root, "ene", "ene.model3.json"))
+	if string(old) != "old" {
+		t.Error("existing model was overwritten")
+	}
+}
+
+func TestImportZipRejects(t *testing.T) {
+	big := strings.Repeat("a", int(testLimits.MaxFileBytes)+1)
+	half := strings.Repeat("a", int(testLimits.MaxFileBytes))
+
+	tests := []struct {
+		name    string
+		entries []zipEntry
+		want    error
+	}{
+		{"no model", []zipEntry{{name: "ene/ene.moc3", content: "moc"}}, ErrNotModelArchive},
+		{"zip slip", []zipEntry{
+			{name: "ene/ene.model3.json", content: settings},
+			{name: "ene/../../evil.txt", content: "pwned"},
+		}, ErrUnsafePath},
+		{"backslash slip", []zipEntry{
+			{name: "ene/ene.model3.json", content: settings},
+			{name: `ene\..\..\evil.txt`, content: "pwned"},
+		}, ErrUnsafePath},
+		{"absolute path", []zipEntry{
+			{name: "ene.model3.json", content: settings},
+			{name: "/tmp/evil.txt", content: "pwned"},
+		}, ErrUnsafePath},
+		{"symlink", []zipEntry{
+			{name: "ene/ene.model3.json", content: settings},
+			{name: "ene/link", content: "/etc/passwd", mode: os.ModeSymlink | 0777},
+		}, ErrUnsafePath},
+		{"file too large", []zipEntry{
+			{name: "ene/ene.model3.json", content: settings},
+			{name: "ene/ene.moc3", content: big},
+		}, ErrArchiveTooLarge},
+		{"total too large", []zipEntry{
+			{name: "ene/ene.model3.json", content: settings},
+			{name: "ene/a.moc3", content: half},
+			{name: "ene/b.moc3", content: half},
+		}, ErrArchiveTooLarge},
+		{"too many files", func() []zipEntry {
+			entries := []zipEntry{{name: "ene/ene.model3.json", content: settings}}
+			for i := 0; i < testLimits.MaxFiles; i++ {
+				entries = append(entries, zipEntry{name: "ene/" + strings.Repeat("x", i+1), content: "x"})
+			}
+			return entries
+		}(), ErrArchiveTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := t.TempDir()
+			root := filepath.Join(parent, "models")
+			_, err := ImportZip(buildZip(t, tt.entries...), root, testLimits)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if got := listDir(t, parent); len(got) > 1 || (len(got) == 1 && got[0] != "models") {
+				t.Errorf("left behind %v", got)
+			}
+		})
+	}
+}
+
+// A bomb whose headers claim a tiny size must still be stopped by the bytes
+// actually written, and the half written model must be cleaned up.
+func TestImportZipLyingSizes(t *testing.T) {
+	content := bytes.Repeat([]byte("a"), int(testLimits.MaxFileBytes)*2)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("ene/ene.model3.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte(settings))
+	raw, err := zw.CreateRaw(&zip.FileHeader{
+		Name:               "ene/ene.moc3",
+		Method:             zip.Store,
+		CRC32:              crc32.ChecksumIEEE(content),
+		CompressedSize64:   uint64(len(content)),
+		UncompressedSize64: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw.Write(content)
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	root := t.TempDir()
+	if _, err := ImportZip(openZip(t, buf.Bytes()), root, testLimits); err == nil {
+		t.Fatal("expected an error")
+	}
+	if got := listDir(t, root); len(got) != 0 {
+		t.Errorf("left behind %v", got)
+	}
+}
@@ -0,0 +1,171 @@
+package live2d
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var ErrInvalidModelName = errors.New("invalid model name")
+
+// ModelInfo is the parsed view of a model folder shown in the library.
+type ModelInfo struct {
+	Name        string         `json:"name"`
+	File        string         `json:"file"` // settings file, relative to the folder
+	Version     int            `json:"version"`
+	Moc         string         `json:"moc"`
+	Textures    []string       `json:"textures"`
+	Physics     string         `json:"physics,omitempty"`
+	Pose        string         `json:"pose,omitempty"`
+	Expressions []string       `json:"expressions"`
+	Motions     map[string]int `json:"motions"` // group -> number of motions
+	HitAreas    []string       `json:"hit_areas"`
+	// Hotkeys come from a VTube Studio .vtube.json, their expressions and
+	// animations are counted in Expressions and Motions.
+	Hotkeys []VTubeHotkey `json:"hotkeys,omitempty"`
+	Size    int64         `json:"size"`
+	// Problems lists missing or unsafe file references. A model with
+	// problems may fail to load in the viewer.
+	Problems []string `json:"problems"`
+}
+
+func (m *ModelInfo) Valid() bool {
+	return len(m.Problems) == 0
+}
+
+// ModelDir returns the folder of a model inside root, rejecting names that
+// would point elsewhere.
+func ModelDir(root, name string) (string, error) {
+	if name == "" || name == "." || name == ".." || name != filepath.Base(name) || strings.ContainsAny(name, `/\`) {
+		return "", ErrInvalidModelName
+	}
+	return filepath.Join(root, name), nil
+}
+
+// Inspect parses the model in dir and checks every file it references.
+func Inspect(dir string) (*ModelInfo, error) {
+	path, err := FindModelFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	model, err := ReadModel(path)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	var problems []string
+	vt, err := FindVTube(dir)
+	switch {
+	case err == nil && model.Version >= 3:
+		ApplyVTube(dir, model, vt)
+		for _, file := range MissingHotkeyFiles(dir, vt) {
+			problems = append(problems, "missing hotkey file: "+file)
+		}
+	case err != nil && !errors.Is(err, ErrNoVTubeFile):
+		problems = append(problems, "cannot read .vtube.json: "+err.Error())
+	}
+
+	refs := model.FileReferences
+	info := &ModelInfo{
+		Name:        filepath.Base(dir),
+		File:        filepath.Base(path),
+		Version:     model.Version,
+		Moc:         refs.Moc,
+		Textures:    refs.Textures,
+		Physics:     refs.Physics,
+		Pose:        refs.Pose,
+		Expressions: make([]string, 0, len(refs.Expressions)),
+		Motions:     make(map[string]int, len(refs.Motions)),
+		HitAreas:    make([]string, 0, len(model.HitAreas)),
+		Problems:    []string{},
+	}
+	if vt != nil {
+		info.Hotkeys = vt.Hotkeys
+	}
+	for _, e := range refs.Expressions {
+		info.Expressions = append(info.Expressions, e.Name)
+	}
+	for group, motions := range refs.Motions {
+		info.Motions[group] = len(motions)
+	}
+	for _, h := range model.HitAreas {
+		info.HitAreas = append(info.HitAreas, h.Name)
+	}
+	info.Size, _ = dirSize(dir)
+
+	if refs.Moc == "" {
+		info.Problems = append(info.Problems, "no moc file referenced")
+	}
+	if len(refs.Textures) == 0 {
+		info.Problems = append(info.Problems, "no textures referenced")
+	}
+	for _, ref := range referencedFiles(model) {
+		if problem := checkFile(dir, ref); problem != "" {
+			info.Problems = append(info.Problems, problem)
+		}
+	}
+	info.Problems = append(info.Problems, problems...)
+	return info, nil
+}
+
+// referencedFiles lists every file path in the settings, sorted so problems
+// come out in a stable order.
+func referencedFiles(model *Model3) []string {
+	refs := model.FileReferences
+	files := []string{refs.Moc, refs.Physics, refs.Pose, refs.DisplayInfo, refs.UserData}
+	files = append(files, refs.Textures...)
+	for _, e := range refs.Expressions {
+		files = append(files, e.File)
+	}
+	for _, motions := range refs.Motions {
+		for _, m := range motions {
+			files = append(files, m.File, m.Sound)
+		}
+	}
+
+	seen := make(map[string]bool, len(files))
+	result := files[:0]
+	for _, f := range files {
+		if f == "" || seen[f] {
+			continue
+		}
+		seen[f] = true
+		result = append(result, f)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func checkFile(dir, ref string) string {
+	if filepath.IsAbs(ref) || !filepath.IsLocal(filepath.FromSlash(ref)) {
+		return "file outside the model folder: " + ref
+	}
+	info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(ref)))
+	if err != nil {
+		return "missing file: " + ref
+	}
+	if info.IsDir() {
+		return "not a file: " + ref
+	}
+	return ""
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
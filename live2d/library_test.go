@@ -0,0 +1,168 @@
+package live2d
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writePNG(t *testing.T, path string, w, h int) {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		img.Set(x, 0, color.NRGBA{R: 255, A: 255})
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+const settings = `{
+  "Version": 3,
+  "FileReferences": {
+    "Moc": "ene.moc3",
+    "Textures": ["ene.1024/texture_00.png"],
+    "Physics": "ene.physics3.json",
+    "Expressions": [{"Name": "Smile", "File": "exp/smile.exp3.json"}],
+    "Motions": {"Idle": [{"File": "motions/idle.motion3.json", "Sound": "sounds/hi.wav"}]}
+  },
+  "HitAreas": [{"Id": "HitAreaHead", "Name": "Head"}]
+}`
+
+func newModel(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "ene")
+	writeFile(t, filepath.Join(dir, "ene.model3.json"), settings)
+	writeFile(t, filepath.Join(dir, "ene.moc3"), "moc")
+	writeFile(t, filepath.Join(dir, "ene.physics3.json"), "{}")
+	writeFile(t, filepath.Join(dir, "exp/smile.exp3.json"), "{}")
+	writeFile(t, filepath.Join(dir, "motions/idle.motion3.json"), "{}")
+	writeFile(t, filepath.Join(dir, "sounds/hi.wav"), "wav")
+	writePNG(t, filepath.Join(dir, "ene.1024/texture_00.png"), 1024, 512)
+	return dir
+}
+
+func TestInspectValidModel(t *testing.T) {
+	info, err := Inspect(newModel(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.Valid() {
+		t.Fatalf("unexpected problems %v", info.Problems)
+	}
+	if info.Name != "ene" || info.File != "ene.model3.json" || info.Moc != "ene.moc3" || info.Physics != "ene.physics3.json" {
+		t.Errorf("unexpected info %+v", info)
+	}
+	if !reflect.DeepEqual(info.Expressions, []string{"Smile"}) || info.Motions["Idle"] != 1 || !reflect.DeepEqual(info.HitAreas, []string{"Head"}) {
+		t.Errorf("unexpected info %+v", info)
+	}
+	if info.Size == 0 {
+		t.Error("size not computed")
+	}
+}
+
+func TestInspectReportsMissingFiles(t *testing.T) {
+	dir := newModel(t)
+	os.Remove(filepath.Join(dir, "ene.moc3"))
+	os.Remove(filepath.Join(dir, "sounds/hi.wav"))
+
+	info, err := Inspect(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"missing file: ene.moc3", "missing file: sounds/hi.wav"}
+	if !reflect.DeepEqual(info.Problems, want) {
+		t.Errorf("problems = %v, want %v", info.Problems, want)
+	}
+}
+
+func TestInspectRejectsEscapingReferences(t *testing.T) {
+	dir := newModel(t)
+	writeFile(t, filepath.Join(dir, "ene.model3.json"), `{"Version":3,"FileReferences":{"Moc":"../other/x.moc3","Textures":["/etc/passwd"]}}`)
+	info, err := Inspect(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Problems) != 2 || info.Valid() {
+		t.Errorf("problems = %v", info.Problems)
+	}
+}
+
+func TestInspectWithoutSettings(t *testing.T) {
+	if _, err := Inspect(t.TempDir()); err != ErrNoModelFile {
+		t.Errorf("err = %v, want ErrNoModelFile", err)
+	}
+}
+
+func TestModelDir(t *testing.T) {
+	if dir, err := ModelDir("models", "ene"); err != nil || dir != filepath.Join("models", "ene") {
+		t.Errorf("ModelDir(ene) = %q, %v", dir, err)
+	}
+	for _, name := range []string{"", ".", "..", "../x", "a/b", `a\b`} {
+		if _, err := ModelDir("models", name); err != ErrInvalidModelName {
+			t.Errorf("ModelDir(%q) err = %v", name, err)
+		}
+	}
+}
+
+func TestThumbnailFromTexture(t *testing.T) {
+	dir := newModel(t)
+	info, err := Inspect(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, err := MakeThumbnail(dir, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	cfg, err := png.DecodeConfig(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Width != ThumbnailSize || cfg.Height != ThumbnailSize/2 {
+		t.Errorf("thumbnail is %dx%d", cfg.Width, cfg.Height)
+	}
+}
+
+func TestThumbnailPrefersPreview(t *testing.T) {
+	dir := newModel(t)
+	writePNG(t, filepath.Join(dir, "preview.png"), 64, 100)
+	info, _ := Inspect(dir)
+	name, err := MakeThumbnail(dir, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, _ := os.Open(filepath.Join(dir, name))
+	defer f.Close()
+	cfg, _ := png.DecodeConfig(f)
+	if cfg.Width != 64 || cfg.Height != 100 {
+		t.Errorf("small previews are kept as they are, got %dx%d", cfg.Width, cfg.Height)
+	}
+}
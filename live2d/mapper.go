@@ -0,0 +1,186 @@
+package live2d
+
+import (
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Mirai3103/Project-Re-ENE/config"
+)
+
+// Emotions the agent may tag its sentences with.
+const (
+	EmotionNeutral   = "neutral"
+	EmotionHappy     = "happy"
+	EmotionSad       = "sad"
+	EmotionAngry     = "angry"
+	EmotionSurprised = "surprised"
+	EmotionShy       = "shy"
+	EmotionThinking  = "thinking"
+)
+
+var Emotions = []string{
+	EmotionNeutral,
+	EmotionHappy,
+	EmotionSad,
+	EmotionAngry,
+	EmotionSurprised,
+	EmotionShy,
+	EmotionThinking,
+}
+
+// emotionAliases are the words model authors commonly use for the motion
+// groups and expressions of each emotion.
+var emotionAliases = map[string][]string{
+	EmotionNeutral:   {"neutral", "normal", "default"},
+	EmotionHappy:     {"happy", "smile", "joy", "laugh", "fun"},
+	EmotionSad:       {"sad", "cry", "tear", "sorrow"},
+	EmotionAngry:     {"angry", "anger", "mad"},
+	EmotionSurprised: {"surprise", "shock", "amazed"},
+	EmotionShy:       {"shy", "blush", "embarrass"},
+	EmotionThinking:  {"think", "wonder", "doubt"},
+}
+
+func IsEmotion(name string) bool {
+	_, ok := emotionAliases[name]
+	return ok
+}
+
+// Motion is what the frontend plays alongside a sentence. Group is empty
+// when only the expression changes.
+type Motion struct {
+	Group      string `json:"group"`
+	Index      int    `json:"index"`
+	Expression string `json:"expression"`
+}
+
+// Mapper resolves emotions to motions of the active model.
+type Mapper struct {
+	cfg    *config.Live
+	logger *slog.Logger
+
+	mu     sync.Mutex
+	models map[string]*Model3 // by model folder name
+}
+
+func NewMapper(cfg *config.Live, logger *slog.Logger) *Mapper {
+	return &Mapper{
+		cfg:    cfg,
+		logger: logger,
+		models: make(map[string]*Model3),
+	}
+}
+
+// Motion returns the motion for emotion on the active model, or nil when the
+// model has nothing fitting.
+func (m *Mapper) Motion(emotion string) *Motion {
+	if emotion == "" {
+		return nil
+	}
+	cfg := m.cfg.Load()
+	modelName := cfg.CharacterConfig.Live2DModelName
+	model, err := m.model(cfg.ModelsConfig.ModelDir, modelName)
+	if err != nil {
+		m.logger.Warn("Cannot read live2d model", "model", modelName, "error", err)
+		return nil
+	}
+
+	if mapping, ok := cfg.ModelsConfig.EmotionMappings[modelName][emotion]; ok {
+		return m.configured(modelName, model, emotion, mapping)
+	}
+	return match(model, emotion)
+}
+
+// Invalidate drops the cached settings of a model, e.g. after it was
+// replaced or deleted.
+func (m *Mapper) Invalidate(modelName string) {
+	m.mu.Lock()
+	delete(m.models, modelName)
+	m.mu.Unlock()
+}
+
+func (m *Mapper) model(modelDir, name string) (*Model3, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if model, ok := m.models[name]; ok {
+		return model, nil
+	}
+	dir, err := ModelDir(modelDir, name)
+	if err != nil {
+		return nil, err
+	}
+	model, err := LoadModel(dir)
+	if err != nil {
+		return nil, err
+	}
+	m.models[name] = model
+	return model, nil
+}
+
+// configured checks a mapping from the config against the model and keeps
+// the parts that exist.
+func (m *Mapper) configured(modelName string, model *Model3, emotion string, mapping config.EmotionMapping) *Motion {
+	motion := &Motion{}
+	if mapping.MotionGroup != "" {
+		if model.HasMotion(mapping.MotionGroup, mapping.MotionIndex) {
+			motion.Group = mapping.MotionGroup
+			motion.Index = mapping.MotionIndex
+		} else {
+			m.logger.Warn("Motion in emotion mapping not found in model", "model", modelName, "emotion", emotion, "group", mapping.MotionGroup, "index", mapping.MotionIndex)
+		}
+	}
+	if mapping.Expression != "" {
+		if model.HasExpression(mapping.Expression) {
+			motion.Expression = mapping.Expression
+		} else {
+			m.logger.Warn("Expression in emotion mapping not found in model", "model", modelName, "emotion", emotion, "expression", mapping.Expression)
+		}
+	}
+	if motion.Group == "" && motion.Expression == "" {
+		return nil
+	}
+	return motion
+}
+
+// match looks for a motion group and an expression whose name contains one of
+// the emotion's aliases.
+func match(model *Model3, emotion string) *Motion {
+	aliases := emotionAliases[emotion]
+	if len(aliases) == 0 {
+		return nil
+	}
+	motion := &Motion{}
+
+	groups := make([]string, 0, len(model.FileReferences.Motions))
+	for group := range model.FileReferences.Motions {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups) // map order would make the pick random
+	for _, group := range groups {
+		if len(model.FileReferences.Motions[group]) > 0 && containsAny(group, aliases) {
+			motion.Group = group
+			break
+		}
+	}
+	for _, e := range model.FileReferences.Expressions {
+		if containsAny(e.Name, aliases) || containsAny(e.File, aliases) {
+			motion.Expression = e.Name
+			break
+		}
+	}
+	if motion.Group == "" && motion.Expression == "" {
+		return nil
+	}
+	return motion
+}
+
+func containsAny(s string, words []string) bool {
+	s = strings.ToLower(s)
+	for _, w := range words {
+		if strings.Contains(s, w) {
+			return true
+		}
+	}
+	return false
+}
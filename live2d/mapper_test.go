@@ -0,0 +1,61 @@
+package live2d
+
+import (
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/Mirai3103/Project-Re-ENE/config"
+)
+
+func newTestMapper(mappings map[string]config.EmotionMapping) *Mapper {
+	cfg := &config.Config{}
+	cfg.ModelsConfig.ModelDir = "testdata"
+	cfg.CharacterConfig.Live2DModelName = "hiyori"
+	cfg.ModelsConfig.EmotionMappings = map[string]map[string]config.EmotionMapping{"hiyori": mappings}
+	return NewMapper(config.NewLive(cfg), slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestMotionMatchesByName(t *testing.T) {
+	m := newTestMapper(nil)
+	tests := map[string]*Motion{
+		EmotionHappy:    {Group: "Happy", Expression: "Smile"},
+		EmotionAngry:    {Expression: "f02"}, // matched through the file name
+		EmotionThinking: nil,
+		"":              nil,
+	}
+	for emotion, want := range tests {
+		if got := m.Motion(emotion); !reflect.DeepEqual(got, want) {
+			t.Errorf("Motion(%q) = %+v, want %+v", emotion, got, want)
+		}
+	}
+}
+
+func TestMotionFromConfig(t *testing.T) {
+	m := newTestMapper(map[string]config.EmotionMapping{
+		EmotionSad:      {MotionGroup: "TapBody", MotionIndex: 1},
+		EmotionShy:      {MotionGroup: "TapBody", MotionIndex: 5, Expression: "Smile"},
+		EmotionThinking: {MotionGroup: "Missing"},
+	})
+	if got, want := m.Motion(EmotionSad), (&Motion{Group: "TapBody", Index: 1}); !reflect.DeepEqual(got, want) {
+		t.Errorf("sad = %+v, want %+v", got, want)
+	}
+	// the index does not exist, only the expression is kept
+	if got, want := m.Motion(EmotionShy), (&Motion{Expression: "Smile"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("shy = %+v, want %+v", got, want)
+	}
+	if got := m.Motion(EmotionThinking); got != nil {
+		t.Errorf("thinking = %+v, want nil", got)
+	}
+}
+
+func TestMotionUnknownModel(t *testing.T) {
+	m := newTestMapper(nil)
+	cfg := *m.cfg.Load()
+	cfg.CharacterConfig.Live2DModelName = "missing"
+	m.cfg.Store(&cfg)
+	if got := m.Motion(EmotionHappy); got != nil {
+		t.Errorf("got %+v for a missing model", got)
+	}
+}
@@ -0,0 +1,80 @@
+package live2d
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// model2 is a Cubism 2 settings file, usually named <name>.model.json.
+type model2 struct {
+	Model       string   `json:"model"`
+	Textures    []string `json:"textures"`
+	Physics     string   `json:"physics"`
+	Pose        string   `json:"pose"`
+	Expressions []struct {
+		Name string `json:"name"`
+		File string `json:"file"`
+	} `json:"expressions"`
+	Motions map[string][]struct {
+		File    string  `json:"file"`
+		Sound   string  `json:"sound"`
+		FadeIn  float64 `json:"fade_in"` // milliseconds
+		FadeOut float64 `json:"fade_out"`
+	} `json:"motions"`
+	HitAreas []struct {
+		Name string `json:"name"`
+		ID   string `json:"id"`
+	} `json:"hit_areas"`
+}
+
+// ReadModel2 parses a Cubism 2 .model.json into the Cubism 3 shape, with
+// Version set to 2.
+func ReadModel2(path string) (*Model3, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var legacy model2
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return nil, err
+	}
+
+	model := &Model3{
+		Version: 2,
+		FileReferences: FileReferences{
+			Moc:      legacy.Model,
+			Textures: legacy.Textures,
+			Physics:  legacy.Physics,
+			Pose:     legacy.Pose,
+		},
+	}
+	for _, e := range legacy.Expressions {
+		model.FileReferences.Expressions = append(model.FileReferences.Expressions, ExpressionRef{Name: e.Name, File: e.File})
+	}
+	if len(legacy.Motions) > 0 {
+		model.FileReferences.Motions = make(map[string][]Motion3, len(legacy.Motions))
+	}
+	for group, motions := range legacy.Motions {
+		for _, m := range motions {
+			model.FileReferences.Motions[group] = append(model.FileReferences.Motions[group], Motion3{
+				File:        m.File,
+				Sound:       m.Sound,
+				FadeInTime:  m.FadeIn / 1000,
+				FadeOutTime: m.FadeOut / 1000,
+			})
+		}
+	}
+	for _, h := range legacy.HitAreas {
+		model.HitAreas = append(model.HitAreas, HitArea{ID: h.ID, Name: h.Name})
+	}
+	return model, nil
+}
+
+// isModel2File reports whether name is a Cubism 2 settings file. Some
+// models name it just model.json.
+func isModel2File(name string) bool {
+	name = strings.ToLower(filepath.Base(name))
+	return name == "model.json" || strings.HasSuffix(name, ".model.json")
+}
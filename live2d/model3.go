@@ -0,0 +1,142 @@
+// Package live2d reads Live2D Cubism model settings and maps the agent's
+// emotions onto their motions and expressions.
+package live2d
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var ErrNoModelFile = errors.New("no .model3.json or .model.json found")
+
+// Model3 is the part of a .model3.json settings file Ene uses.
+type Model3 struct {
+	Version        int            `json:"Version"`
+	FileReferences FileReferences `json:"FileReferences"`
+	Groups         []Group        `json:"Groups,omitempty"`
+	HitAreas       []HitArea      `json:"HitAreas,omitempty"`
+}
+
+type FileReferences struct {
+	Moc         string               `json:"Moc"`
+	Textures    []string             `json:"Textures"`
+	Physics     string               `json:"Physics,omitempty"`
+	Pose        string               `json:"Pose,omitempty"`
+	DisplayInfo string               `json:"DisplayInfo,omitempty"`
+	UserData    string               `json:"UserData,omitempty"`
+	Expressions []ExpressionRef      `json:"Expressions,omitempty"`
+	Motions     map[string][]Motion3 `json:"Motions,omitempty"`
+}
+
+type ExpressionRef struct {
+	Name string `json:"Name"`
+	File string `json:"File"`
+}
+
+// Motion3 is one entry of a motion group.
+type Motion3 struct {
+	File        string  `json:"File"`
+	Sound       string  `json:"Sound,omitempty"`
+	FadeInTime  float64 `json:"FadeInTime,omitempty"`
+	FadeOutTime float64 `json:"FadeOutTime,omitempty"`
+}
+
+type Group struct {
+	Target string   `json:"Target"`
+	Name   string   `json:"Name"`
+	Ids    []string `json:"Ids"`
+}
+
+type HitArea struct {
+	ID   string `json:"Id"`
+	Name string `json:"Name"`
+}
+
+// ReadModel3 parses a .model3.json file.
+func ReadModel3(path string) (*Model3, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var model Model3
+	if err := json.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+// ReadModel parses Cubism 3 settings, or Cubism 2 settings converted to the
+// same shape.
+func ReadModel(path string) (*Model3, error) {
+	if isModel2File(path) {
+		return ReadModel2(path)
+	}
+	return ReadModel3(path)
+}
+
+// LoadModel reads the settings in dir with the hotkeys of a VTube Studio
+// .vtube.json merged in.
+func LoadModel(dir string) (*Model3, error) {
+	path, err := FindModelFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	model, err := ReadModel(path)
+	if err != nil {
+		return nil, err
+	}
+	if vt, err := FindVTube(dir); err == nil && !isModel2File(path) {
+		ApplyVTube(dir, model, vt)
+	}
+	return model, nil
+}
+
+// FindModelFile returns the settings file directly inside dir, a .model3.json
+// or else a Cubism 2 .model.json.
+func FindModelFile(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	var legacy string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if IsModelFile(entry.Name()) && !isModel2File(entry.Name()) {
+			return filepath.Join(dir, entry.Name()), nil
+		}
+		if legacy == "" && isModel2File(entry.Name()) {
+			legacy = filepath.Join(dir, entry.Name())
+		}
+	}
+	if legacy != "" {
+		return legacy, nil
+	}
+	return "", ErrNoModelFile
+}
+
+// IsModelFile reports whether name is a Cubism 2 or 3 settings file.
+func IsModelFile(name string) bool {
+	name = strings.ToLower(name)
+	return strings.HasSuffix(name, ".model3.json") || isModel2File(name)
+}
+
+// HasExpression reports whether the model defines the named expression.
+func (m *Model3) HasExpression(name string) bool {
+	for _, e := range m.FileReferences.Expressions {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// HasMotion reports whether the group has a motion at index.
+func (m *Model3) HasMotion(group string, index int) bool {
+	motions, ok := m.FileReferences.Motions[group]
+	return ok && index >= 0 && index < len(motions)
+}
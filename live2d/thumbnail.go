@@ -0,0 +1,125 @@
+package live2d
+
+import (
+	"errors"
+	"image"
+	_ "image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/image/draw"
+)
+
+// ThumbnailFile is written inside each model folder.
+const ThumbnailFile = ".thumbnail.png"
+
+const ThumbnailSize = 256
+
+// previewNames are file names authors use for a picture of the model.
+var previewNames = []string{"preview", "icon", "thumbnail", "thumb", "cover"}
+
+// Thumbnail returns the file name of the model folder's thumbnail, or an
+// empty string when none was made yet. It never writes.
+func Thumbnail(dir string) string {
+	if _, err := os.Stat(filepath.Join(dir, ThumbnailFile)); err != nil {
+		return ""
+	}
+	return ThumbnailFile
+}
+
+// MakeThumbnail makes sure the model folder has a thumbnail and returns its
+// file name. A preview image shipped with the model is preferred over the
+// first texture atlas.
+func MakeThumbnail(dir string, info *ModelInfo) (string, error) {
+	target := filepath.Join(dir, ThumbnailFile)
+	if fresh(target, filepath.Join(dir, info.File)) {
+		return ThumbnailFile, nil
+	}
+
+	source := findPreview(dir)
+	if source == "" && len(info.Textures) > 0 {
+		source = filepath.Join(dir, filepath.FromSlash(info.Textures[0]))
+	}
+	if source == "" {
+		return "", errors.New("no image to make a thumbnail from")
+	}
+	if err := writeThumbnail(source, target, ThumbnailSize); err != nil {
+		return "", err
+	}
+	return ThumbnailFile, nil
+}
+
+// fresh reports whether target exists and is newer than source.
+func fresh(target, source string) bool {
+	t, err := os.Stat(target)
+	if err != nil {
+		return false
+	}
+	s, err := os.Stat(source)
+	return err == nil && !t.ModTime().Before(s.ModTime())
+}
+
+func findPreview(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		name := strings.ToLower(entry.Name())
+		ext := filepath.Ext(name)
+		if entry.IsDir() || name == ThumbnailFile || (ext != ".png" && ext != ".jpg" && ext != ".jpeg") {
+			continue
+		}
+		base := strings.TrimSuffix(name, ext)
+		for _, p := range previewNames {
+			if strings.Contains(base, p) {
+				return filepath.Join(dir, entry.Name())
+			}
+		}
+	}
+	return ""
+}
+
+func writeThumbnail(source, target string, size int) error {
+	f, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	src, _, err := image.Decode(f)
+	if err != nil {
+		return err
+	}
+
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w == 0 || h == 0 {
+		return errors.New("empty image")
+	}
+	if w > size || h > size {
+		if w >= h {
+			w, h = size, max(1, h*size/w)
+		} else {
+			w, h = max(1, w*size/h), size
+		}
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+
+	out, err := os.CreateTemp(filepath.Dir(target), ".thumbnail-*.png")
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(out, dst); err != nil {
+		out.Close()
+		os.Remove(out.Name())
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(out.Name())
+		return err
+	}
+	return os.Rename(out.Name(), target)
+}
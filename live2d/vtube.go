@@ -0,0 +1,286 @@
+package live2d
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var ErrNoVTubeFile = errors.New("no .vtube.json found")
+
+// Hotkey actions of VTube Studio that carry an expression or a motion.
+const (
+	VTubeToggleExpression = "ToggleExpression"
+	VTubeTriggerAnimation = "TriggerAnimation"
+)
+
+// VTube is the part of a VTube Studio .vtube.json Ene uses. VTube Studio
+// keeps expressions and animations as hotkeys instead of listing them in the
+// model settings.
+type VTube struct {
+	Version        int    `json:"Version"`
+	Name           string `json:"Name"`
+	FileReferences struct {
+		Icon          string `json:"Icon"`
+		Model         string `json:"Model"`
+		IdleAnimation string `json:"IdleAnimation"`
+	} `json:"FileReferences"`
+	Hotkeys []VTubeHotkey `json:"Hotkeys"`
+}
+
+type VTubeHotkey struct {
+	Name   string `json:"Name"`
+	Action string `json:"Action"`
+	File   string `json:"File"`
+}
+
+// FindVTube reads the .vtube.json directly inside dir.
+func FindVTube(dir string) (*VTube, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(strings.ToLower(entry.Name()), ".vtube.json") {
+			return ReadVTube(filepath.Join(dir, entry.Name()))
+		}
+	}
+	return nil, ErrNoVTubeFile
+}
+
+func ReadVTube(path string) (*VTube, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var vt VTube
+	if err := json.Unmarshal(data, &vt); err != nil {
+		return nil, err
+	}
+	return &vt, nil
+}
+
+// ApplyVTube adds the expression and animation hotkeys of vt to model, so the
+// emotion mapping can pick them by name. Expressions are named after their
+// hotkey and each animation gets a motion group of the same name. The idle
+// animation becomes the Idle group when the model has none. Files the model
+// already references and files that cannot be found are skipped. The returned
+// references hold only what was added.
+func ApplyVTube(dir string, model *Model3, vt *VTube) FileReferences {
+	refs := &model.FileReferences
+	var added FileReferences
+	addMotion := func(group, file string) {
+		if refs.Motions == nil {
+			refs.Motions = make(map[string][]Motion3)
+		}
+		if added.Motions == nil {
+			added.Motions = make(map[string][]Motion3)
+		}
+		refs.Motions[group] = append(refs.Motions[group], Motion3{File: file})
+		added.Motions[group] = append(added.Motions[group], Motion3{File: file})
+	}
+
+	for _, h := range vt.Hotkeys {
+		if h.Action != VTubeToggleExpression && h.Action != VTubeTriggerAnimation {
+			continue
+		}
+		file := resolveFile(dir, h.File)
+		if file == "" || references(model, file) {
+			continue
+		}
+		name := h.Name
+		if name == "" {
+			name = fileStem(file)
+		}
+		if h.Action == VTubeToggleExpression {
+			if model.HasExpression(name) {
+				name = fileStem(file)
+			}
+			if model.HasExpression(name) {
+				continue
+			}
+			e := ExpressionRef{Name: name, File: file}
+			refs.Expressions = append(refs.Expressions, e)
+			added.Expressions = append(added.Expressions, e)
+		} else {
+			addMotion(name, file)
+		}
+	}
+
+	if idle := resolveFile(dir, vt.FileReferences.IdleAnimation); idle != "" && !references(model, idle) && !hasGroup(model, "Idle") {
+		addMotion("Idle", idle)
+	}
+	return added
+}
+
+// MissingHotkeyFiles lists the hotkey files of vt that are not in dir.
+func MissingHotkeyFiles(dir string, vt *VTube) []string {
+	var missing []string
+	for _, h := range vt.Hotkeys {
+		if h.Action != VTubeToggleExpression && h.Action != VTubeTriggerAnimation {
+			continue
+		}
+		if h.File != "" && resolveFile(dir, h.File) == "" {
+			missing = append(missing, h.File)
+		}
+	}
+	return missing
+}
+
+// resolveFile returns the slash separated path of a hotkey file inside dir.
+// VTube Studio stores only the file name, so the folder is searched when the
+// file is not at the top.
+func resolveFile(dir, name string) string {
+	if name == "" || filepath.IsAbs(name) || !filepath.IsLocal(filepath.FromSlash(name)) {
+		return ""
+	}
+	if info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name))); err == nil && info.Mode().IsRegular() {
+		return filepath.ToSlash(name)
+	}
+	base := strings.ToLower(filepath.Base(filepath.FromSlash(name)))
+	var found string
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // skip what cannot be read
+		}
+		if d.Type().IsRegular() && strings.ToLower(d.Name()) == base {
+			rel, _ := filepath.Rel(dir, path)
+			found = filepath.ToSlash(rel)
+			return fs.SkipAll
+		}
+		return nil
+	})
+	return found
+}
+
+// references reports whether the model already uses file as an expression
+// or motion.
+func references(model *Model3, file string) bool {
+	for _, e := range model.FileReferences.Expressions {
+		if strings.EqualFold(e.File, file) {
+			return true
+		}
+	}
+	for _, motions := range model.FileReferences.Motions {
+		for _, m := range motions {
+			if strings.EqualFold(m.File, file) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasGroup(model *Model3, name string) bool {
+	for group := range model.FileReferences.Motions {
+		if strings.EqualFold(group, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// fileStem turns "exp/smile.exp3.json" into "smile".
+func fileStem(file string) string {
+	name := filepath.Base(filepath.FromSlash(file))
+	if i := strings.Index(name, "."); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// writeVTubeSettings adds the hotkeys of the .vtube.json in dir to its
+// .model3.json. Only the new entries are appended, the rest of the file is
+// kept as it was.
+func writeVTubeSettings(dir string) error {
+	vt, err := FindVTube(dir)
+	if errors.Is(err, ErrNoVTubeFile) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	path, err := FindModelFile(dir)
+	if err != nil || isModel2File(path) {
+		return nil // nothing VTube Studio could have loaded either
+	}
+	model, err := ReadModel3(path)
+	if err != nil {
+		return err
+	}
+	added := ApplyVTube(dir, model, vt)
+	if len(added.Expressions) == 0 && len(added.Motions) == 0 {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var settings map[string]json.RawMessage
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return err
+	}
+	var refs map[string]json.RawMessage
+	if err := json.Unmarshal(settings["FileReferences"], &refs); err != nil {
+		return err
+	}
+	if len(added.Expressions) > 0 {
+		if err := appendJSON(refs, "Expressions", added.Expressions); err != nil {
+			return err
+		}
+	}
+	if len(added.Motions) > 0 {
+		var motions map[string]json.RawMessage
+		if raw, ok := refs["Motions"]; ok {
+			if err := json.Unmarshal(raw, &motions); err != nil {
+				return err
+			}
+		}
+		if motions == nil {
+			motions = make(map[string]json.RawMessage)
+		}
+		for group, list := range added.Motions {
+			if err := appendJSON(motions, group, list); err != nil {
+				return err
+			}
+		}
+		if refs["Motions"], err = json.Marshal(motions); err != nil {
+			return err
+		}
+	}
+	if settings["FileReferences"], err = json.Marshal(refs); err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(settings, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, out, 0644)
+}
+
+// appendJSON appends items to the JSON array stored under key.
+func appendJSON[T any](m map[string]json.RawMessage, key string, items []T) error {
+	var list []json.RawMessage
+	if raw, ok := m[key]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return err
+		}
+	}
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		list = append(list, data)
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	m[key] = data
+	return nil
+}
@@ -0,0 +1,225 @@
+package live2d
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Mirai3103/Project-Re-ENE/config"
+)
+
+const vtubeSettings = `{
+  "Version": 3,
+  "FileReferences": {
+    "Moc": "ene.moc3",
+    "Textures": ["ene.1024/texture_00.png"],
+    "Expressions": [{"Name": "Smile", "File": "smile.exp3.json"}]
+  },
+  "Layout": {"Width": 2.0}
+}`
+
+const vtubeJSON = `{
+  "Version": 1,
+  "Name": "Ene",
+  "FileReferences": {"Icon": "ene.png", "Model": "ene.model3.json", "IdleAnimation": "idle.motion3.json"},
+  "Hotkeys": [
+    {"Name": "Smile again", "Action": "ToggleExpression", "File": "smile.exp3.json"},
+    {"Name": "Blush", "Action": "ToggleExpression", "File": "blush.exp3.json"},
+    {"Name": "Angry face", "Action": "ToggleExpression", "File": "angry.exp3.json"},
+    {"Name": "Happy dance", "Action": "TriggerAnimation", "File": "dance.motion3.json"},
+    {"Name": "Reset", "Action": "RemoveAllExpressions", "File": ""},
+    {"Name": "Gone", "Action": "ToggleExpression", "File": "gone.exp3.json"}
+  ]
+}`
+
+func newVTubeModel(t *testing.T, root string) string {
+	t.Helper()
+	dir := filepath.Join(root, "ene")
+	writeFile(t, filepath.Join(dir, "ene.model3.json"), vtubeSettings)
+	writeFile(t, filepath.Join(dir, "ene.vtube.json"), vtubeJSON)
+	writeFile(t, filepath.Join(dir, "ene.moc3"), "moc")
+	writeFile(t, filepath.Join(dir, "ene.1024/texture_00.png"), "png")
+	writeFile(t, filepath.Join(dir, "smile.exp3.json"), "{}")
+	writeFile(t, filepath.Join(dir, "blush.exp3.json"), "{}")
+	writeFile(t, filepath.Join(dir, "expressions/angry.exp3.json"), "{}")
+	writeFile(t, filepath.Join(dir, "dance.motion3.json"), "{}")
+	writeFile(t, filepath.Join(dir, "idle.motion3.json"), "{}")
+	return dir
+}
+
+var wantVTubeRefs = FileReferences{
+	Expressions: []ExpressionRef{
+		{Name: "Blush", File: "blush.exp3.json"},
+		{Name: "Angry face", File: "expressions/angry.exp3.json"},
+	},
+	Motions: map[string][]Motion3{
+		"Happy dance": {{File: "dance.motion3.json"}},
+		"Idle":        {{File: "idle.motion3.json"}},
+	},
+}
+
+func TestLoadModelAppliesVTube(t *testing.T) {
+	dir := newVTubeModel(t, t.TempDir())
+	model, err := LoadModel(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refs := model.FileReferences
+	if len(refs.Expressions) != 3 || refs.Expressions[0].Name != "Smile" {
+		t.Errorf("expressions = %+v", refs.Expressions)
+	}
+	if !reflect.DeepEqual(refs.Expressions[1:], wantVTubeRefs.Expressions) {
+		t.Errorf("added expressions = %+v, want %+v", refs.Expressions[1:], wantVTubeRefs.Expressions)
+	}
+	if !reflect.DeepEqual(refs.Motions, wantVTubeRefs.Motions) {
+		t.Errorf("motions = %+v, want %+v", refs.Motions, wantVTubeRefs.Motions)
+	}
+}
+
+func TestInspectVTube(t *testing.T) {
+	dir := newVTubeModel(t, t.TempDir())
+	info, err := Inspect(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Hotkeys) != 6 {
+		t.Errorf("hotkeys = %d, want 6", len(info.Hotkeys))
+	}
+	if want := []string{"missing hotkey file: gone.exp3.json"}; !reflect.DeepEqual(info.Problems, want) {
+		t.Errorf("problems = %v, want %v", info.Problems, want)
+	}
+	if info.Motions["Happy dance"] != 1 || len(info.Expressions) != 3 {
+		t.Errorf("motions = %v, expressions = %v", info.Motions, info.Expressions)
+	}
+}
+
+func TestMapperUsesVTubeHotkeys(t *testing.T) {
+	root := t.TempDir()
+	newVTubeModel(t, root)
+	cfg := &config.Config{}
+	cfg.ModelsConfig.ModelDir = root
+	cfg.CharacterConfig.Live2DModelName = "ene"
+	m := NewMapper(config.NewLive(cfg), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	tests := map[string]*Motion{
+		EmotionHappy: {Group: "Happy dance", Expression: "Smile"},
+		EmotionShy:   {Expression: "Blush"},
+		EmotionAngry: {Expression: "Angry face"},
+	}
+	for emotion, want := range tests {
+		if got := m.Motion(emotion); !reflect.DeepEqual(got, want) {
+			t.Errorf("Motion(%q) = %+v, want %+v", emotion, got, want)
+		}
+	}
+}
+
+func TestImportZipWritesVTubeHotkeys(t *testing.T) {
+	src := newVTubeModel(t, t.TempDir())
+	var entries []zipEntry
+	filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(filepath.Dir(src), path)
+		data, _ := os.ReadFile(path)
+		entries = append(entries, zipEntry{name: filepath.ToSlash(rel), content: string(data)})
+		return nil
+	})
+
+	root := t.TempDir()
+	name, err := ImportZip(buildZip(t, entries...), root, testLimits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(root, name, "ene.model3.json")
+	model, err := ReadModel3(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(model.FileReferences.Motions, wantVTubeRefs.Motions) {
+		t.Errorf("motions = %+v, want %+v", model.FileReferences.Motions, wantVTubeRefs.Motions)
+	}
+	if len(model.FileReferences.Expressions) != 3 {
+		t.Errorf("expressions = %+v", model.FileReferences.Expressions)
+	}
+
+	// keys Ene does not know about survive
+	data, _ := os.ReadFile(path)
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := raw["Layout"]; !ok {
+		t.Error("Layout was dropped from the settings")
+	}
+
+	// loading again must not add the hotkeys twice
+	loaded, err := LoadModel(filepath.Join(root, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.FileReferences.Expressions) != 3 {
+		t.Errorf("expressions after reload = %+v", loaded.FileReferences.Expressions)
+	}
+}
+
+const model2Settings = `{
+  "version": "Sample 1.0.0",
+  "model": "shizuku.moc",
+  "textures": ["shizuku.1024/texture_00.png"],
+  "physics": "shizuku.physics.json",
+  "expressions": [{"name": "f01", "file": "exp/f01.exp.json"}],
+  "motions": {"idle": [{"file": "motions/idle_00.mtn", "fade_in": 2000, "fade_out": 1000}]},
+  "hit_areas": [{"name": "head", "id": "D_REF.HEAD"}]
+}`
+
+func TestCubism2Model(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "shizuku")
+	writeFile(t, filepath.Join(dir, "shizuku.model.json"), model2Settings)
+	writeFile(t, filepath.Join(dir, "shizuku.physics.json"), "{}")
+	writeFile(t, filepath.Join(dir, "shizuku.moc"), "moc")
+	writeFile(t, filepath.Join(dir, "shizuku.1024/texture_00.png"), "png")
+	writeFile(t, filepath.Join(dir, "exp/f01.exp.json"), "{}")
+
+	path, err := FindModelFile(dir)
+	if err != nil || filepath.Base(path) != "shizuku.model.json" {
+		t.Fatalf("FindModelFile = %q, %v", path, err)
+	}
+	info, err := Inspect(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Version != 2 || info.Moc != "shizuku.moc" || info.Motions["idle"] != 1 {
+		t.Errorf("info = %+v", info)
+	}
+	if want := []string{"missing file: motions/idle_00.mtn"}; !reflect.DeepEqual(info.Problems, want) {
+		t.Errorf("problems = %v, want %v", info.Problems, want)
+	}
+
+	model, err := ReadModel(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := model.FileReferences.Motions["idle"][0]; m.FadeInTime != 2 || m.FadeOutTime != 1 {
+		t.Errorf("fade = %v/%v, want 2/1", m.FadeInTime, m.FadeOutTime)
+	}
+}
+
+func TestImportZipCubism2(t *testing.T) {
+	root := t.TempDir()
+	zr := buildZip(t,
+		zipEntry{name: "pack/shizuku/shizuku.model.json", content: model2Settings},
+		zipEntry{name: "pack/shizuku/shizuku.moc", content: "moc"},
+	)
+	name, err := ImportZip(zr, root, testLimits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "shizuku" {
+		t.Errorf("name = %q, want shizuku", name)
+	}
+}
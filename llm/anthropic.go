@@ -0,0 +1,59 @@
+package llm
+
+import (
+	"context"
+
+	llmConfig "github.com/Mirai3103/Project-Re-ENE/config/llm"
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+
+	"github.com/firebase/genkit/go/ai"
+	"github.com/firebase/genkit/go/core/api"
+	"github.com/firebase/genkit/go/plugins/compat_oai/anthropic"
+)
+
+// anthropicPlugin also registers a model the plugin does not list, a newer
+// Claude or a dated version, with the capabilities from the config.
+type anthropicPlugin struct {
+	*anthropic.Anthropic
+	model string
+	opts  ai.ModelOptions
+}
+
+func (p *anthropicPlugin) Init(ctx context.Context) []api.Action {
+	actions := p.Anthropic.Init(ctx)
+	name := api.NewName(p.Name(), p.model)
+	for _, a := range actions {
+		if a.Name() == name {
+			return actions
+		}
+	}
+	return append(actions, p.DefineModel(p.model, p.opts).(api.Action))
+}
+
+func newAnthropicProvider(cfg *llmConfig.AnthropicConfig) *provider {
+	opts := []option.RequestOption{option.WithAPIKey(cfg.APIKey)}
+	if cfg.BaseURL != "" {
+		opts = append(opts, option.WithBaseURL(cfg.BaseURL))
+	}
+	return &provider{
+		plugin: &anthropicPlugin{
+			Anthropic: &anthropic.Anthropic{Opts: opts},
+			model:     cfg.Model,
+			opts:      ai.ModelOptions{Supports: modelSupports(cfg.Capabilities())},
+		},
+		model:        "anthropic/" + cfg.Model,
+		config:       anthropicGenerateConfig(cfg),
+		capabilities: cfg.Capabilities(),
+	}
+}
+
+func anthropicGenerateConfig(cfg *llmConfig.AnthropicConfig) *openai.ChatCompletionNewParams {
+	params := &openai.ChatCompletionNewParams{
+		Temperature: openai.Float(float64(cfg.Temperature)),
+	}
+	if cfg.MaxTokens > 0 {
+		params.MaxTokens = openai.Int(int64(cfg.MaxTokens))
+	}
+	return params
+}
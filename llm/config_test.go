@@ -0,0 +1,69 @@
+package llm
+
+import (
+	"testing"
+
+	llmConfig "github.com/Mirai3103/Project-Re-ENE/config/llm"
+	"google.golang.org/genai"
+)
+
+func TestGeminiGenerateConfig(t *testing.T) {
+	gc := geminiGenerateConfig(&llmConfig.GeminiConfig{
+		Model:       "gemini-2.5-flash",
+		BaseURL:     "https://proxy.example.com",
+		Temperature: 0.4,
+		TopP:        0.9,
+		MaxTokens:   512,
+		SafetySettings: map[string]string{
+			"harassment":        "block_none",
+			"dangerous_content": "block_only_high",
+		},
+	})
+	if *gc.Temperature != 0.4 || *gc.TopP != 0.9 || gc.MaxOutputTokens != 512 {
+		t.Errorf("sampling = %v, %v, %d", *gc.Temperature, *gc.TopP, gc.MaxOutputTokens)
+	}
+	if gc.HTTPOptions == nil || gc.HTTPOptions.BaseURL != "https://proxy.example.com" {
+		t.Errorf("http options = %+v", gc.HTTPOptions)
+	}
+	want := []genai.SafetySetting{
+		{Category: genai.HarmCategoryDangerousContent, Threshold: genai.HarmBlockThresholdBlockOnlyHigh},
+		{Category: genai.HarmCategoryHarassment, Threshold: genai.HarmBlockThresholdBlockNone},
+	}
+	if len(gc.SafetySettings) != len(want) {
+		t.Fatalf("safety settings = %v", gc.SafetySettings)
+	}
+	for i, s := range gc.SafetySettings {
+		if *s != want[i] {
+			t.Errorf("safety setting %d = %+v, want %+v", i, *s, want[i])
+		}
+	}
+
+	gc = geminiGenerateConfig(&llmConfig.GeminiConfig{Model: "gemini-2.5-flash", Temperature: 0.7})
+	if gc.TopP != nil || gc.HTTPOptions != nil || gc.SafetySettings != nil {
+		t.Errorf("unset options sent: %+v", gc)
+	}
+}
+
+func TestOpenAIGenerateConfig(t *testing.T) {
+	params := openAIGenerateConfig(&llmConfig.OpenAIConfig{
+		BaseURL:     llmConfig.OpenAIBaseURL,
+		Temperature: 0.5,
+		TopP:        0.8,
+		MaxTokens:   256,
+	})
+	if params.Temperature.Value != 0.5 || !params.TopP.Valid() || params.MaxCompletionTokens.Value != 256 || params.MaxTokens.Valid() {
+		t.Errorf("openai params = %+v", params)
+	}
+
+	params = openAIGenerateConfig(&llmConfig.OpenAIConfig{BaseURL: "http://localhost:1234/v1", MaxTokens: 256})
+	if params.MaxTokens.Value != 256 || params.MaxCompletionTokens.Valid() || params.TopP.Valid() {
+		t.Errorf("compatible params = %+v", params)
+	}
+}
+
+func TestModelSupports(t *testing.T) {
+	s := modelSupports(llmConfig.Capabilities{Vision: true})
+	if s.Tools || s.SystemRole || !s.Media || !s.Multiturn {
+		t.Errorf("supports = %+v", s)
+	}
+}
@@ -0,0 +1,270 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	llmConfig "github.com/Mirai3103/Project-Re-ENE/config/llm"
+	"github.com/Mirai3103/Project-Re-ENE/metering"
+	"github.com/Mirai3103/Project-Re-ENE/telemetry"
+	"github.com/firebase/genkit/go/ai"
+	"github.com/firebase/genkit/go/core"
+	"github.com/firebase/genkit/go/genkit"
+	"github.com/openai/openai-go"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/genai"
+)
+
+// chain calls the main model and, when it fails, the fallbacks in order.
+// Each model is retried with exponential backoff on rate limits and server
+// errors, and skipped for a cooldown once it failed too often in a row.
+type chain struct {
+	g      *genkit.Genkit
+	links  []*link
+	retry  llmConfig.RetryConfig
+	logger *slog.Logger
+
+	task  string
+	meter *metering.Meter // nil records nothing
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+type link struct {
+	*provider
+	breaker *breaker
+}
+
+func newChain(g *genkit.Genkit, providers []*provider, cfg *config.LLMConfig, logger *slog.Logger) *chain {
+	c := &chain{
+		g:      g,
+		retry:  llmConfig.RetryConfig{MaxAttempts: 1},
+		logger: logger,
+		now:    time.Now,
+		sleep:  sleep,
+	}
+	if cfg.Retry != nil {
+		c.retry = *cfg.Retry
+	}
+	var threshold int
+	var cooldown time.Duration
+	if cfg.CircuitBreaker != nil {
+		threshold = cfg.CircuitBreaker.FailureThreshold
+		cooldown = time.Duration(cfg.CircuitBreaker.CooldownSeconds) * time.Second
+	}
+	for _, p := range providers {
+		c.links = append(c.links, &link{provider: p, breaker: &breaker{threshold: threshold, cooldown: cooldown}})
+	}
+	return c
+}
+
+// middleware is the chain as genkit model middleware. next is the main
+// model; the fallbacks are looked up on g and sent the request with their
+// own settings.
+func (c *chain) middleware(next ai.ModelFunc) ai.ModelFunc {
+	return func(ctx context.Context, req *ai.ModelRequest, cb ai.ModelStreamCallback) (*ai.ModelResponse, error) {
+		if err := c.meter.Allow(ctx); err != nil {
+			return nil, err
+		}
+		var errs []error
+		for _, l := range c.available() {
+			call, r := next, req
+			if l != c.links[0] {
+				m := genkit.LookupModel(c.g, l.model)
+				if m == nil {
+					errs = append(errs, fmt.Errorf("%s: model not found", l.model))
+					continue
+				}
+				call, r = m.Generate, l.request(req)
+			}
+
+			// Once a chunk went out, another model would start the reply over.
+			streamed := false
+			stream := cb
+			if cb != nil {
+				stream = func(ctx context.Context, chunk *ai.ModelResponseChunk) error {
+					streamed = true
+					return cb(ctx, chunk)
+				}
+			}
+			resp, err := c.call(ctx, l, call, r, stream, &streamed)
+			if ctx.Err() != nil {
+				return resp, err
+			}
+			if l.breaker.record(err, c.now()) {
+				c.logger.Warn("LLM skipped after repeated failures", "model", l.model, "cooldown", l.breaker.cooldown)
+			}
+			if err == nil {
+				if l != c.links[0] {
+					c.logger.Info("LLM answered by fallback", "model", l.model)
+				}
+				return resp, nil
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", l.model, err))
+			if streamed {
+				break
+			}
+			c.logger.Warn("LLM call failed, trying the next model", "model", l.model, "error", err)
+		}
+		return nil, errors.Join(errs...)
+	}
+}
+
+// available lists the links whose breaker is closed, or every link when all
+// are open, so a turn is never refused without a try.
+func (c *chain) available() []*link {
+	now := c.now()
+	var links []*link
+	for _, l := range c.links {
+		if l.breaker.allow(now) {
+			links = append(links, l)
+		}
+	}
+	if len(links) == 0 {
+		return c.links
+	}
+	return links
+}
+
+func (c *chain) call(ctx context.Context, l *link, call ai.ModelFunc, req *ai.ModelRequest, cb ai.ModelStreamCallback, streamed *bool) (*ai.ModelResponse, error) {
+	backoff := time.Duration(c.retry.InitialBackoffMs) * time.Millisecond
+	for attempt := 1; ; attempt++ {
+		resp, err := c.attempt(ctx, l, call, req, cb)
+		if err == nil || attempt >= c.retry.MaxAttempts || *streamed || !retryable(err) {
+			return resp, err
+		}
+		c.logger.Warn("LLM call failed, retrying", "model", l.model, "attempt", attempt, "backoff", backoff, "error", err)
+		if err := c.sleep(ctx, backoff); err != nil {
+			return nil, err
+		}
+		backoff = min(backoff*2, time.Duration(c.retry.MaxBackoffMs)*time.Millisecond)
+	}
+}
+
+// attempt makes one call to the model of l, traced and metered on its own
+// as every attempt is billed.
+func (c *chain) attempt(ctx context.Context, l *link, call ai.ModelFunc, req *ai.ModelRequest, cb ai.ModelStreamCallback) (*ai.ModelResponse, error) {
+	// registry names are the plugin's namespace, then the model
+	_, model, _ := strings.Cut(l.model, "/")
+	ctx, span := telemetry.StartCall(ctx, telemetry.KindLLM, l.name, model)
+	if c.task != "" {
+		span.SetAttributes(attribute.String("ene.task", c.task))
+	}
+	stream := cb
+	if cb != nil {
+		stream = func(ctx context.Context, chunk *ai.ModelResponseChunk) error {
+			span.FirstToken()
+			return cb(ctx, chunk)
+		}
+	}
+	start := c.now()
+	resp, err := call(ctx, req, stream)
+	e := metering.Event{Kind: metering.KindLLM, Task: c.task, Provider: l.name, Model: model, Latency: c.now().Sub(start), Err: err}
+	if resp != nil && resp.Usage != nil {
+		e.InputTokens, e.OutputTokens = resp.Usage.InputTokens, resp.Usage.OutputTokens
+		span.Tokens(e.InputTokens, e.OutputTokens)
+	}
+	span.End(err)
+	c.meter.Record(ctx, e)
+	return resp, err
+}
+
+// request is req with the settings of this fallback. Tools are left out for
+// a model without them, a reply without tools beats no reply.
+func (p *provider) request(req *ai.ModelRequest) *ai.ModelRequest {
+	r := *req
+	r.Config = p.config
+	if !p.capabilities.Tools {
+		r.Tools = nil
+		r.ToolChoice = ""
+	}
+	return &r
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// breaker counts the failed calls of one model in a row. At the threshold
+// the model is skipped for the cooldown; the next call after it is a trial,
+// a success closes the breaker and a failure opens it again.
+type breaker struct {
+	threshold int // 0 never opens
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+func (b *breaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.threshold <= 0 || b.failures < b.threshold || !now.Before(b.openUntil)
+}
+
+// record counts the outcome of a call and tells whether it opened the
+// breaker.
+func (b *breaker) record(err error, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		b.failures = 0
+		return false
+	}
+	b.failures++
+	if b.threshold <= 0 || b.failures < b.threshold {
+		return false
+	}
+	b.openUntil = now.Add(b.cooldown)
+	return true
+}
+
+// ollama's plugin only reports the status in its message.
+var statusPattern = regexp.MustCompile(`status: (\d{3})`)
+
+// statusCode digs the HTTP status out of the errors the provider SDKs
+// return, 0 when there is none.
+func statusCode(err error) int {
+	var geminiErr genai.APIError
+	if errors.As(err, &geminiErr) {
+		return geminiErr.Code
+	}
+	var openaiErr *openai.Error
+	if errors.As(err, &openaiErr) {
+		return openaiErr.StatusCode
+	}
+	var genkitErr *core.GenkitError
+	if errors.As(err, &genkitErr) && genkitErr.HTTPCode != 0 {
+		return genkitErr.HTTPCode
+	}
+	if m := statusPattern.FindStringSubmatch(err.Error()); m != nil {
+		code, _ := strconv.Atoi(m[1])
+		return code
+	}
+	return 0
+}
+
+// retryable tells whether the same call may succeed later: rate limits and
+// server errors.
+func retryable(err error) bool {
+	code := statusCode(err)
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
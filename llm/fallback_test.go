@@ -0,0 +1,210 @@
+package llm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	llmConfig "github.com/Mirai3103/Project-Re-ENE/config/llm"
+	"github.com/Mirai3103/Project-Re-ENE/metering"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+	"github.com/firebase/genkit/go/ai"
+	"github.com/firebase/genkit/go/genkit"
+	"google.golang.org/genai"
+	_ "modernc.org/sqlite"
+)
+
+// testChain builds a chain of a main model run by main and one fallback
+// defined on a genkit instance, which records the requests it gets.
+func testChain(t *testing.T, cfg *config.LLMConfig, fallbackTools bool) (c *chain, fallbackReqs *[]*ai.ModelRequest, sleeps *[]time.Duration) {
+	t.Helper()
+	g := genkit.Init(context.Background())
+	reqs := []*ai.ModelRequest{}
+	genkit.DefineModel(g, "test/fallback", &ai.ModelOptions{Supports: &ai.ModelSupports{Multiturn: true, Tools: fallbackTools, SystemRole: true}},
+		func(ctx context.Context, req *ai.ModelRequest, cb ai.ModelStreamCallback) (*ai.ModelResponse, error) {
+			reqs = append(reqs, req)
+			return &ai.ModelResponse{Message: ai.NewModelTextMessage("from fallback")}, nil
+		})
+	providers := []*provider{
+		{name: "main", model: "test/main", config: "main config", capabilities: llmConfig.Capabilities{Tools: true}},
+		{name: "fallback", model: "test/fallback", config: "fallback config", capabilities: llmConfig.Capabilities{Tools: fallbackTools}},
+	}
+	c = newChain(g, providers, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	waits := []time.Duration{}
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return c, &reqs, &waits
+}
+
+// failing is a main model that fails with errs in turn and then answers.
+func failing(calls *int, errs ...error) ai.ModelFunc {
+	return func(ctx context.Context, req *ai.ModelRequest, cb ai.ModelStreamCallback) (*ai.ModelResponse, error) {
+		*calls++
+		if *calls <= len(errs) {
+			return nil, errs[*calls-1]
+		}
+		return &ai.ModelResponse{Message: ai.NewModelTextMessage("from main")}, nil
+	}
+}
+
+func TestChainRetriesRateLimits(t *testing.T) {
+	c, fallbackReqs, sleeps := testChain(t, &config.LLMConfig{
+		Retry: &llmConfig.RetryConfig{MaxAttempts: 3, InitialBackoffMs: 100, MaxBackoffMs: 150},
+	}, true)
+	calls := 0
+	limited := genai.APIError{Code: 429, Status: "RESOURCE_EXHAUSTED"}
+	resp, err := c.middleware(failing(&calls, limited, fmt.Errorf("wrapped: %w", limited)))(context.Background(), &ai.ModelRequest{}, nil)
+	if err != nil || resp.Text() != "from main" {
+		t.Fatalf("resp %v, err %v", resp, err)
+	}
+	if calls != 3 || len(*fallbackReqs) != 0 {
+		t.Errorf("main called %d times, fallback %d", calls, len(*fallbackReqs))
+	}
+	if want := []time.Duration{100 * time.Millisecond, 150 * time.Millisecond}; fmt.Sprint(*sleeps) != fmt.Sprint(want) {
+		t.Errorf("backoff %v, want %v", *sleeps, want)
+	}
+}
+
+func TestChainFallsBack(t *testing.T) {
+	c, fallbackReqs, sleeps := testChain(t, &config.LLMConfig{Retry: llmConfig.GetDefaultRetryConfig()}, false)
+	calls := 0
+	req := &ai.ModelRequest{Config: "main config", Tools: []*ai.ToolDefinition{{Name: "search"}}}
+	resp, err := c.middleware(failing(&calls, errors.New("invalid key")))(context.Background(), req, nil)
+	if err != nil || resp.Text() != "from fallback" {
+		t.Fatalf("resp %v, err %v", resp, err)
+	}
+	if calls != 1 || len(*sleeps) != 0 {
+		t.Errorf("error without a status retried: %d calls", calls)
+	}
+	got := (*fallbackReqs)[0]
+	if got.Config != "fallback config" || got.Tools != nil {
+		t.Errorf("fallback got config %v, tools %v", got.Config, got.Tools)
+	}
+	if req.Config != "main config" || len(req.Tools) != 1 {
+		t.Error("request of the main model changed")
+	}
+}
+
+func TestChainDoesNotRepeatStreamedReply(t *testing.T) {
+	c, fallbackReqs, _ := testChain(t, &config.LLMConfig{}, true)
+	main := func(ctx context.Context, req *ai.ModelRequest, cb ai.ModelStreamCallback) (*ai.ModelResponse, error) {
+		if err := cb(ctx, &ai.ModelResponseChunk{Content: []*ai.Part{ai.NewTextPart("Hel")}}); err != nil {
+			return nil, err
+		}
+		return nil, genai.APIError{Code: 503}
+	}
+	noop := func(context.Context, *ai.ModelResponseChunk) error { return nil }
+	if _, err := c.middleware(main)(context.Background(), &ai.ModelRequest{}, noop); err == nil {
+		t.Fatal("error hidden")
+	}
+	if len(*fallbackReqs) != 0 {
+		t.Error("fallback started the reply over")
+	}
+}
+
+func TestChainCircuitBreaker(t *testing.T) {
+	c, fallbackReqs, _ := testChain(t, &config.LLMConfig{
+		CircuitBreaker: &llmConfig.CircuitBreakerConfig{FailureThreshold: 2, CooldownSeconds: 60},
+	}, true)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	calls := 0
+	down := genai.APIError{Code: 500}
+	mw := c.middleware(failing(&calls, down, down))
+	for range 3 {
+		if _, err := mw(context.Background(), &ai.ModelRequest{}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 2 || len(*fallbackReqs) != 3 {
+		t.Errorf("main called %d times, fallback %d, want 2 and 3", calls, len(*fallbackReqs))
+	}
+
+	// After the cooldown the main model gets a trial call.
+	now = now.Add(time.Minute)
+	resp, err := mw(context.Background(), &ai.ModelRequest{}, nil)
+	if err != nil || resp.Text() != "from main" {
+		t.Errorf("after cooldown: %v, %v", resp, err)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{genai.APIError{Code: 429}, true},
+		{fmt.Errorf("call: %w", genai.APIError{Code: 503}), true},
+		{genai.APIError{Code: 400}, false},
+		{errors.New("server returned non-200 status: 502, body: bad gateway"), true},
+		{errors.New("server returned non-200 status: 404, body: model not found"), false},
+		{errors.New("connection refused"), false},
+	} {
+		if got := retryable(tc.err); got != tc.want {
+			t.Errorf("retryable(%v) = %v", tc.err, got)
+		}
+	}
+}
+
+// testMeter is an enabled meter on an in-memory database.
+func testMeter(t *testing.T) *metering.Meter {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1) // every connection would get its own database
+	t.Cleanup(func() { db.Close() })
+	schema, err := os.ReadFile("../store/migrations/004_usage_events.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(string(schema)); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.NewLive(&config.Config{MeteringConfig: config.MeteringConfig{Enable: true}})
+	return metering.New(cfg, store.New(db), slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestChainMetersUsagePerTask(t *testing.T) {
+	c, _, _ := testChain(t, &config.LLMConfig{}, true)
+	c.task, c.meter = llmConfig.TaskSummary, testMeter(t)
+	main := func(ctx context.Context, req *ai.ModelRequest, cb ai.ModelStreamCallback) (*ai.ModelResponse, error) {
+		return &ai.ModelResponse{Usage: &ai.GenerationUsage{InputTokens: 120, OutputTokens: 30}}, nil
+	}
+	mw := c.middleware(main)
+	for range 2 {
+		if _, err := mw(context.Background(), &ai.ModelRequest{}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	calls := 0
+	if _, err := c.middleware(failing(&calls, errors.New("down")))(context.Background(), &ai.ModelRequest{}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	usage, err := c.meter.ByTask(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usage) != 2 {
+		t.Fatalf("usage = %+v", usage)
+	}
+	fallback, primary := usage[0], usage[1]
+	if fallback.Task != "summary" || fallback.Model != "fallback" || fallback.Totals.Calls != 1 {
+		t.Errorf("fallback = %+v", fallback)
+	}
+	if primary.Task != "summary" || primary.Model != "main" || primary.Totals.Calls != 3 || primary.Totals.Errors != 1 ||
+		primary.Totals.InputTokens != 240 || primary.Totals.OutputTokens != 60 {
+		t.Errorf("primary = %+v", primary)
+	}
+}
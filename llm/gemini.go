@@ -1,26 +1,45 @@
 package llm
 
 import (
-	"context"
-	"fmt"
+	"slices"
+	"strings"
 
 	llmConfig "github.com/Mirai3103/Project-Re-ENE/config/llm"
 	"google.golang.org/genai"
 
-	"github.com/firebase/genkit/go/ai"
-	"github.com/firebase/genkit/go/genkit"
 	"github.com/firebase/genkit/go/plugins/googlegenai"
 )
 
-func newGeminiModel(ctx context.Context, cfg *llmConfig.GeminiConfig) (*genkit.Genkit, ai.ModelArg, error) {
-	gemini := &googlegenai.GoogleAI{
-		APIKey: cfg.APIKey,
+func newGeminiProvider(cfg *llmConfig.GeminiConfig) *provider {
+	return &provider{
+		plugin:       &googlegenai.GoogleAI{APIKey: cfg.APIKey},
+		model:        "googleai/" + cfg.Model,
+		config:       geminiGenerateConfig(cfg),
+		capabilities: cfg.Capabilities(),
 	}
-	g := genkit.Init(ctx, genkit.WithPlugins(gemini), genkit.WithDefaultModel(cfg.Model))
-	modelRef := googlegenai.GoogleAIModelRef("gemini-2.5-flash", &genai.GenerateContentConfig{
-		Temperature: genai.Ptr[float32](cfg.Temperature),
-		// Other configuration...
+}
+
+// geminiGenerateConfig turns the config into the request settings sent with
+// every call. Zero top_p and max_tokens leave the model defaults.
+func geminiGenerateConfig(cfg *llmConfig.GeminiConfig) *genai.GenerateContentConfig {
+	gc := &genai.GenerateContentConfig{
+		Temperature:     genai.Ptr(cfg.Temperature),
+		MaxOutputTokens: cfg.MaxTokens,
+	}
+	if cfg.TopP > 0 {
+		gc.TopP = genai.Ptr(cfg.TopP)
+	}
+	if cfg.BaseURL != "" {
+		gc.HTTPOptions = &genai.HTTPOptions{BaseURL: cfg.BaseURL}
+	}
+	for category, threshold := range cfg.SafetySettings {
+		gc.SafetySettings = append(gc.SafetySettings, &genai.SafetySetting{
+			Category:  genai.HarmCategory("HARM_CATEGORY_" + strings.ToUpper(category)),
+			Threshold: genai.HarmBlockThreshold(strings.ToUpper(threshold)),
+		})
+	}
+	slices.SortFunc(gc.SafetySettings, func(a, b *genai.SafetySetting) int {
+		return strings.Compare(string(a.Category), string(b.Category))
 	})
-	fmt.Println("Gemini model initialized", cfg.Model)
-	return g, modelRef, nil
+	return gc
 }
@@ -0,0 +1,29 @@
+package llm
+
+import (
+	llmConfig "github.com/Mirai3103/Project-Re-ENE/config/llm"
+
+	"github.com/firebase/genkit/go/ai"
+	"github.com/firebase/genkit/go/genkit"
+	"github.com/firebase/genkit/go/plugins/ollama"
+)
+
+// newOllamaProvider defines the configured model on a local Ollama server.
+// The plugin sends no sampling options, the model's Modelfile decides them.
+func newOllamaProvider(cfg *llmConfig.OllamaConfig) *provider {
+	o := &ollama.Ollama{
+		ServerAddress: cfg.ServerAddress,
+		Timeout:       cfg.Timeout,
+	}
+	return &provider{
+		plugin:       o,
+		model:        "ollama/" + cfg.Model,
+		capabilities: cfg.Capabilities(),
+		define: func(g *genkit.Genkit) {
+			o.DefineModel(g, ollama.ModelDefinition{Name: cfg.Model, Type: "chat"}, &ai.ModelOptions{
+				Label:    cfg.Model,
+				Supports: modelSupports(cfg.Capabilities()),
+			})
+		},
+	}
+}
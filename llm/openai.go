@@ -4,31 +4,70 @@ import (
 	"context"
 
 	llmConfig "github.com/Mirai3103/Project-Re-ENE/config/llm"
-	"github.com/openai/openai-go/option"
+	"github.com/openai/openai-go"
 
 	"github.com/firebase/genkit/go/ai"
-	"github.com/firebase/genkit/go/genkit"
+	"github.com/firebase/genkit/go/core/api"
 	oai "github.com/firebase/genkit/go/plugins/compat_oai"
 )
 
 const key = "random"
 
-func newOpenAIModel(ctx context.Context, cfg *llmConfig.OpenAIConfig) (*genkit.Genkit, ai.ModelArg, error) {
-	o := &oai.OpenAICompatible{
-		APIKey: cfg.APIKey,
-		Opts: []option.RequestOption{
-			option.WithAPIKey(cfg.APIKey),
-			option.WithBaseURL(cfg.BaseURL),
+// compatPlugin registers one model of an OpenAI compatible server, so genkit
+// finds it by name like the models of the other plugins.
+type compatPlugin struct {
+	*oai.OpenAICompatible
+	model string
+	opts  ai.ModelOptions
+}
+
+func (p *compatPlugin) Init(ctx context.Context) []api.Action {
+	p.OpenAICompatible.Init(ctx)
+	return []api.Action{p.DefineModel(p.Provider, p.model, p.opts).(api.Action)}
+}
+
+func newOpenAIProvider(cfg *llmConfig.OpenAIConfig) *provider {
+	return newCompatProvider(key, cfg, cfg.Capabilities())
+}
+
+// newOpenRouterProvider is the compatible provider pointed at OpenRouter.
+func newOpenRouterProvider(cfg *llmConfig.OpenRouterConfig) *provider {
+	return newCompatProvider("openrouter", cfg.OpenAI(), cfg.Capabilities())
+}
+
+func newCompatProvider(name string, cfg *llmConfig.OpenAIConfig, caps llmConfig.Capabilities) *provider {
+	return &provider{
+		plugin: &compatPlugin{
+			OpenAICompatible: &oai.OpenAICompatible{
+				APIKey:   cfg.APIKey,
+				Provider: name,
+				BaseURL:  cfg.BaseURL,
+			},
+			model: cfg.Model,
+			opts:  ai.ModelOptions{Supports: modelSupports(caps)},
 		},
-		Provider: key,
-		BaseURL:  cfg.BaseURL,
+		model:        name + "/" + cfg.Model,
+		config:       openAIGenerateConfig(cfg),
+		capabilities: caps,
 	}
-	g := genkit.Init(ctx, genkit.WithPlugins(o), genkit.WithDefaultModel(key+"/"+cfg.Model))
-	modelRef := o.DefineModel("", key+"/"+cfg.Model, ai.ModelOptions{
-		Supports: &ai.ModelSupports{
-			Tools:      true,
-			SystemRole: true,
-		},
-	})
-	return g, modelRef, nil
+}
+
+// openAIGenerateConfig turns the config into the request settings sent with
+// every call. OpenAI wants max_completion_tokens, most compatible servers
+// still only read max_tokens.
+func openAIGenerateConfig(cfg *llmConfig.OpenAIConfig) *openai.ChatCompletionNewParams {
+	params := &openai.ChatCompletionNewParams{
+		Temperature: openai.Float(float64(cfg.Temperature)),
+	}
+	if cfg.TopP > 0 {
+		params.TopP = openai.Float(float64(cfg.TopP))
+	}
+	if cfg.MaxTokens > 0 {
+		if cfg.IsOpenAI() {
+			params.MaxCompletionTokens = openai.Int(int64(cfg.MaxTokens))
+		} else {
+			params.MaxTokens = openai.Int(int64(cfg.MaxTokens))
+		}
+	}
+	return params
 }
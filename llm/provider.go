@@ -3,9 +3,15 @@ package llm
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
 
 	"github.com/Mirai3103/Project-Re-ENE/config"
+	llmConfig "github.com/Mirai3103/Project-Re-ENE/config/llm"
+	"github.com/Mirai3103/Project-Re-ENE/metering"
 	"github.com/firebase/genkit/go/ai"
+	"github.com/firebase/genkit/go/core/api"
 	"github.com/firebase/genkit/go/genkit"
 )
 
@@ -13,14 +19,188 @@ type LLMProvider interface {
 	GetModel(ctx context.Context) (*genkit.Genkit, ai.ModelArg, error)
 }
 
-func New(ctx context.Context, cfg *config.Config) (*genkit.Genkit, ai.ModelArg, error) {
-	fmt.Println("Getting model", cfg.LLMConfig.Provider)
-	switch cfg.LLMConfig.Provider {
+// provider is one configured LLM service: the plugin to register, the
+// registry name of its model and the request settings sent with each call.
+type provider struct {
+	name         string
+	plugin       api.Plugin
+	model        string
+	config       any
+	capabilities llmConfig.Capabilities
+	define       func(g *genkit.Genkit) // models the plugin does not register itself
+}
+
+func newProvider(cfg *config.LLMConfig, name string) (*provider, error) {
+	var p *provider
+	switch name {
 	case "gemini":
-		return newGeminiModel(ctx, cfg.LLMConfig.GeminiConfig)
+		p = newGeminiProvider(cfg.GeminiConfig)
 	case "openai":
-		return newOpenAIModel(ctx, cfg.LLMConfig.OpenAIConfig)
+		p = newOpenAIProvider(cfg.OpenAIConfig)
+	case "anthropic":
+		p = newAnthropicProvider(cfg.AnthropicConfig)
+	case "ollama":
+		p = newOllamaProvider(cfg.OllamaConfig)
+	case "openrouter":
+		p = newOpenRouterProvider(cfg.OpenRouterConfig)
 	default:
-		return nil, nil, fmt.Errorf("provider not found")
+		return nil, fmt.Errorf("provider not found: %s", name)
+	}
+	p.name = name
+	return p, nil
+}
+
+// Model is the LLM built from the config. G holds the models of the main
+// provider and of every fallback; Ref is the main model with its settings.
+// Pass Fallback with ai.WithMiddleware to every call that should move on
+// to the fallbacks when the main model fails.
+type Model struct {
+	G            *genkit.Genkit
+	Ref          ai.ModelArg
+	Capabilities llmConfig.Capabilities
+	Fallback     ai.ModelMiddleware
+}
+
+// Models holds the model of each task, see llmConfig.TasksConfig. Tasks
+// routed to the same settings share one genkit instance, each with its own
+// fallback chain so usage is metered per task.
+type Models struct {
+	Chat       *Model
+	Extraction *Model
+	Summary    *Model
+}
+
+func NewModels(ctx context.Context, cfg *config.Config, meter *metering.Meter, logger *slog.Logger) (*Models, error) {
+	type built struct {
+		cfg       *config.LLMConfig
+		g         *genkit.Genkit
+		providers []*provider
+	}
+	var instances []built
+	get := func(task string) (*Model, error) {
+		routed := cfg.LLMConfig.ForTask(task)
+		for _, b := range instances {
+			if reflect.DeepEqual(b.cfg, routed) {
+				return newModel(b.g, b.providers, routed, task, meter, logger), nil
+			}
+		}
+		g, providers, err := initProviders(ctx, routed, logger)
+		if err != nil {
+			return nil, fmt.Errorf("%s model: %w", task, err)
+		}
+		instances = append(instances, built{routed, g, providers})
+		return newModel(g, providers, routed, task, meter, logger), nil
+	}
+	var m Models
+	var err error
+	if m.Chat, err = get(llmConfig.TaskChat); err != nil {
+		return nil, err
+	}
+	if m.Extraction, err = get(llmConfig.TaskExtraction); err != nil {
+		return nil, err
+	}
+	if m.Summary, err = get(llmConfig.TaskSummary); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// New builds the main model, without task routing or metering.
+func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Model, error) {
+	g, providers, err := initProviders(ctx, &cfg.LLMConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+	return newModel(g, providers, &cfg.LLMConfig, "", nil, logger), nil
+}
+
+// initProviders registers the main provider and the fallbacks on a new
+// genkit instance.
+func initProviders(ctx context.Context, cfg *config.LLMConfig, logger *slog.Logger) (*genkit.Genkit, []*provider, error) {
+	names := append([]string{cfg.Provider}, cfg.Fallbacks...)
+	providers := make([]*provider, 0, len(names))
+	plugins := make([]api.Plugin, 0, len(names))
+	for _, name := range names {
+		p, err := newProvider(cfg, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		providers = append(providers, p)
+		plugins = append(plugins, p.plugin)
+	}
+	logger.Info("Getting model", "model", providers[0].model, "fallbacks", len(providers)-1)
+
+	g := genkit.Init(ctx, genkit.WithPlugins(plugins...), genkit.WithDefaultModel(providers[0].model))
+	for _, p := range providers {
+		if p.define != nil {
+			p.define(g)
+		}
+	}
+	return g, providers, nil
+}
+
+func newModel(g *genkit.Genkit, providers []*provider, cfg *config.LLMConfig, task string, meter *metering.Meter, logger *slog.Logger) *Model {
+	c := newChain(g, providers, cfg, logger)
+	c.task, c.meter = task, meter
+	main := providers[0]
+	return &Model{
+		G:            g,
+		Ref:          ai.NewModelRef(main.model, main.config),
+		Capabilities: main.capabilities,
+		Fallback:     c.middleware,
+	}
+}
+
+// modelSupports tells genkit what a model accepts, so it rejects tools sent
+// to a model without them and folds the system prompt into the first
+// message where there is no system role.
+func modelSupports(caps llmConfig.Capabilities) *ai.ModelSupports {
+	return &ai.ModelSupports{
+		Multiturn:  true,
+		Tools:      caps.Tools,
+		SystemRole: caps.SystemRole,
+		Media:      caps.Vision || caps.Audio,
+	}
+}
+
+// ConnectionResult is the outcome of TestConnection.
+type ConnectionResult struct {
+	OK        bool   `json:"ok"`
+	Model     string `json:"model"`
+	LatencyMs int64  `json:"latency_ms"`
+	Reply     string `json:"reply,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// TestConnection validates the LLM config, builds its model and asks it for
+// a short reply, so a key, endpoint or model name can be checked before it
+// is saved. Only the main model is asked, the fallbacks are left out.
+func TestConnection(ctx context.Context, cfg *config.Config, logger *slog.Logger) *ConnectionResult {
+	result := &ConnectionResult{}
+	if err := cfg.LLMConfig.Validate(); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	m, err := New(ctx, cfg, logger)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Model = m.Ref.Name()
+	start := time.Now()
+	resp, err := genkit.Generate(ctx, m.G,
+		ai.WithModel(m.Ref),
+		ai.WithPrompt("Reply with the single word: ok"),
+	)
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
 	}
+	result.OK = true
+	result.Reply = resp.Text()
+	return result
 }
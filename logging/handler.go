@@ -0,0 +1,94 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// handler drops records below the level of their package, redacts the rest
+// and hands them to inner and to the recent buffer.
+type handler struct {
+	inner  slog.Handler
+	shared *shared
+
+	// for the recent buffer, which formats records itself
+	attrs  map[string]any
+	prefix string // open groups, "a.b."
+}
+
+type shared struct {
+	levels   *levels
+	redactor *redactor
+	recent   *Recent
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.shared.levels.min
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	pkg := packageOf(r.PC)
+	if r.Level < h.shared.levels.of(pkg) {
+		return nil
+	}
+	red := h.shared.redactor
+	out := slog.NewRecord(r.Time, r.Level, red.text(r.Message), r.PC)
+	entry := Entry{Time: r.Time, Level: r.Level.String(), Package: pkg, Message: out.Message, Attrs: map[string]any{}}
+	for k, v := range h.attrs {
+		entry.Attrs[k] = v
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		a = red.attr(a)
+		out.AddAttrs(a)
+		flatten(entry.Attrs, h.prefix, a)
+		return true
+	})
+	if len(entry.Attrs) == 0 {
+		entry.Attrs = nil
+	}
+	h.shared.recent.add(entry)
+	return h.inner.Handle(ctx, out)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	next := make(map[string]any, len(h.attrs)+len(attrs))
+	for k, v := range h.attrs {
+		next[k] = v
+	}
+	for i, a := range attrs {
+		redacted[i] = h.shared.redactor.attr(a)
+		flatten(next, h.prefix, redacted[i])
+	}
+	return &handler{inner: h.inner.WithAttrs(redacted), shared: h.shared, attrs: next, prefix: h.prefix}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &handler{inner: h.inner.WithGroup(name), shared: h.shared, attrs: h.attrs, prefix: h.prefix + name + "."}
+}
+
+// flatten adds a to m under dotted keys, the way the UI lists them.
+func flatten(m map[string]any, prefix string, a slog.Attr) {
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, g := range v.Group() {
+			flatten(m, prefix, g)
+		}
+		return
+	}
+	if a.Key == "" {
+		return
+	}
+	switch v.Kind() {
+	case slog.KindInt64, slog.KindUint64, slog.KindFloat64, slog.KindBool:
+		m[prefix+a.Key] = v.Any()
+	default:
+		m[prefix+a.Key] = v.String()
+	}
+}
@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"log/slog"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Mirai3103/Project-Re-ENE/config"
+)
+
+const modulePath = "github.com/Mirai3103/Project-Re-ENE"
+
+// levels is the level of each package: the longest configured prefix of its
+// path wins, the base level applies to the rest.
+type levels struct {
+	base     slog.Level
+	min      slog.Level // lowest level of any package, what the handler lets in
+	packages []packageLevel
+}
+
+type packageLevel struct {
+	path  string
+	level slog.Level
+}
+
+func newLevels(c *config.LoggerConfig) (*levels, error) {
+	base, err := config.ParseLogLevel(c.Level)
+	if err != nil {
+		return nil, err
+	}
+	l := &levels{base: base, min: base}
+	for path, level := range c.Packages {
+		lvl, err := config.ParseLogLevel(level)
+		if err != nil {
+			return nil, err
+		}
+		l.packages = append(l.packages, packageLevel{strings.Trim(path, "/"), lvl})
+		l.min = min(l.min, lvl)
+	}
+	sort.Slice(l.packages, func(i, j int) bool { return len(l.packages[i].path) > len(l.packages[j].path) })
+	return l, nil
+}
+
+func (l *levels) of(pkg string) slog.Level {
+	for _, p := range l.packages {
+		if pkg == p.path || strings.HasPrefix(pkg, p.path+"/") {
+			return p.level
+		}
+	}
+	return l.base
+}
+
+var packages sync.Map // pc -> package
+
+// packageOf names the package of the function at pc, relative to the module
+// for the app's own packages and by import path for the others.
+func packageOf(pc uintptr) string {
+	if pc == 0 {
+		return ""
+	}
+	if pkg, ok := packages.Load(pc); ok {
+		return pkg.(string)
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	// github.com/x/y/agent.(*Agent).Chat: the package ends at the first dot
+	// after the last slash
+	name := frame.Function
+	slash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[slash+1:], "."); dot >= 0 {
+		name = name[:slash+1+dot]
+	}
+	pkg := name
+	if rest, ok := strings.CutPrefix(name, modulePath+"/"); ok {
+		pkg = rest
+	}
+	packages.Store(pc, pkg)
+	return pkg
+}
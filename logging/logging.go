@@ -0,0 +1,69 @@
+// Package logging builds the app logger from LoggerConfig: tint on the
+// console or JSON lines in a rotated file, a level per package, prompts and
+// secrets redacted, and the latest records kept for the UI to tail.
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/lmittmann/tint"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// recentSize is how many records Recent keeps.
+const recentSize = 1000
+
+// Logging is the configured logger with the buffer of its latest records.
+type Logging struct {
+	Logger *slog.Logger
+	Recent *Recent
+}
+
+// New builds the logger from cfg. The returned function closes the log
+// file, call it once nothing logs anymore.
+func New(cfg *config.Config) (*Logging, func(), error) {
+	c := &cfg.LoggerConfig
+	levels, err := newLevels(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts := &slog.HandlerOptions{Level: levels.min}
+
+	var inner slog.Handler
+	closeLog := func() {}
+	switch c.Mode {
+	case "file":
+		file := newFileWriter(c)
+		inner = slog.NewJSONHandler(file, opts)
+		closeLog = func() { file.Close() }
+	default:
+		inner = tint.NewHandler(os.Stderr, &tint.Options{Level: levels.min})
+	}
+	recent := newRecent(recentSize)
+	h := &handler{
+		inner: inner,
+		shared: &shared{
+			levels:   levels,
+			redactor: newRedactor(cfg.SecretValues(), c.RedactPrompts),
+			recent:   recent,
+		},
+	}
+	return &Logging{Logger: slog.New(h), Recent: recent}, closeLog, nil
+}
+
+func newFileWriter(c *config.LoggerConfig) io.WriteCloser {
+	file := &lumberjack.Logger{
+		Filename:   c.FilePath,
+		MaxSize:    c.MaxSizeMB,
+		MaxAge:     c.MaxAgeDays,
+		MaxBackups: c.MaxBackups,
+		LocalTime:  true,
+	}
+	if c.RotateDaily {
+		return newDailyWriter(file)
+	}
+	return file
+}
@@ -0,0 +1,65 @@
+package logging
+
+import (
+	"sync"
+	"time"
+)
+
+// Entry is one log record as the UI shows it.
+type Entry struct {
+	ID      int64          `json:"id"`
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"`
+	Package string         `json:"package"`
+	Message string         `json:"message"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+}
+
+// Recent keeps the latest records in a ring, after level filtering and
+// redaction.
+type Recent struct {
+	mu      sync.Mutex
+	entries []Entry
+	next    int   // slot of the next entry
+	lastID  int64 // IDs start at 1
+}
+
+func newRecent(size int) *Recent {
+	return &Recent{entries: make([]Entry, 0, size)}
+}
+
+func (r *Recent) add(e Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastID++
+	e.ID = r.lastID
+	if len(r.entries) < cap(r.entries) {
+		r.entries = append(r.entries, e)
+		return
+	}
+	r.entries[r.next] = e
+	r.next = (r.next + 1) % len(r.entries)
+}
+
+// Since returns up to limit entries after the one with ID afterID, oldest
+// first. Pass 0 for the latest entries, then the ID of the last one seen to
+// follow the log.
+func (r *Recent) Since(afterID int64, limit int) []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []Entry
+	for i := range r.entries {
+		e := r.entries[(r.next+i)%len(r.entries)]
+		if e.ID > afterID {
+			out = append(out, e)
+		}
+	}
+	if limit > 0 && len(out) > limit {
+		if afterID == 0 {
+			out = out[len(out)-limit:] // the latest
+		} else {
+			out = out[:limit] // the next ones, the rest comes on the next call
+		}
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// Keys of attributes holding credentials, whatever their value: api_key,
+// bearer_token, client_secret but not input_tokens.
+var secretKey = regexp.MustCompile(`(?i)(^|[_\-. ])(api[_-]?key|key|token|secret|password|authorization)$`)
+
+// Key formats of the providers in use, for keys that show up in URLs and
+// error messages.
+var secretPattern = regexp.MustCompile(`AIza[0-9A-Za-z_\-]{35}|sk-[A-Za-z0-9_\-]{20,}|(?i:bearer)\s+[A-Za-z0-9._\-]{16,}`)
+
+// Keys of attributes holding what the user said or the model answered.
+var promptKeys = map[string]bool{
+	"prompt": true, "prompts": true, "text": true, "input": true, "message": true, "messages": true,
+	"history": true, "reply": true, "response": true, "final response": true, "summary": true,
+	"facts": true, "transcript": true, "content": true, "sentence": true, "arguments": true,
+}
+
+// redactor hides the configured secrets, anything shaped like a provider key
+// and, when prompts is set, the conversation.
+type redactor struct {
+	secrets *strings.Replacer // nil without secrets
+	prompts bool
+}
+
+func newRedactor(secrets []string, prompts bool) *redactor {
+	r := &redactor{prompts: prompts}
+	var pairs []string
+	for _, s := range secrets {
+		// short values would match ordinary words
+		if len(s) >= 8 {
+			pairs = append(pairs, s, redacted)
+		}
+	}
+	if len(pairs) > 0 {
+		r.secrets = strings.NewReplacer(pairs...)
+	}
+	return r
+}
+
+func (r *redactor) text(s string) string {
+	if r.secrets != nil {
+		s = r.secrets.Replace(s)
+	}
+	return secretPattern.ReplaceAllString(s, redacted)
+}
+
+func (r *redactor) attr(a slog.Attr) slog.Attr {
+	v := a.Value.Resolve()
+	switch {
+	case v.Kind() == slog.KindGroup:
+		group := v.Group()
+		out := make([]slog.Attr, len(group))
+		for i, g := range group {
+			out[i] = r.attr(g)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(out...)}
+	case secretKey.MatchString(a.Key):
+		return slog.String(a.Key, redacted)
+	case r.prompts && promptKeys[strings.ToLower(a.Key)]:
+		return slog.String(a.Key, fmt.Sprintf("[%d chars]", len([]rune(v.String()))))
+	case v.Kind() == slog.KindString:
+		return slog.String(a.Key, r.text(v.String()))
+	case v.Kind() == slog.KindAny:
+		// errors and the like carry URLs and request bodies
+		if err, ok := v.Any().(error); ok {
+			if s := r.text(err.Error()); s != err.Error() {
+				return slog.String(a.Key, s)
+			}
+		}
+	}
+	return slog.Attr{Key: a.Key, Value: v}
+}
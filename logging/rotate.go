@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// dailyWriter starts a new file on the first write of each day, on top of
+// the size limit of lumberjack.
+type dailyWriter struct {
+	file *lumberjack.Logger
+	now  func() time.Time
+
+	mu  sync.Mutex
+	day string // date of the current file, empty until the first write
+}
+
+func newDailyWriter(file *lumberjack.Logger) *dailyWriter {
+	return &dailyWriter{file: file, now: time.Now}
+}
+
+func (w *dailyWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	today := w.now().Format(time.DateOnly)
+	if w.day == "" {
+		// a file left by an earlier run belongs to the day it was last written
+		w.day = today
+		if info, err := os.Stat(w.file.Filename); err == nil {
+			w.day = info.ModTime().Format(time.DateOnly)
+		}
+	}
+	if w.day != today {
+		if err := w.file.Rotate(); err != nil {
+			return 0, err
+		}
+		w.day = today
+	}
+	return w.file.Write(p)
+}
+
+func (w *dailyWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"embed"
 	_ "embed"
-	"log"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
 	"time"
 
+	"github.com/Mirai3103/Project-Re-ENE/agent"
 	"github.com/Mirai3103/Project-Re-ENE/config"
 	"github.com/Mirai3103/Project-Re-ENE/services"
 	"github.com/wailsapp/wails/v3/pkg/application"
@@ -26,32 +30,102 @@ func init() {
 	// and provide a strongly typed JS/TS API for them.
 	application.RegisterEvent[string]("time")
 	// Register events with their data types
-	application.RegisterEvent[SetMotionData]("live2d:set-motion")
+	application.RegisterEvent[services.SetMotionData]("live2d:set-motion")
+	application.RegisterEvent[string]("live2d:model-changed")
 	application.RegisterEvent[services.PlayAudioData]("live2d:play-audio")
+	application.RegisterEvent[agent.ToolConfirmRequest]("tool:confirm")
+	application.RegisterEvent[string]("tool:confirm-closed")
+	application.RegisterEvent[agent.MCPServerInfo]("mcp:status")
 
 }
 
-// main function serves as the application's entry point. It initializes the application, creates a window,
-// and starts a goroutine that emits a time-based event every second. It subsequently runs the application and
-// logs any error that might occur.
+// main parses the flags and runs the app. Errors are printed once run has
+// returned, so its deferred shutdowns have already happened.
 func main() {
+	mcpTransport := flag.String("mcp", "", "run only the MCP server over the given transport (stdio or http), without the window")
+	headless := flag.Bool("headless", false, "run only the HTTP/WebSocket API, without the window")
+	flag.Parse()
+
+	if err := run(*mcpTransport, *headless); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// run initializes the application, then serves the MCP server or the API
+// alone, or creates the window and runs it until it is closed.
+func run(mcpTransport string, headless bool) error {
 	// Load configuration
 	cfg, err := config.LoadConfig("config.yaml")
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	// Initialize all dependencies using Wire
 	ctx := context.Background()
-	appDeps, err := InitializeApplication(ctx, cfg)
+	appDeps, cleanup, err := InitializeApplication(ctx, cfg)
 	if err != nil {
-		panic(err)
+		return err
 	}
+	defer cleanup()
+	defer func() {
+		// export what the last turns left behind, without hanging on a dead collector
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := appDeps.Telemetry.Shutdown(ctx); err != nil {
+			appDeps.Logger.Error("Telemetry shutdown failed", "error", err)
+		}
+	}()
 
 	// Compile the agent
-	err = appDeps.Agent.Compile(ctx)
-	if err != nil {
-		panic(err)
+	if err := appDeps.Agent.Compile(ctx); err != nil {
+		return err
+	}
+
+	// Headless MCP server mode
+	if mcpTransport != "" {
+		cfg.MCPServerConfig.Enable = true
+		cfg.MCPServerConfig.Transport = mcpTransport
+		if err := cfg.MCPServerConfig.Validate(); err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+		return appDeps.MCPServer.Serve(ctx)
+	}
+	if cfg.DiscordConfig.Enable {
+		if err := appDeps.DiscordBot.Start(ctx); err != nil {
+			appDeps.Logger.Error("Discord bot failed to start", "error", err)
+		}
+	}
+	if cfg.TelegramConfig.Enable {
+		if err := appDeps.TelegramBot.Start(ctx); err != nil {
+			appDeps.Logger.Error("Telegram bot failed to start", "error", err)
+		}
+	}
+	// Headless API mode
+	if headless {
+		cfg.APIServerConfig.Enable = true
+		if err := cfg.APIServerConfig.Validate(); err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+		return appDeps.APIServer.Serve(ctx)
+	}
+	if cfg.APIServerConfig.Enable {
+		go func() {
+			if err := appDeps.APIServer.Serve(ctx); err != nil {
+				appDeps.Logger.Error("API server stopped", "error", err)
+			}
+		}()
+	}
+	if cfg.MCPServerConfig.Enable && cfg.MCPServerConfig.Transport == "http" {
+		go func() {
+			if err := appDeps.MCPServer.ServeHTTP(ctx); err != nil {
+				appDeps.Logger.Error("MCP server stopped", "error", err)
+			}
+		}()
 	}
 
 	// Create a new Wails application by providing the necessary options.
@@ -71,6 +145,10 @@ func main() {
 			application.NewService(appDeps.RecorderService),
 			application.NewService(appDeps.ConfigService),
 			application.NewService(appDeps.ChatService),
+			application.NewService(appDeps.ToolService),
+			application.NewService(appDeps.MCPService),
+			application.NewService(appDeps.UsageService),
+			application.NewService(appDeps.LogService),
 		},
 
 		Assets: application.AssetOptions{
@@ -108,15 +186,5 @@ func main() {
 	}()
 
 	// Run the application. This blocks until the application has been exited.
-	err = app.Run()
-
-	// If an error occurred while running the application, log it and exit.
-	if err != nil {
-		log.Fatal(err)
-	}
-}
-
-type SetMotionData struct {
-	Group string
-	Index int
+	return app.Run()
 }
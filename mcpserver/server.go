@@ -0,0 +1,95 @@
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/package/httpserver"
+	"github.com/Mirai3103/Project-Re-ENE/package/mcphttp"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const serverVersion = "1.0.0"
+
+// Agent is what the chat tool needs from agent.Agent.
+type Agent interface {
+	Chat(ctx context.Context, input *agent.FlowInput) (string, error)
+}
+
+// Server exposes Ene's chat, memories and facts as MCP tools.
+type Server struct {
+	cfg              *config.MCPServerConfig
+	agent            Agent
+	embeddingService *agent.EmbeddingService
+	store            *store.Queries
+	logger           *slog.Logger
+	mcp              *server.MCPServer
+}
+
+func New(cfg *config.Config, ag *agent.Agent, embeddingService *agent.EmbeddingService, store *store.Queries, logger *slog.Logger) *Server {
+	return newServer(cfg, ag, embeddingService, store, logger)
+}
+
+func newServer(cfg *config.Config, ag Agent, embeddingService *agent.EmbeddingService, store *store.Queries, logger *slog.Logger) *Server {
+	s := &Server{
+		cfg:              &cfg.MCPServerConfig,
+		agent:            ag,
+		embeddingService: embeddingService,
+		store:            store,
+		logger:           logger,
+	}
+	s.mcp = server.NewMCPServer(
+		cfg.CharacterConfig.CharacterName,
+		serverVersion,
+		server.WithToolCapabilities(false),
+		server.WithRecovery(),
+		server.WithInstructions("Talk to "+cfg.CharacterConfig.CharacterName+" and manage what she remembers about the user."),
+	)
+	s.registerTools()
+	return s
+}
+
+// Serve runs the transport selected in the config until ctx is done.
+func (s *Server) Serve(ctx context.Context) error {
+	switch s.cfg.Transport {
+	case "stdio":
+		return s.ServeStdio(ctx)
+	case "http":
+		return s.ServeHTTP(ctx)
+	default:
+		return errors.New("mcp server transport is not supported: " + s.cfg.Transport)
+	}
+}
+
+// ServeStdio speaks MCP over stdin/stdout. Logs must go to stderr in this mode.
+func (s *Server) ServeStdio(ctx context.Context) error {
+	s.logger.Info("Starting MCP server", "transport", "stdio")
+	return s.serveStdio(ctx, os.Stdin, os.Stdout)
+}
+
+func (s *Server) serveStdio(ctx context.Context, in io.Reader, out io.Writer) error {
+	return server.NewStdioServer(s.mcp).Listen(ctx, in, out)
+}
+
+// ServeHTTP listens on the configured address and serves both streamable HTTP
+// (/mcp) and the older SSE transport (/sse, /message).
+func (s *Server) ServeHTTP(ctx context.Context) error {
+	s.logger.Info("Starting MCP server", "transport", "http", "address", s.cfg.Address)
+	return httpserver.Serve(ctx, s.cfg.Address, s.Handler())
+}
+
+func (s *Server) Handler() http.Handler {
+	sse := server.NewSSEServer(s.mcp, server.WithUseFullURLForMessageEndpoint(false))
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", mcphttp.StreamableHandler(s.mcp))
+	mux.Handle("/sse", sse.SSEHandler())
+	mux.Handle("/message", sse.MessageHandler())
+	return httpserver.RequireToken(s.cfg.BearerToken, false)(mux)
+}
@@ -0,0 +1,239 @@
+package mcpserver
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/package/utils"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	_ "modernc.org/sqlite"
+)
+
+// fakeAgent answers every message with a fixed prefix and remembers the input.
+type fakeAgent struct {
+	mu     sync.Mutex
+	inputs []agent.FlowInput
+	err    error
+}
+
+func (a *fakeAgent) Chat(ctx context.Context, input *agent.FlowInput) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.inputs = append(a.inputs, *input)
+	if a.err != nil {
+		return "", a.err
+	}
+	return "reply to " + input.Text, nil
+}
+
+// fakeEmbedder puts "coffee" on one axis and everything else on the other.
+type fakeEmbedder struct{}
+
+func (fakeEmbedder) Get(ctx context.Context, text string) ([]float32, error) {
+	if strings.Contains(text, "coffee") {
+		return []float32{1, 0}, nil
+	}
+	return []float32{0, 1}, nil
+}
+
+func (e fakeEmbedder) Gets(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i], _ = e.Get(ctx, text)
+	}
+	return vectors, nil
+}
+
+// testStore is an in-memory database with every migration applied.
+func testStore(t *testing.T) *store.Queries {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1) // every connection would get its own database
+	t.Cleanup(func() { db.Close() })
+	files, err := filepath.Glob("../store/migrations/*.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		schema, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(string(schema)); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+	}
+	return store.New(db)
+}
+
+func newTestServer(t *testing.T, token string) (*Server, *fakeAgent, *store.Queries) {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.CharacterConfig.CharacterName = "Ene"
+	cfg.MCPServerConfig = config.MCPServerConfig{Enable: true, Transport: "http", BearerToken: token, UserID: "1", CharacterID: "1"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	queries := testStore(t)
+	ag := &fakeAgent{}
+	embeddingService := agent.NewEmbeddingService(cfg, logger, fakeEmbedder{}, queries)
+	return newServer(cfg, ag, embeddingService, queries, logger), ag, queries
+}
+
+// startClient connects and initializes an MCP client over tr.
+func startClient(t *testing.T, tr transport.Interface) (*client.Client, error) {
+	t.Helper()
+	c := client.NewClient(tr)
+	t.Cleanup(func() { c.Close() })
+	ctx := context.Background()
+	if err := c.Start(ctx); err != nil {
+		return nil, err
+	}
+	req := mcp.InitializeRequest{}
+	req.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	req.Params.ClientInfo = mcp.Implementation{Name: "test", Version: "1.0.0"}
+	_, err := c.Initialize(ctx, req)
+	return c, err
+}
+
+func newStdioClient(t *testing.T, s *Server) (*client.Client, error) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+	go s.serveStdio(ctx, serverIn, serverOut)
+	return startClient(t, transport.NewIO(clientIn, clientOut, io.NopCloser(strings.NewReader(""))))
+}
+
+func newHTTPClient(t *testing.T, s *Server, token string) (*client.Client, error) {
+	t.Helper()
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+	tr, err := transport.NewStreamableHTTP(ts.URL+"/mcp", transport.WithHTTPHeaders(map[string]string{"Authorization": "Bearer " + token}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return startClient(t, tr)
+}
+
+func callTool(t *testing.T, c *client.Client, name string, args map[string]any) string {
+	t.Helper()
+	req := mcp.CallToolRequest{}
+	req.Params.Name = name
+	req.Params.Arguments = args
+	result, err := c.CallTool(context.Background(), req)
+	if err != nil {
+		t.Fatalf("call %s: %v", name, err)
+	}
+	if len(result.Content) != 1 {
+		t.Fatalf("call %s: expected one content item, got %+v", name, result.Content)
+	}
+	text, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		t.Fatalf("call %s: expected text content, got %+v", name, result.Content[0])
+	}
+	if result.IsError {
+		return "error: " + text.Text
+	}
+	return text.Text
+}
+
+// testTools calls the chat, memory and fact tools through c.
+func testTools(t *testing.T, c *client.Client, ag *fakeAgent, queries *store.Queries) {
+	ctx := context.Background()
+
+	var chat chatResult
+	if err := json.Unmarshal([]byte(callTool(t, c, "chat", map[string]any{"message": "hi"})), &chat); err != nil {
+		t.Fatal(err)
+	}
+	if chat.Reply != "reply to hi" || chat.ConversationID == "" {
+		t.Fatalf("unexpected chat result: %+v", chat)
+	}
+	callTool(t, c, "chat", map[string]any{"message": "again", "conversation_id": chat.ConversationID})
+	if len(ag.inputs) != 2 || ag.inputs[1].ConversationID != chat.ConversationID || ag.inputs[1].UserID != "1" {
+		t.Fatalf("conversation was not continued: %+v", ag.inputs)
+	}
+
+	ag.err = errors.New("model down")
+	if out := callTool(t, c, "chat", map[string]any{"message": "hi"}); !strings.HasPrefix(out, "error: ") {
+		t.Fatalf("failed chat should be a tool error, got %q", out)
+	}
+	ag.err = nil
+
+	for id, vector := range map[string][]float32{"near": {1, 0}, "far": {0, 1}} {
+		err := queries.CreateMemory(ctx, store.CreateMemoryParams{
+			ID:        id,
+			Content:   utils.Ptr(id + " memory"),
+			Embedding: store.Float32ToBytes(vector),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	var memories []memoryResult
+	if err := json.Unmarshal([]byte(callTool(t, c, "search_memories", map[string]any{"query": "coffee"})), &memories); err != nil {
+		t.Fatal(err)
+	}
+	if len(memories) != 1 || memories[0].ID != "near" || memories[0].Distance != 0 {
+		t.Fatalf("expected only the near memory, got %+v", memories)
+	}
+
+	if out := callTool(t, c, "add_fact", map[string]any{"subject": "user", "name": "favorite_drink", "value": "coffee"}); out != "fact saved" {
+		t.Fatalf("unexpected add_fact result: %q", out)
+	}
+	facts, err := queries.GetUserFacts(ctx, store.GetUserFactsParams{UserID: utils.Ptr("1"), Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(facts) != 1 || *facts[0].Name != "favorite_drink" || *facts[0].Value != "coffee" {
+		t.Fatalf("fact was not saved: %+v", facts)
+	}
+	if out := callTool(t, c, "add_fact", map[string]any{"subject": "someone", "name": "a", "value": "b"}); !strings.HasPrefix(out, "error: ") {
+		t.Fatalf("unknown subject should be a tool error, got %q", out)
+	}
+}
+
+func TestToolsOverStdio(t *testing.T) {
+	s, ag, queries := newTestServer(t, "")
+	c, err := newStdioClient(t, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testTools(t, c, ag, queries)
+}
+
+func TestToolsOverHTTP(t *testing.T) {
+	s, ag, queries := newTestServer(t, "s3cret")
+	c, err := newHTTPClient(t, s, "s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testTools(t, c, ag, queries)
+}
+
+func TestHTTPRejectsWrongToken(t *testing.T) {
+	s, ag, _ := newTestServer(t, "s3cret")
+	if _, err := newHTTPClient(t, s, "wrong"); err == nil {
+		t.Fatal("initialize with a wrong token should fail")
+	}
+	if len(ag.inputs) != 0 {
+		t.Fatal("the agent must not be reached without the token")
+	}
+}
@@ -0,0 +1,196 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/package/utils"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	defaultMemoryLimit       = 5
+	defaultConversationLimit = 20
+	// memories further than this (L2 distance) are not considered related
+	memorySearchThreshold = 1.2
+)
+
+const (
+	factSubjectUser      = "user"
+	factSubjectCharacter = "character"
+)
+
+type chatResult struct {
+	ConversationID string `json:"conversation_id"`
+	Reply          string `json:"reply"`
+}
+
+type memoryResult struct {
+	ID         string     `json:"id"`
+	Content    string     `json:"content"`
+	Tags       string     `json:"tags,omitempty"`
+	Importance float64    `json:"importance"`
+	Distance   float64    `json:"distance"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+}
+
+type conversationResult struct {
+	ID        string     `json:"id"`
+	Title     string     `json:"title,omitempty"`
+	Summary   string     `json:"summary,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+func (s *Server) registerTools() {
+	s.mcp.AddTool(mcp.NewTool("chat",
+		mcp.WithDescription("Send a message to the character and get her reply. Reuse conversation_id to continue a conversation."),
+		mcp.WithString("message", mcp.Required(), mcp.Description("Message from the user")),
+		mcp.WithString("conversation_id", mcp.Description("Conversation to continue, a new one is started when empty")),
+	), s.chat)
+
+	s.mcp.AddTool(mcp.NewTool("search_memories",
+		mcp.WithDescription("Search long-term memories related to a query."),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("query", mcp.Required(), mcp.Description("What to look for")),
+		mcp.WithNumber("limit", mcp.Description("Maximum number of memories"), mcp.DefaultNumber(defaultMemoryLimit), mcp.Min(1), mcp.Max(50)),
+	), s.searchMemories)
+
+	s.mcp.AddTool(mcp.NewTool("add_fact",
+		mcp.WithDescription("Remember a fact about the user or about the character."),
+		mcp.WithString("subject", mcp.Required(), mcp.Enum(factSubjectUser, factSubjectCharacter), mcp.Description("Who the fact is about")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Short name of the fact, e.g. favorite_food")),
+		mcp.WithString("value", mcp.Required(), mcp.Description("Value of the fact")),
+		mcp.WithString("type", mcp.Description("Optional category of the fact")),
+	), s.addFact)
+
+	s.mcp.AddTool(mcp.NewTool("list_conversations",
+		mcp.WithDescription("List the most recent conversations."),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithNumber("limit", mcp.Description("Maximum number of conversations"), mcp.DefaultNumber(defaultConversationLimit), mcp.Min(1), mcp.Max(100)),
+	), s.listConversations)
+}
+
+func (s *Server) chat(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	message, err := req.RequireString("message")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	conversationID := req.GetString("conversation_id", "")
+	if conversationID == "" {
+		conversationID = uuid.New().String()
+	}
+
+	reply, err := s.agent.Chat(ctx, &agent.FlowInput{
+		Text:           message,
+		ConversationID: conversationID,
+		UserID:         s.cfg.UserID,
+		CharacterID:    s.cfg.CharacterID,
+	})
+	if err != nil {
+		s.logger.Error("MCP chat failed", "conversation_id", conversationID, "error", err)
+		return mcp.NewToolResultErrorFromErr("chat failed", err), nil
+	}
+	return jsonResult(chatResult{ConversationID: conversationID, Reply: reply})
+}
+
+func (s *Server) searchMemories(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query, err := req.RequireString("query")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	limit := req.GetInt("limit", defaultMemoryLimit)
+
+	vector, err := s.embeddingService.EmbedText(ctx, query)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("embedding failed", err), nil
+	}
+	memories, err := s.store.SimilarMemories(ctx, vector, limit, memorySearchThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]memoryResult, 0, len(memories))
+	for _, m := range memories {
+		results = append(results, memoryResult{
+			ID:         m.ID,
+			Content:    utils.OrDefault(m.Content, ""),
+			Tags:       utils.OrDefault(m.Tags, ""),
+			Importance: utils.OrDefault(m.Importance, 0),
+			Distance:   m.Score,
+			CreatedAt:  m.CreatedAt,
+		})
+	}
+	return jsonResult(results)
+}
+
+func (s *Server) addFact(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	subject, err := req.RequireString("subject")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	name, err := req.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	value, err := req.RequireString("value")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var factType *string
+	if t := req.GetString("type", ""); t != "" {
+		factType = utils.Ptr(t)
+	}
+
+	switch subject {
+	case factSubjectUser:
+		err = s.embeddingService.AddUserFact(ctx, &store.UserFact{
+			UserID: utils.Ptr(s.cfg.UserID),
+			Name:   utils.Ptr(name),
+			Value:  utils.Ptr(value),
+			Type:   factType,
+		})
+	case factSubjectCharacter:
+		err = s.embeddingService.AddCharacterFact(ctx, &store.CharacterFact{
+			CharacterID: utils.Ptr(s.cfg.CharacterID),
+			Name:        utils.Ptr(name),
+			Value:       utils.Ptr(value),
+			Type:        factType,
+		})
+	default:
+		return mcp.NewToolResultError("subject must be user or character"), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultText("fact saved"), nil
+}
+
+func (s *Server) listConversations(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	limit := req.GetInt("limit", defaultConversationLimit)
+	conversations, err := s.store.ListConversations(ctx, int64(limit))
+	if err != nil {
+		return nil, err
+	}
+	results := make([]conversationResult, 0, len(conversations))
+	for _, c := range conversations {
+		results = append(results, conversationResult{
+			ID:        c.ID,
+			Title:     utils.OrDefault(c.Title, ""),
+			Summary:   utils.OrDefault(c.CurrentSummary, ""),
+			UpdatedAt: c.UpdatedAt,
+		})
+	}
+	return jsonResult(results)
+}
+
+func jsonResult(v any) (*mcp.CallToolResult, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(data)), nil
+}
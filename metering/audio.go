@@ -0,0 +1,29 @@
+package metering
+
+import "encoding/binary"
+
+// WAVSeconds is the duration of a WAV file, 0 when data is not one.
+func WAVSeconds(data []byte) float64 {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return 0
+	}
+	var byteRate uint32
+	for rest := data[12:]; len(rest) >= 8; {
+		id, size := string(rest[0:4]), binary.LittleEndian.Uint32(rest[4:8])
+		body := rest[8:]
+		switch {
+		case id == "fmt " && len(body) >= 12:
+			byteRate = binary.LittleEndian.Uint32(body[8:12])
+		case id == "data" && byteRate > 0:
+			// recorders streaming the file leave the size unset
+			size = min(size, uint32(len(body)))
+			return float64(size) / float64(byteRate)
+		}
+		next := uint64(size) + uint64(size%2) // chunks are word aligned
+		if next > uint64(len(body)) {
+			return 0
+		}
+		rest = body[next:]
+	}
+	return 0
+}
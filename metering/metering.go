@@ -0,0 +1,178 @@
+// Package metering records what every call to a paid service used: tokens,
+// characters or audio seconds, the latency and an estimated cost from the
+// configured price table. It also enforces the monthly budget.
+package metering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+)
+
+// Kinds of metered calls.
+const (
+	KindLLM       = "llm"
+	KindTTS       = "tts"
+	KindASR       = "asr"
+	KindEmbedding = "embedding"
+)
+
+// ErrBudgetExceeded is returned by Allow once the estimated spend of the
+// month reached the budget.
+var ErrBudgetExceeded = errors.New("monthly budget reached")
+
+// Event is one call to a provider.
+type Event struct {
+	Kind     string
+	Task     string // LLM task, empty for the other kinds
+	Provider string
+	Model    string
+
+	InputTokens  int
+	OutputTokens int
+	Characters   int
+	AudioSeconds float64
+
+	Latency time.Duration
+	Err     error
+}
+
+// Meter records events in the store. A nil Meter records nothing and
+// allows everything.
+type Meter struct {
+	queries *store.Queries
+	cfg     *config.Live
+	logger  *slog.Logger
+	now     func() time.Time
+
+	mu    sync.Mutex
+	month time.Time // start of the month spent covers, zero until loaded
+	spent float64
+}
+
+func New(cfg *config.Live, queries *store.Queries, logger *slog.Logger) *Meter {
+	return &Meter{queries: queries, cfg: cfg, logger: logger, now: time.Now}
+}
+
+// config is the running metering config, reloads may change it between calls.
+func (m *Meter) config() *config.MeteringConfig {
+	return &m.cfg.Load().MeteringConfig
+}
+
+// Allow tells whether another call fits in the monthly budget.
+func (m *Meter) Allow(ctx context.Context) error {
+	if m == nil {
+		return nil
+	}
+	cfg := m.config()
+	if !cfg.Enable || cfg.MonthlyBudget <= 0 {
+		return nil
+	}
+	spent, err := m.monthToDate(ctx)
+	if err != nil {
+		// accounting trouble should not silence the assistant
+		m.logger.Error("read monthly spend", "error", err)
+		return nil
+	}
+	if spent >= cfg.MonthlyBudget {
+		return fmt.Errorf("%w: spent %.2f of %.2f", ErrBudgetExceeded, spent, cfg.MonthlyBudget)
+	}
+	return nil
+}
+
+// Record saves e with its estimated cost. Failures are logged, never
+// returned, as they must not fail the call they account for.
+func (m *Meter) Record(ctx context.Context, e Event) {
+	if m == nil || !m.config().Enable {
+		return
+	}
+	now := m.now()
+	c := m.Cost(e)
+	params := store.CreateUsageEventParams{
+		Kind:         e.Kind,
+		Provider:     e.Provider,
+		Model:        e.Model,
+		InputTokens:  int64(e.InputTokens),
+		OutputTokens: int64(e.OutputTokens),
+		Characters:   int64(e.Characters),
+		AudioSeconds: e.AudioSeconds,
+		LatencyMs:    e.Latency.Milliseconds(),
+		Cost:         c,
+		CreatedAt:    now.UTC(),
+	}
+	if e.Task != "" {
+		params.Task = &e.Task
+	}
+	if id := ConversationFrom(ctx); id != "" {
+		params.ConversationID = &id
+	}
+	if e.Err != nil {
+		msg := e.Err.Error()
+		params.Error = &msg
+	}
+	// the call may have ended because ctx did, the record still belongs
+	if err := m.queries.CreateUsageEvent(context.WithoutCancel(ctx), params); err != nil {
+		m.logger.Error("record usage", "kind", e.Kind, "model", e.Model, "error", err)
+		return
+	}
+
+	m.mu.Lock()
+	if m.month.Equal(monthStart(now)) {
+		m.spent += c
+	}
+	m.mu.Unlock()
+}
+
+// Cost estimates what e cost. Failed calls are not billed.
+func (m *Meter) Cost(e Event) float64 {
+	p, ok := m.config().PriceOf(e.Provider, e.Model)
+	if !ok || e.Err != nil {
+		return 0
+	}
+	return p.PerCall +
+		float64(e.InputTokens)*p.InputPerMillionTokens/1e6 +
+		float64(e.OutputTokens)*p.OutputPerMillionTokens/1e6 +
+		float64(e.Characters)*p.PerThousandCharacters/1e3 +
+		e.AudioSeconds/60*p.PerMinute
+}
+
+// monthToDate is the spend of the current month, read from the store once
+// per month and kept up to date by Record.
+func (m *Meter) monthToDate(ctx context.Context) (float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	start := monthStart(m.now())
+	if !m.month.Equal(start) {
+		spent, err := m.queries.SumUsageCostSince(ctx, start.UTC())
+		if err != nil {
+			return 0, err
+		}
+		m.month, m.spent = start, spent
+	}
+	return m.spent, nil
+}
+
+// monthStart is the first instant of the local month of t.
+func monthStart(t time.Time) time.Time {
+	y, mo, _ := t.Date()
+	return time.Date(y, mo, 1, 0, 0, 0, 0, t.Location())
+}
+
+type conversationKey struct{}
+
+// WithConversation attributes the calls made with ctx to a conversation.
+func WithConversation(ctx context.Context, conversationID string) context.Context {
+	return context.WithValue(ctx, conversationKey{}, conversationID)
+}
+
+// ConversationFrom is the conversation set by WithConversation, if any.
+func ConversationFrom(ctx context.Context) string {
+	id, _ := ctx.Value(conversationKey{}).(string)
+	return id
+}
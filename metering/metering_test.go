@@ -0,0 +1,192 @@
+package metering
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+	_ "modernc.org/sqlite"
+)
+
+// testMeter is a meter on an in-memory database with a fixed clock.
+func testMeter(t *testing.T, cfg config.MeteringConfig, now *time.Time) *Meter {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1) // every connection would get its own database
+	t.Cleanup(func() { db.Close() })
+	schema, err := os.ReadFile("../store/migrations/004_usage_events.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(string(schema)); err != nil {
+		t.Fatal(err)
+	}
+	m := New(config.NewLive(&config.Config{MeteringConfig: cfg}), store.New(db), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	m.now = func() time.Time { return *now }
+	return m
+}
+
+var prices = map[string]config.Price{
+	"gemini/gemini-2.5-flash": {InputPerMillionTokens: 0.30, OutputPerMillionTokens: 2.50},
+	"elevenlabs":              {PerThousandCharacters: 0.10},
+	"elevenlabs/scribe_v1":    {PerMinute: 0.006},
+}
+
+func TestCost(t *testing.T) {
+	m := &Meter{cfg: config.NewLive(&config.Config{MeteringConfig: config.MeteringConfig{Prices: prices}})}
+	for _, tc := range []struct {
+		e    Event
+		want float64
+	}{
+		{Event{Provider: "gemini", Model: "gemini-2.5-flash", InputTokens: 1_000_000, OutputTokens: 200_000}, 0.80},
+		{Event{Provider: "elevenlabs", Model: "eleven_flash_v2_5", Characters: 500}, 0.05},
+		{Event{Provider: "elevenlabs", Model: "scribe_v1", AudioSeconds: 90}, 0.009},
+		{Event{Provider: "ollama", Model: "llama3.2", InputTokens: 1000}, 0},
+		{Event{Provider: "gemini", Model: "gemini-2.5-flash", InputTokens: 1000, Err: errors.New("down")}, 0},
+	} {
+		if got := m.Cost(tc.e); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("Cost(%+v) = %v, want %v", tc.e, got, tc.want)
+		}
+	}
+}
+
+func TestBudgetBlocksCalls(t *testing.T) {
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.Local)
+	m := testMeter(t, config.MeteringConfig{Enable: true, MonthlyBudget: 1, Prices: prices}, &now)
+	ctx := context.Background()
+
+	call := Event{Kind: KindLLM, Provider: "gemini", Model: "gemini-2.5-flash", OutputTokens: 300_000}
+	m.Record(ctx, call)
+	if err := m.Allow(ctx); err != nil {
+		t.Fatalf("blocked at %v spent", 0.75)
+	}
+	m.Record(ctx, call)
+	if err := m.Allow(ctx); !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("Allow = %v over budget", err)
+	}
+	status, err := m.Budget(ctx)
+	if err != nil || !status.Exceeded || status.Month != "2025-03" || math.Abs(status.Spent-1.5) > 1e-9 {
+		t.Errorf("budget %+v, %v", status, err)
+	}
+
+	// A new month starts from nothing, and so does a meter without a cap.
+	now = now.AddDate(0, 0, 1)
+	if err := m.Allow(ctx); err != nil {
+		t.Errorf("blocked in a new month: %v", err)
+	}
+	now = now.AddDate(0, 0, -1)
+	m.cfg.Store(&config.Config{MeteringConfig: config.MeteringConfig{Enable: true, Prices: prices}})
+	if err := m.Allow(ctx); err != nil {
+		t.Errorf("blocked without a budget: %v", err)
+	}
+}
+
+func TestReports(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.Local)
+	m := testMeter(t, config.MeteringConfig{Enable: true, Prices: prices}, &now)
+	ctx := WithConversation(context.Background(), "conv-1")
+
+	m.Record(ctx, Event{Kind: KindLLM, Task: "chat", Provider: "gemini", Model: "gemini-2.5-flash", InputTokens: 1000, OutputTokens: 100, Latency: 300 * time.Millisecond})
+	m.Record(ctx, Event{Kind: KindLLM, Task: "chat", Provider: "gemini", Model: "gemini-2.5-flash", Err: errors.New("down"), Latency: 100 * time.Millisecond})
+	m.Record(ctx, Event{Kind: KindLLM, Task: "extraction", Provider: "ollama", Model: "qwen2.5:3b", InputTokens: 500})
+	m.Record(ctx, Event{Kind: KindTTS, Provider: "elevenlabs", Model: "eleven_flash_v2_5", Characters: 200})
+	now = now.AddDate(0, 0, -1)
+	m.Record(context.Background(), Event{Kind: KindTTS, Provider: "elevenlabs", Model: "eleven_flash_v2_5", Characters: 100})
+	now = now.AddDate(0, 0, 1)
+
+	daily, err := m.Daily(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(daily) != 3 || daily[0].Day != "2025-03-09" || daily[1].Kind != KindLLM {
+		t.Fatalf("daily = %+v", daily)
+	}
+	if llm := daily[1].Totals; llm.Calls != 3 || llm.Errors != 1 || llm.InputTokens != 1500 || llm.AvgLatencyMs != 400.0/3 {
+		t.Errorf("llm totals = %+v", llm)
+	}
+	if today, _ := m.Daily(ctx, 1); len(today) != 2 {
+		t.Errorf("today = %+v", today)
+	}
+
+	conversations, err := m.ByConversation(ctx, 7, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conversations) != 1 || conversations[0].ConversationID != "conv-1" || conversations[0].Totals.Calls != 4 {
+		t.Errorf("conversations = %+v", conversations)
+	}
+
+	byProvider, err := m.ByProvider(ctx, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byProvider) != 3 || byProvider[0].Provider != "elevenlabs" || math.Abs(byProvider[0].Totals.Cost-0.03) > 1e-9 {
+		t.Errorf("providers = %+v", byProvider)
+	}
+
+	byTask, err := m.ByTask(ctx, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byTask) != 2 || byTask[0].Task != "chat" || byTask[0].Totals.Calls != 2 || byTask[0].Totals.Errors != 1 {
+		t.Fatalf("tasks = %+v", byTask)
+	}
+	if byTask[1].Task != "extraction" || byTask[1].Provider != "ollama" || byTask[1].Model != "qwen2.5:3b" || byTask[1].Totals.InputTokens != 500 {
+		t.Errorf("extraction = %+v", byTask[1])
+	}
+}
+
+func TestDisabledMeterRecordsNothing(t *testing.T) {
+	now := time.Now()
+	m := testMeter(t, config.MeteringConfig{MonthlyBudget: 0.01, Prices: prices}, &now)
+	m.Record(context.Background(), Event{Kind: KindTTS, Provider: "elevenlabs", Characters: 1000})
+	if err := m.Allow(context.Background()); err != nil {
+		t.Errorf("disabled meter blocked: %v", err)
+	}
+	if daily, _ := m.Daily(context.Background(), 1); len(daily) != 0 {
+		t.Errorf("recorded %+v", daily)
+	}
+
+	var nilMeter *Meter
+	nilMeter.Record(context.Background(), Event{})
+	if err := nilMeter.Allow(context.Background()); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWAVSeconds(t *testing.T) {
+	wav := func(byteRate uint32, samples int) []byte {
+		b := []byte("RIFF\x00\x00\x00\x00WAVE")
+		b = append(b, "fmt "...)
+		b = binary.LittleEndian.AppendUint32(b, 16)
+		fmtChunk := make([]byte, 16)
+		binary.LittleEndian.PutUint32(fmtChunk[8:], byteRate)
+		b = append(b, fmtChunk...)
+		b = append(b, "data"...)
+		b = binary.LittleEndian.AppendUint32(b, uint32(samples))
+		return append(b, make([]byte, samples)...)
+	}
+	if got := WAVSeconds(wav(32000, 48000)); got != 1.5 {
+		t.Errorf("WAVSeconds = %v, want 1.5", got)
+	}
+	streamed := wav(32000, 16000)
+	binary.LittleEndian.PutUint32(streamed[40:], math.MaxUint32)
+	if got := WAVSeconds(streamed); got != 0.5 {
+		t.Errorf("unset data size: %v, want 0.5", got)
+	}
+	if got := WAVSeconds([]byte("ID3 mp3 data")); got != 0 {
+		t.Errorf("mp3: %v", got)
+	}
+}
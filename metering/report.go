@@ -0,0 +1,142 @@
+package metering
+
+import (
+	"context"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/store"
+)
+
+// Totals sums the calls of a group.
+type Totals struct {
+	Calls        int64   `json:"calls"`
+	Errors       int64   `json:"errors"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	Characters   int64   `json:"characters"`
+	AudioSeconds float64 `json:"audio_seconds"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+	Cost         float64 `json:"cost"`
+}
+
+type DailyUsage struct {
+	Day    string `json:"day"` // local date, 2006-01-02
+	Kind   string `json:"kind"`
+	Totals Totals `json:"totals"`
+}
+
+type ConversationUsage struct {
+	ConversationID string `json:"conversation_id"`
+	Totals         Totals `json:"totals"`
+}
+
+type ProviderUsage struct {
+	Kind     string `json:"kind"`
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	Totals   Totals `json:"totals"`
+}
+
+type TaskUsage struct {
+	Task     string `json:"task"`
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	Totals   Totals `json:"totals"`
+}
+
+type BudgetStatus struct {
+	Month    string  `json:"month"`  // 2006-01
+	Budget   float64 `json:"budget"` // 0 when there is no cap
+	Spent    float64 `json:"spent"`
+	Exceeded bool    `json:"exceeded"`
+}
+
+// Daily sums the usage of the last days, today included, per day and kind.
+func (m *Meter) Daily(ctx context.Context, days int) ([]DailyUsage, error) {
+	rows, err := m.queries.ListDailyUsage(ctx, m.since(days))
+	if err != nil {
+		return nil, err
+	}
+	usage := make([]DailyUsage, len(rows))
+	for i, r := range rows {
+		usage[i] = DailyUsage{Day: r.Day, Kind: r.Kind, Totals: Totals{
+			Calls: r.Calls, Errors: r.Errors, InputTokens: r.InputTokens, OutputTokens: r.OutputTokens,
+			Characters: r.Characters, AudioSeconds: r.AudioSeconds, AvgLatencyMs: r.AvgLatencyMs, Cost: r.Cost,
+		}}
+	}
+	return usage, nil
+}
+
+// ByConversation sums the usage of the last days per conversation, the most
+// expensive first.
+func (m *Meter) ByConversation(ctx context.Context, days, limit int) ([]ConversationUsage, error) {
+	rows, err := m.queries.ListConversationUsage(ctx, store.ListConversationUsageParams{
+		CreatedAt: m.since(days),
+		Limit:     int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	usage := make([]ConversationUsage, len(rows))
+	for i, r := range rows {
+		usage[i] = ConversationUsage{ConversationID: r.ConversationID, Totals: Totals{
+			Calls: r.Calls, Errors: r.Errors, InputTokens: r.InputTokens, OutputTokens: r.OutputTokens,
+			Characters: r.Characters, AudioSeconds: r.AudioSeconds, AvgLatencyMs: r.AvgLatencyMs, Cost: r.Cost,
+		}}
+	}
+	return usage, nil
+}
+
+// ByProvider sums the usage of the last days per kind, provider and model,
+// the most expensive first.
+func (m *Meter) ByProvider(ctx context.Context, days int) ([]ProviderUsage, error) {
+	rows, err := m.queries.ListProviderUsage(ctx, m.since(days))
+	if err != nil {
+		return nil, err
+	}
+	usage := make([]ProviderUsage, len(rows))
+	for i, r := range rows {
+		usage[i] = ProviderUsage{Kind: r.Kind, Provider: r.Provider, Model: r.Model, Totals: Totals{
+			Calls: r.Calls, Errors: r.Errors, InputTokens: r.InputTokens, OutputTokens: r.OutputTokens,
+			Characters: r.Characters, AudioSeconds: r.AudioSeconds, AvgLatencyMs: r.AvgLatencyMs, Cost: r.Cost,
+		}}
+	}
+	return usage, nil
+}
+
+// ByTask sums the LLM usage of the last days per task, provider and model.
+func (m *Meter) ByTask(ctx context.Context, days int) ([]TaskUsage, error) {
+	rows, err := m.queries.ListTaskUsage(ctx, m.since(days))
+	if err != nil {
+		return nil, err
+	}
+	usage := make([]TaskUsage, len(rows))
+	for i, r := range rows {
+		usage[i] = TaskUsage{Task: r.Task, Provider: r.Provider, Model: r.Model, Totals: Totals{
+			Calls: r.Calls, Errors: r.Errors, InputTokens: r.InputTokens, OutputTokens: r.OutputTokens,
+			Characters: r.Characters, AudioSeconds: r.AudioSeconds, AvgLatencyMs: r.AvgLatencyMs, Cost: r.Cost,
+		}}
+	}
+	return usage, nil
+}
+
+// Budget tells how much of the monthly budget is spent.
+func (m *Meter) Budget(ctx context.Context) (*BudgetStatus, error) {
+	spent, err := m.monthToDate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	budget := m.config().MonthlyBudget
+	return &BudgetStatus{
+		Month:    m.now().Format("2006-01"),
+		Budget:   budget,
+		Spent:    spent,
+		Exceeded: budget > 0 && spent >= budget,
+	}, nil
+}
+
+// since is the local midnight starting the last days, today included.
+func (m *Meter) since(days int) time.Time {
+	y, mo, d := m.now().Date()
+	return time.Date(y, mo, d-max(days, 1)+1, 0, 0, 0, 0, m.now().Location()).UTC()
+}
@@ -3,6 +3,7 @@ package audio
 import (
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/ebitengine/oto/v3"
@@ -10,7 +11,10 @@ import (
 )
 
 type Player interface {
+	// Play queues an MP3 stream, clips are played one after another.
 	Play(audio io.Reader)
+	// Wait blocks until every queued clip has been played.
+	Wait()
 	Close()
 }
 
@@ -20,6 +24,7 @@ type audioPlayer struct {
 	ctx   *oto.Context
 	queue chan io.Reader
 	done  chan struct{}
+	wg    sync.WaitGroup
 }
 
 func NewAudioPlayer() (Player, error) {
@@ -47,6 +52,7 @@ func (p *audioPlayer) worker() {
 		select {
 		case file := <-p.queue:
 			p.play(file)
+			p.wg.Done()
 		case <-p.done:
 			close(p.queue)
 			return
@@ -69,9 +75,14 @@ func (p *audioPlayer) play(audio io.Reader) {
 }
 
 func (p *audioPlayer) Play(audio io.Reader) {
+	p.wg.Add(1)
 	p.queue <- audio
 }
 
+func (p *audioPlayer) Wait() {
+	p.wg.Wait()
+}
+
 func (p *audioPlayer) Close() {
 	close(p.done)
 }
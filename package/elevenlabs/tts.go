@@ -2,6 +2,8 @@ package elevenlabs
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 )
@@ -96,3 +98,40 @@ func (c *Client) TTSStream(ctx context.Context, options TTSOptions) (io.Reader,
 	}
 	return resp.Body, nil
 }
+
+// Alignment gives the timing of each character of the spoken text.
+type Alignment struct {
+	Characters                 []string  `json:"characters"`
+	CharacterStartTimesSeconds []float64 `json:"character_start_times_seconds"`
+	CharacterEndTimesSeconds   []float64 `json:"character_end_times_seconds"`
+}
+
+type TTSWithTimestampsResponse struct {
+	AudioBase64         string     `json:"audio_base64"`
+	Alignment           *Alignment `json:"alignment,omitempty"`
+	NormalizedAlignment *Alignment `json:"normalized_alignment,omitempty"`
+}
+
+// TTSWithTimestamps generates speech together with character timings.
+func (c *Client) TTSWithTimestamps(ctx context.Context, options TTSOptions) (*TTSWithTimestampsResponse, error) {
+	log := c.logger
+	log.Debug("Generating TTS with timestamps")
+	resp, err := c.req.R().
+		SetBody(options).
+		SetContext(ctx).
+		Post(fmt.Sprintf("/text-to-speech/%s/with-timestamps?output_format=%s", options.VoiceID, options.OutputFormat))
+	if err != nil {
+		log.Error("Failed to generate TTS with timestamps", "err", err)
+		return nil, err
+	}
+	if !resp.IsSuccessState() {
+		log.Error("Failed to generate TTS with timestamps", "status", resp.GetStatusCode(), "body", resp.String())
+		return nil, errors.New("API error")
+	}
+	var result TTSWithTimestampsResponse
+	if err := json.Unmarshal(resp.Bytes(), &result); err != nil {
+		log.Error("Failed to parse response", "err", err)
+		return nil, errors.New("failed to parse response")
+	}
+	return &result, nil
+}
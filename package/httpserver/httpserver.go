@@ -0,0 +1,64 @@
+// Package httpserver holds what the API and MCP servers share: the listen
+// loop and the bearer token check.
+package httpserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+// Serve listens on addr until ctx is done, then shuts the server down
+// gracefully.
+func Serve(ctx context.Context, addr string, handler http.Handler) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// RequireToken rejects requests that do not carry token as a bearer token.
+// An empty token disables the check. With allowQuery, ?token= is accepted
+// too, browsers cannot set headers on a websocket handshake.
+func RequireToken(token string, allowQuery bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if token == "" {
+			return next
+		}
+		want := []byte(token)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var got string
+			if header := r.Header.Get("Authorization"); header != "" {
+				if bearer, ok := strings.CutPrefix(header, "Bearer "); ok {
+					got = bearer
+				}
+			} else if allowQuery {
+				got = r.URL.Query().Get("token")
+			}
+			if subtle.ConstantTimeCompare([]byte(got), want) != 1 {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error":"unauthorized"}` + "\n"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
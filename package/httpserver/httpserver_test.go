@@ -0,0 +1,42 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireToken(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	tests := []struct {
+		name       string
+		token      string
+		allowQuery bool
+		header     string
+		url        string
+		want       int
+	}{
+		{"disabled", "", false, "", "/", http.StatusNoContent},
+		{"missing", "secret", false, "", "/", http.StatusUnauthorized},
+		{"bearer", "secret", false, "Bearer secret", "/", http.StatusNoContent},
+		{"wrong bearer", "secret", true, "Bearer nope", "/?token=secret", http.StatusUnauthorized},
+		{"bare header", "secret", false, "secret", "/", http.StatusUnauthorized},
+		{"query allowed", "secret", true, "", "/?token=secret", http.StatusNoContent},
+		{"query not allowed", "secret", false, "", "/?token=secret", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			RequireToken(tt.token, tt.allowQuery)(ok).ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Package lipsync computes mouth movement data for TTS audio: a loudness
+// envelope from the decoded MP3 and, when the provider gives character
+// timings, a viseme timeline.
+package lipsync
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/hajimehoshi/go-mp3"
+)
+
+// DefaultFrameMs gives 50 values per second, enough for a mouth parameter.
+const DefaultFrameMs = 20
+
+// Data travels with an audio chunk to the frontend.
+type Data struct {
+	// FrameMs is the duration each Envelope value covers.
+	FrameMs int `json:"frame_ms"`
+	// Envelope is the RMS loudness per frame scaled to 0..1, where 1 is the
+	// loudest frame of the chunk.
+	Envelope []float32 `json:"envelope"`
+	// Visemes is empty when the TTS provider has no timestamps.
+	Visemes []Viseme `json:"visemes,omitempty"`
+}
+
+// Envelope decodes an MP3 and returns its loudness envelope.
+func Envelope(audio []byte, frameMs int) ([]float32, error) {
+	decoder, err := mp3.NewDecoder(bytes.NewReader(audio))
+	if err != nil {
+		return nil, err
+	}
+	pcm, err := io.ReadAll(decoder)
+	if err != nil {
+		return nil, err
+	}
+	return envelopeFromPCM(pcm, decoder.SampleRate(), frameMs), nil
+}
+
+// envelopeFromPCM works on the decoder's output, 16 bit little endian stereo.
+func envelopeFromPCM(pcm []byte, sampleRate, frameMs int) []float32 {
+	const bytesPerSample = 4 // two channels of int16
+	samplesPerFrame := sampleRate * frameMs / 1000
+	if samplesPerFrame <= 0 {
+		return nil
+	}
+	frameBytes := samplesPerFrame * bytesPerSample
+	frames := (len(pcm) + frameBytes - 1) / frameBytes
+
+	envelope := make([]float32, 0, frames)
+	var peak float64
+	for start := 0; start < len(pcm); start += frameBytes {
+		end := min(start+frameBytes, len(pcm))
+		var sum float64
+		var n int
+		for i := start; i+1 < end; i += 2 {
+			s := float64(int16(binary.LittleEndian.Uint16(pcm[i:]))) / math.MaxInt16
+			sum += s * s
+			n++
+		}
+		var rms float64
+		if n > 0 {
+			rms = math.Sqrt(sum / float64(n))
+		}
+		peak = max(peak, rms)
+		envelope = append(envelope, float32(rms))
+	}
+	if peak == 0 {
+		return envelope
+	}
+	for i, v := range envelope {
+		// three decimals is plenty for a mouth and keeps the JSON small
+		envelope[i] = float32(math.Round(float64(v)/peak*1000) / 1000)
+	}
+	return envelope
+}
@@ -0,0 +1,101 @@
+package lipsync
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// silentMP3 builds MPEG-1 Layer III frames (128 kbps, 44.1 kHz) whose side
+// info and main data are all zero, which decodes to silence.
+func silentMP3(frames int) []byte {
+	const frameSize = 417
+	var buf bytes.Buffer
+	for i := 0; i < frames; i++ {
+		frame := make([]byte, frameSize)
+		copy(frame, []byte{0xFF, 0xFB, 0x90, 0x64})
+		buf.Write(frame)
+	}
+	return buf.Bytes()
+}
+
+func stereoPCM(amplitudes []float64, samplesEach int) []byte {
+	var buf bytes.Buffer
+	for _, amp := range amplitudes {
+		for i := 0; i < samplesEach; i++ {
+			v := int16(amp * math.MaxInt16)
+			if i%2 == 1 {
+				v = -v // square wave, RMS equals the amplitude
+			}
+			binary.Write(&buf, binary.LittleEndian, v)
+			binary.Write(&buf, binary.LittleEndian, v)
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestEnvelopeFromPCM(t *testing.T) {
+	// 20 ms at 1000 Hz is 20 samples per frame
+	pcm := stereoPCM([]float64{0, 0.25, 0.5}, 20)
+	got := envelopeFromPCM(pcm, 1000, 20)
+	want := []float32{0, 0.5, 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestEnvelopeFromPCMPartialFrame(t *testing.T) {
+	pcm := stereoPCM([]float64{0.5}, 30)
+	if got := envelopeFromPCM(pcm, 1000, 20); len(got) != 2 {
+		t.Errorf("got %d frames, want 2", len(got))
+	}
+}
+
+func TestEnvelopeDecodesMP3(t *testing.T) {
+	envelope, err := Envelope(silentMP3(50), DefaultFrameMs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 50 frames of 1152 samples at 44.1 kHz is about 1.3 s
+	if len(envelope) < 60 || len(envelope) > 70 {
+		t.Errorf("got %d values for ~1.3 s of audio", len(envelope))
+	}
+	for i, v := range envelope {
+		if v != 0 {
+			t.Fatalf("value %d = %v, want silence", i, v)
+		}
+	}
+}
+
+func TestEnvelopeRejectsGarbage(t *testing.T) {
+	if _, err := Envelope([]byte("not an mp3"), DefaultFrameMs); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestVisemes(t *testing.T) {
+	chars := []string{"C", "h", "à", "o", " ", "b", "ạ", "n", "!"}
+	starts := []float64{0, 0.05, 0.1, 0.2, 0.3, 0.35, 0.4, 0.5, 0.55}
+	ends := []float64{0.05, 0.1, 0.2, 0.3, 0.35, 0.4, 0.5, 0.55, 0.6}
+	want := []Viseme{
+		{Shape: VisemeA, Start: 0.1, End: 0.2},
+		{Shape: VisemeO, Start: 0.2, End: 0.3},
+		{Shape: VisemeRest, Start: 0.3, End: 0.35},
+		{Shape: VisemeClosed, Start: 0.35, End: 0.4},
+		{Shape: VisemeA, Start: 0.4, End: 0.55},
+		{Shape: VisemeRest, Start: 0.55, End: 0.6},
+	}
+	if got := Visemes(chars, starts, ends); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestVisemesVietnameseVowels(t *testing.T) {
+	for char, shape := range map[string]string{"ơ": VisemeO, "ư": VisemeU, "ế": VisemeE, "ỳ": VisemeI, "đ": ""} {
+		if got := shapeOf(char); got != shape {
+			t.Errorf("shapeOf(%q) = %q, want %q", char, got, shape)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package lipsync
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Mouth shapes, the five vowels Live2D models are usually rigged for plus a
+// closed and a resting mouth.
+const (
+	VisemeA      = "A"
+	VisemeI      = "I"
+	VisemeU      = "U"
+	VisemeE      = "E"
+	VisemeO      = "O"
+	VisemeClosed = "closed" // b, m, p
+	VisemeRest   = "rest"
+)
+
+// Viseme is one mouth shape held from Start to End, in seconds from the
+// beginning of the chunk.
+type Viseme struct {
+	Shape string  `json:"shape"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// Visemes turns per-character timings into a mouth shape timeline. Other
+// consonants extend the shape before them, which looks more natural than
+// snapping the mouth for every letter.
+func Visemes(characters []string, starts, ends []float64) []Viseme {
+	n := min(len(characters), len(starts), len(ends))
+	var timeline []Viseme
+	for i := 0; i < n; i++ {
+		shape := shapeOf(characters[i])
+		if shape == "" {
+			if len(timeline) > 0 {
+				timeline[len(timeline)-1].End = ends[i]
+			}
+			continue
+		}
+		if last := len(timeline) - 1; last >= 0 && timeline[last].Shape == shape {
+			timeline[last].End = ends[i]
+			continue
+		}
+		timeline = append(timeline, Viseme{Shape: shape, Start: starts[i], End: ends[i]})
+	}
+	return timeline
+}
+
+// shapeOf returns the shape of a character, or "" for consonants that do not
+// set one. Vietnamese diacritics are dropped first, "ơ" is still an "o".
+func shapeOf(character string) string {
+	r := baseLetter(character)
+	switch r {
+	case 'a':
+		return VisemeA
+	case 'i', 'y':
+		return VisemeI
+	case 'u', 'w':
+		return VisemeU
+	case 'e':
+		return VisemeE
+	case 'o':
+		return VisemeO
+	case 'b', 'm', 'p':
+		return VisemeClosed
+	}
+	if r == 0 || unicode.IsSpace(r) || unicode.IsPunct(r) {
+		return VisemeRest
+	}
+	return ""
+}
+
+func baseLetter(character string) rune {
+	for _, r := range norm.NFD.String(strings.ToLower(character)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if r == 'đ' {
+			return 'd'
+		}
+		return r
+	}
+	return 0
+}
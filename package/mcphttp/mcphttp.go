@@ -0,0 +1,38 @@
+// Package mcphttp serves an MCP server over the streamable HTTP transport.
+package mcphttp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const maxRequestBody = 4 << 20
+
+// StreamableHandler serves the streamable HTTP transport in its simplest
+// form: every JSON-RPC POST gets a single JSON response. mcp-go has no server
+// for this transport yet and none of our tools need server-sent streams.
+func StreamableHandler(s *server.MCPServer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBody))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp := s.HandleMessage(r.Context(), body)
+		if resp == nil {
+			// notifications and responses have nothing to answer
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+}
@@ -0,0 +1,233 @@
+// Package ogg reads and writes Opus packets in an Ogg container (RFC 7845).
+// Voice chats deliver raw Opus packets while speech APIs and voice notes
+// expect .ogg/.opus files, this package converts between the two.
+package ogg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	pageHeaderSize = 27
+	maxSegmentSize = 255
+	maxSegments    = 255
+
+	flagContinued = 0x01
+	flagBOS       = 0x02
+	flagEOS       = 0x04
+)
+
+var (
+	ErrInvalidPage = errors.New("ogg: invalid page")
+	ErrNotOpus     = errors.New("ogg: stream is not opus")
+)
+
+// OpusWriter writes Opus packets into an Ogg Opus stream. Packets are kept
+// one per page, which is valid and keeps the writer simple.
+type OpusWriter struct {
+	w        io.Writer
+	serial   uint32
+	sequence uint32
+	granule  uint64
+	pending  []byte
+	hasData  bool
+	closed   bool
+}
+
+// NewOpusWriter writes the OpusHead and OpusTags headers. sampleRate is only
+// informative, Opus granule positions always count 48 kHz samples.
+func NewOpusWriter(w io.Writer, sampleRate uint32, channels uint8) (*OpusWriter, error) {
+	ow := &OpusWriter{w: w, serial: 0x454e45}
+
+	head := make([]byte, 19)
+	copy(head, "OpusHead")
+	head[8] = 1 // version
+	head[9] = channels
+	binary.LittleEndian.PutUint16(head[10:], 0) // pre-skip
+	binary.LittleEndian.PutUint32(head[12:], sampleRate)
+	binary.LittleEndian.PutUint16(head[16:], 0) // output gain
+	head[18] = 0                                // channel mapping family
+	if err := ow.writePage(head, 0, flagBOS); err != nil {
+		return nil, err
+	}
+
+	vendor := "ene"
+	tags := make([]byte, 8+4+len(vendor)+4)
+	copy(tags, "OpusTags")
+	binary.LittleEndian.PutUint32(tags[8:], uint32(len(vendor)))
+	copy(tags[12:], vendor)
+	if err := ow.writePage(tags, 0, 0); err != nil {
+		return nil, err
+	}
+	return ow, nil
+}
+
+// WritePacket appends one Opus packet lasting samples (at 48 kHz), e.g. 960
+// for a 20 ms frame.
+func (ow *OpusWriter) WritePacket(packet []byte, samples uint64) error {
+	if ow.closed {
+		return errors.New("ogg: writer is closed")
+	}
+	// the previous packet is written now so Close can flag the last one
+	if ow.hasData {
+		if err := ow.writePage(ow.pending, ow.granule, 0); err != nil {
+			return err
+		}
+	}
+	ow.granule += samples
+	ow.pending = append(ow.pending[:0], packet...)
+	ow.hasData = true
+	return nil
+}
+
+// Close writes the last page with the end of stream flag.
+func (ow *OpusWriter) Close() error {
+	if ow.closed {
+		return nil
+	}
+	ow.closed = true
+	return ow.writePage(ow.pending, ow.granule, flagEOS)
+}
+
+func (ow *OpusWriter) writePage(packet []byte, granule uint64, flags byte) error {
+	if len(packet) >= maxSegmentSize*maxSegments {
+		return errors.New("ogg: packet too large")
+	}
+	segments := len(packet)/maxSegmentSize + 1
+	page := make([]byte, pageHeaderSize+segments+len(packet))
+	copy(page, "OggS")
+	page[4] = 0 // version
+	page[5] = flags
+	binary.LittleEndian.PutUint64(page[6:], granule)
+	binary.LittleEndian.PutUint32(page[14:], ow.serial)
+	binary.LittleEndian.PutUint32(page[18:], ow.sequence)
+	page[26] = byte(segments)
+	for i := 0; i < segments-1; i++ {
+		page[pageHeaderSize+i] = maxSegmentSize
+	}
+	page[pageHeaderSize+segments-1] = byte(len(packet) % maxSegmentSize)
+	copy(page[pageHeaderSize+segments:], packet)
+	binary.LittleEndian.PutUint32(page[22:], crc(page))
+
+	ow.sequence++
+	_, err := ow.w.Write(page)
+	return err
+}
+
+// EncodeOpus wraps packets of samplesPerPacket each into an Ogg Opus file.
+func EncodeOpus(packets [][]byte, channels uint8, samplesPerPacket uint64) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := NewOpusWriter(&buf, 48000, channels)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range packets {
+		if err := w.WritePacket(p, samplesPerPacket); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadOpusPackets returns the audio packets of the first logical stream,
+// without the OpusHead and OpusTags headers.
+func ReadOpusPackets(r io.Reader) ([][]byte, error) {
+	var (
+		packets [][]byte
+		current []byte
+		serial  uint32
+		header  = make([]byte, pageHeaderSize)
+		index   int
+	)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if string(header[:4]) != "OggS" {
+			return nil, ErrInvalidPage
+		}
+		pageSerial := binary.LittleEndian.Uint32(header[14:])
+		if index == 0 {
+			serial = pageSerial
+		}
+		lacing := make([]byte, header[26])
+		if _, err := io.ReadFull(r, lacing); err != nil {
+			return nil, err
+		}
+		size := 0
+		for _, l := range lacing {
+			size += int(l)
+		}
+		body := make([]byte, size)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return nil, err
+		}
+		if pageSerial != serial {
+			continue
+		}
+		if header[5]&flagContinued == 0 {
+			current = current[:0]
+		}
+
+		offset := 0
+		for _, l := range lacing {
+			current = append(current, body[offset:offset+int(l)]...)
+			offset += int(l)
+			if l < maxSegmentSize {
+				packet := append([]byte(nil), current...)
+				current = current[:0]
+				switch index {
+				case 0:
+					if !bytes.HasPrefix(packet, []byte("OpusHead")) {
+						return nil, ErrNotOpus
+					}
+				case 1:
+					// OpusTags
+				default:
+					if len(packet) > 0 {
+						packets = append(packets, packet)
+					}
+				}
+				index++
+			}
+		}
+	}
+	if index == 0 {
+		return nil, ErrNotOpus
+	}
+	return packets, nil
+}
+
+var crcTable = func() [256]uint32 {
+	var table [256]uint32
+	for i := range table {
+		r := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if r&0x80000000 != 0 {
+				r = r<<1 ^ 0x04c11db7
+			} else {
+				r <<= 1
+			}
+		}
+		table[i] = r
+	}
+	return table
+}()
+
+// crc is the Ogg checksum of a page whose checksum field is still zero.
+func crc(page []byte) uint32 {
+	var c uint32
+	for _, b := range page {
+		c = c<<8 ^ crcTable[byte(c>>24)^b]
+	}
+	return c
+}
@@ -0,0 +1,82 @@
+package ogg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestCRC(t *testing.T) {
+	// check value of the Ogg CRC (CRC-32/MPEG-2 polynomial, no reflection, init 0)
+	if got := crc([]byte("123456789")); got != 0x89a1897f {
+		t.Fatalf("crc = %#x", got)
+	}
+}
+
+func TestOpusRoundTrip(t *testing.T) {
+	packets := [][]byte{
+		{0xf8, 0x01},
+		bytes.Repeat([]byte{0xaa}, 255), // exactly one full segment
+		bytes.Repeat([]byte{0xbb}, 700), // spans several segments
+		{0xfc},
+	}
+	data, err := EncodeOpus(packets, 2, 960)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadOpusPackets(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(packets) {
+		t.Fatalf("got %d packets, want %d", len(got), len(packets))
+	}
+	for i := range packets {
+		if !bytes.Equal(got[i], packets[i]) {
+			t.Fatalf("packet %d differs", i)
+		}
+	}
+}
+
+func TestOpusWriterPages(t *testing.T) {
+	data, err := EncodeOpus([][]byte{{1}, {2}}, 1, 960)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// walk the pages and check flags, granules and checksums
+	var flags []byte
+	var granules []uint64
+	for len(data) > 0 {
+		segments := int(data[26])
+		size := pageHeaderSize + segments
+		for _, l := range data[pageHeaderSize : pageHeaderSize+segments] {
+			size += int(l)
+		}
+		page := append([]byte(nil), data[:size]...)
+		want := binary.LittleEndian.Uint32(page[22:])
+		binary.LittleEndian.PutUint32(page[22:], 0)
+		if crc(page) != want {
+			t.Fatalf("bad checksum on page %d", len(flags))
+		}
+		flags = append(flags, page[5])
+		granules = append(granules, binary.LittleEndian.Uint64(page[6:]))
+		data = data[size:]
+	}
+
+	if len(flags) != 4 || flags[0] != flagBOS || flags[3] != flagEOS {
+		t.Fatalf("unexpected page flags %v", flags)
+	}
+	if granules[2] != 960 || granules[3] != 1920 {
+		t.Fatalf("unexpected granule positions %v", granules)
+	}
+}
+
+func TestReadOpusPacketsRejectsOtherCodecs(t *testing.T) {
+	var buf bytes.Buffer
+	w := &OpusWriter{w: &buf}
+	w.writePage([]byte("\x01vorbis"), 0, flagBOS)
+	if _, err := ReadOpusPackets(&buf); err != ErrNotOpus {
+		t.Fatalf("expected ErrNotOpus, got %v", err)
+	}
+}
@@ -18,15 +18,15 @@ type BrowserHistory struct {
 }
 
 type GetRecentInput struct {
-	Limit int `json:"limit", description:"The number of history to get"`
+	Limit int `json:"limit" description:"The number of history to get"`
 }
 type GetByKeywordInput struct {
-	Keyword string `json:"keyword", description:"The keyword to get history"`
-	Limit   int    `json:"limit", description:"The number of history to get"`
+	Keyword string `json:"keyword" description:"The keyword to get history"`
+	Limit   int    `json:"limit" description:"The number of history to get"`
 }
 type GetByDomainInput struct {
-	Domain string `json:"domain", description:"The domain to get history"`
-	Limit  int    `json:"limit", description:"The number of history to get"`
+	Domain string `json:"domain" description:"The domain to get history"`
+	Limit  int    `json:"limit" description:"The number of history to get"`
 }
 type BrowserHistoryTool interface {
 	GetRecent(ctx context.Context, input GetRecentInput) ([]BrowserHistory, error)
@@ -0,0 +1,47 @@
+// Package transcode converts audio between formats with the ffmpeg binary,
+// the same dependency the recorder already needs.
+package transcode
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+var ErrFFmpegNotFound = errors.New("ffmpeg not found in PATH")
+
+// ToOggOpus encodes any audio ffmpeg understands (e.g. the MP3 from TTS) as
+// 48 kHz Ogg Opus with 20 ms frames, the format of voice notes and voice chats.
+func ToOggOpus(ctx context.Context, input []byte, channels int) ([]byte, error) {
+	return run(ctx, input,
+		"-c:a", "libopus",
+		"-b:a", "64k",
+		"-ar", "48000",
+		"-ac", strconv.Itoa(channels),
+		"-frame_duration", "20",
+		"-application", "voip",
+		"-f", "ogg",
+	)
+}
+
+func run(ctx context.Context, input []byte, outputArgs ...string) ([]byte, error) {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		return nil, ErrFFmpegNotFound
+	}
+	args := append([]string{"-hide_banner", "-loglevel", "error", "-i", "pipe:0"}, outputArgs...)
+	args = append(args, "pipe:1")
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("ffmpeg: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
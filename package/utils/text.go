@@ -28,3 +28,25 @@ func SplitSentences(text string) []string {
 	}
 	return result
 }
+
+// SplitMessage cuts text into parts of at most limit runes, preferring line
+// breaks and spaces, for chat platforms that cap message length.
+func SplitMessage(text string, limit int) []string {
+	var parts []string
+	runes := []rune(strings.TrimSpace(text))
+	for len(runes) > limit {
+		cut := limit
+		for i := limit; i > limit/2; i-- {
+			if runes[i] == '\n' || runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		parts = append(parts, strings.TrimSpace(string(runes[:cut])))
+		runes = []rune(strings.TrimSpace(string(runes[cut:])))
+	}
+	if len(runes) > 0 {
+		parts = append(parts, string(runes))
+	}
+	return parts
+}
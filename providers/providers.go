@@ -0,0 +1,53 @@
+// Package providers holds the wire providers shared by the desktop app and
+// the command line tools, so both build the same dependency graph.
+package providers
+
+import (
+	"database/sql"
+	"log/slog"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/asr"
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/embedding"
+	"github.com/Mirai3103/Project-Re-ENE/live2d"
+	"github.com/Mirai3103/Project-Re-ENE/llm"
+	"github.com/Mirai3103/Project-Re-ENE/logging"
+	"github.com/Mirai3103/Project-Re-ENE/metering"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+	"github.com/Mirai3103/Project-Re-ENE/telemetry"
+	"github.com/Mirai3103/Project-Re-ENE/tts"
+	"github.com/google/wire"
+)
+
+// CoreSet builds the agent with its store, models and MCP servers. Injectors
+// using it must provide a *slog.Logger and an agent.ToolConfirmer.
+var CoreSet = wire.NewSet(
+	// Infrastructure
+	store.NewSQLiteDB,
+	wire.Bind(new(store.DBTX), new(*sql.DB)),
+	store.New,
+
+	// Config
+	config.NewLive,
+
+	// Agents and Models
+	asr.New,
+	tts.New,
+	llm.NewModels,
+	metering.New,
+	telemetry.New,
+	embedding.New,
+	agent.NewEmbeddingService,
+	agent.NewMCPManager,
+	live2d.NewMapper,
+	agent.NewAgent,
+)
+
+// ProvideLogger returns the configured logger and makes it the default, so
+// the log package and libraries logging through slog end up in the same
+// place.
+func ProvideLogger(l *logging.Logging) *slog.Logger {
+	slog.SetDefault(l.Logger)
+	return l.Logger
+}
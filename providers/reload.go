@@ -0,0 +1,232 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/asr"
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/embedding"
+	"github.com/Mirai3103/Project-Re-ENE/llm"
+	"github.com/Mirai3103/Project-Re-ENE/metering"
+	"github.com/Mirai3103/Project-Re-ENE/tts"
+	"gopkg.in/yaml.v3"
+)
+
+// What happened to a subsystem when a config change was applied.
+const (
+	StatusUnchanged       = "unchanged"
+	StatusReloaded        = "reloaded"         // its client was rebuilt and swapped in
+	StatusUpdated         = "updated"          // it reads the config live, nothing to rebuild
+	StatusRestartRequired = "restart_required" // saved, used after the next start
+	StatusFailed          = "failed"
+	StatusSkipped         = "skipped" // not applied because another subsystem failed
+)
+
+type SubsystemResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ReloadResult reports a config change per subsystem. When Applied is false
+// nothing was saved or swapped.
+type ReloadResult struct {
+	Applied    bool              `json:"applied"`
+	Subsystems []SubsystemResult `json:"subsystems"`
+}
+
+// Reloader applies config changes while the app runs. Changed LLM, TTS, ASR
+// and embedding configs get new clients, which are all built before any is
+// swapped in, so a bad key leaves every subsystem as it was.
+type Reloader struct {
+	cfg              *config.Live
+	agent            *agent.Agent
+	embeddingService *agent.EmbeddingService
+	meter            *metering.Meter
+	logger           *slog.Logger
+
+	mu       sync.Mutex
+	sections []section
+}
+
+// section is one top level part of the config. build is nil for sections
+// that are not rebuilt, live tells those read on every use apart from those
+// read once at startup.
+type section struct {
+	name  string
+	get   func(*config.Config) any
+	build func(ctx context.Context, cfg *config.Config) (swap func(), err error)
+	live  bool
+}
+
+func NewReloader(cfg *config.Live, ag *agent.Agent, embeddingService *agent.EmbeddingService, meter *metering.Meter, logger *slog.Logger) *Reloader {
+	r := &Reloader{cfg: cfg, agent: ag, embeddingService: embeddingService, meter: meter, logger: logger}
+	r.sections = []section{
+		{name: "llm", get: func(c *config.Config) any { return c.LLMConfig }, build: r.buildLLM},
+		{name: "tts", get: func(c *config.Config) any { return c.TTSConfig }, build: r.buildTTS},
+		{name: "asr", get: func(c *config.Config) any { return c.ASRConfig }, build: r.buildASR},
+		{name: "embedding", get: func(c *config.Config) any { return c.EmbeddingConfig }, build: r.buildEmbedding},
+		{name: "character", get: func(c *config.Config) any { return c.CharacterConfig }, live: true},
+		{name: "agent", get: func(c *config.Config) any { return c.AgentConfig }, build: r.buildAgent},
+		{name: "models", get: func(c *config.Config) any { return c.ModelsConfig }, live: true},
+		{name: "metering", get: func(c *config.Config) any { return c.MeteringConfig }, live: true},
+		{name: "telemetry", get: func(c *config.Config) any { return c.TelemetryConfig }},
+		{name: "logger", get: func(c *config.Config) any { return c.LoggerConfig }},
+		{name: "mcp_server", get: func(c *config.Config) any { return c.MCPServerConfig }},
+		{name: "api_server", get: func(c *config.Config) any { return c.APIServerConfig }},
+		{name: "discord", get: func(c *config.Config) any { return c.DiscordConfig }},
+		{name: "telegram", get: func(c *config.Config) any { return c.TelegramConfig }},
+	}
+	return r
+}
+
+// Apply merges patch, a JSON merge patch, into the config, validates it, rebuilds the clients of
+// the changed subsystems, saves the file and swaps the new clients in. A
+// validation or save error is returned as an error, a client that cannot be
+// built is reported in the result.
+func (r *Reloader) Apply(ctx context.Context, patch json.RawMessage) (*ReloadResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx = context.WithoutCancel(ctx) // the new clients outlive the call
+
+	current := r.cfg.Load()
+	next, err := current.Clone()
+	if err != nil {
+		return nil, err
+	}
+	if err := config.MergeConfig(next, patch); err != nil {
+		return nil, fmt.Errorf("merge config: %w", err)
+	}
+	if err := next.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	result := &ReloadResult{}
+	var swaps []func()
+	failed := false
+	for _, s := range r.sections {
+		res := SubsystemResult{Name: s.name, Status: StatusUnchanged}
+		changed, err := sectionChanged(s.get(current), s.get(next))
+		switch {
+		case err != nil:
+			res.Status, res.Error = StatusFailed, err.Error()
+			failed = true
+		case !changed:
+		case s.build != nil:
+			swap, err := s.build(ctx, next)
+			if err != nil {
+				r.logger.Error("Không thể khởi tạo lại", "subsystem", s.name, "error", err)
+				res.Status, res.Error = StatusFailed, err.Error()
+				failed = true
+				break
+			}
+			res.Status = StatusReloaded
+			swaps = append(swaps, swap)
+		case s.live:
+			res.Status = StatusUpdated
+		default:
+			res.Status = StatusRestartRequired
+		}
+		result.Subsystems = append(result.Subsystems, res)
+	}
+
+	if failed {
+		for i, res := range result.Subsystems {
+			if res.Status != StatusFailed && res.Status != StatusUnchanged {
+				result.Subsystems[i].Status = StatusSkipped
+			}
+		}
+		return result, nil
+	}
+
+	if err := next.Save(); err != nil {
+		return nil, err
+	}
+	// published before the swaps, so whatever they start reads the new config
+	r.cfg.Store(next)
+	for _, swap := range swaps {
+		swap()
+	}
+	result.Applied = true
+	r.logger.Info("Config applied", "subsystems", result.Subsystems)
+	return result, nil
+}
+
+// MigrateSecrets moves the plain text secrets of the running config into the
+// secret store, under the same lock as Apply so the two don't interleave.
+func (r *Reloader) MigrateSecrets() (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	next, err := r.cfg.Load().Clone()
+	if err != nil {
+		return 0, err
+	}
+	moved, err := next.MigrateSecrets()
+	if moved > 0 {
+		// the keyring already holds what moved, even when the save failed
+		r.cfg.Store(next)
+	}
+	return moved, err
+}
+
+func (r *Reloader) buildLLM(ctx context.Context, cfg *config.Config) (func(), error) {
+	models, err := llm.NewModels(ctx, cfg, r.meter, r.logger)
+	if err != nil {
+		return nil, err
+	}
+	return func() { r.agent.SetLLM(models) }, nil
+}
+
+func (r *Reloader) buildTTS(_ context.Context, cfg *config.Config) (func(), error) {
+	ttsAgent, err := tts.New(cfg, r.meter, r.logger)
+	if err != nil {
+		return nil, err
+	}
+	return func() { r.agent.SetTTS(ttsAgent) }, nil
+}
+
+func (r *Reloader) buildASR(_ context.Context, cfg *config.Config) (func(), error) {
+	asrAgent, err := asr.New(cfg, r.meter, r.logger)
+	if err != nil {
+		return nil, err
+	}
+	return func() { r.agent.SetASR(asrAgent) }, nil
+}
+
+func (r *Reloader) buildEmbedding(ctx context.Context, cfg *config.Config) (func(), error) {
+	model, err := embedding.New(ctx, cfg, r.meter)
+	if err != nil {
+		return nil, err
+	}
+	return func() { r.embeddingService.SetModel(model) }, nil
+}
+
+// buildAgent rebuilds the local tools and reloads the MCP servers once the
+// new config is published, as both are only read when they are built.
+func (r *Reloader) buildAgent(ctx context.Context, _ *config.Config) (func(), error) {
+	return func() {
+		if err := r.agent.ReloadTools(ctx); err != nil {
+			r.logger.Error("Không thể tải lại công cụ", "error", err)
+		}
+	}, nil
+}
+
+// sectionChanged compares two sections by their YAML, the form they are
+// saved in, so a nil and an empty map count as equal.
+func sectionChanged(before, after any) (bool, error) {
+	a, err := yaml.Marshal(before)
+	if err != nil {
+		return false, err
+	}
+	b, err := yaml.Marshal(after)
+	if err != nil {
+		return false, err
+	}
+	return !bytes.Equal(a, b), nil
+}
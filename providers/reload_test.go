@@ -0,0 +1,230 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mirai3103/Project-Re-ENE/config"
+)
+
+// newTestReloader loads a valid config from a temp file and replaces the
+// client builders, which would otherwise reach the providers.
+func newTestReloader(t *testing.T, buildErr map[string]error) (*Reloader, *config.Live, string, map[string]int) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	def := config.GetDefaultConfig()
+	def.LLMConfig.GeminiConfig.APIKey = "llm-key"
+	def.TTSConfig.ElevenLabsConfig.APIKey = "tts-key"
+	def.TTSConfig.ElevenLabsConfig.ModelID = "eleven_flash_v2_5"
+	def.ModelsConfig.ModelDir = t.TempDir()
+	if err := config.PersistConfig(def, path); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	live := config.NewLive(cfg)
+	r := NewReloader(live, nil, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	swapped := map[string]int{}
+	for i := range r.sections {
+		s := &r.sections[i]
+		if s.build == nil {
+			continue
+		}
+		name := s.name
+		s.build = func(context.Context, *config.Config) (func(), error) {
+			if err := buildErr[name]; err != nil {
+				return nil, err
+			}
+			return func() { swapped[name]++ }, nil
+		}
+	}
+	return r, live, path, swapped
+}
+
+func statuses(result *ReloadResult) map[string]string {
+	m := map[string]string{}
+	for _, s := range result.Subsystems {
+		m[s.Name] = s.Status
+	}
+	return m
+}
+
+func ttsPatch(voice string) json.RawMessage {
+	return json.RawMessage(`{"tts_config": {"eleven_labs_config": {"voice_id": "` + voice + `"}}}`)
+}
+
+func TestApplyReloadsChangedSubsystems(t *testing.T) {
+	r, live, path, swapped := newTestReloader(t, nil)
+	before := live.Load()
+
+	patch := json.RawMessage(`{
+		"tts_config": {"eleven_labs_config": {"voice_id": "new-voice"}},
+		"discord_config": {"token": "discord-token"},
+		"character_config": {"live2d_model_name": "ene"}
+	}`)
+	result, err := r.Apply(context.Background(), patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Applied {
+		t.Fatalf("not applied: %+v", result)
+	}
+	got := statuses(result)
+	want := map[string]string{
+		"tts":       StatusReloaded,
+		"discord":   StatusRestartRequired,
+		"character": StatusUpdated,
+		"llm":       StatusUnchanged,
+		"asr":       StatusUnchanged,
+	}
+	for name, status := range want {
+		if got[name] != status {
+			t.Errorf("%s = %s, want %s", name, got[name], status)
+		}
+	}
+	if swapped["tts"] != 1 || len(swapped) != 1 {
+		t.Errorf("swapped = %v, want only tts", swapped)
+	}
+
+	cfg := live.Load()
+	if cfg.TTSConfig.ElevenLabsConfig.VoiceID != "new-voice" || cfg.TTSConfig.ElevenLabsConfig.APIKey != "tts-key" {
+		t.Errorf("running config not updated: %+v", cfg.TTSConfig.ElevenLabsConfig)
+	}
+	// a reader holding the old config must never see it change under it
+	if before == cfg || before.TTSConfig.ElevenLabsConfig.VoiceID == "new-voice" || before.CharacterConfig.Live2DModelName == "ene" {
+		t.Error("the old config was modified in place")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "new-voice") {
+		t.Error("config file not written")
+	}
+}
+
+func TestApplyFailedBuildChangesNothing(t *testing.T) {
+	r, live, path, swapped := newTestReloader(t, map[string]error{"tts": errors.New("bad key")})
+	cfg := live.Load()
+	before, _ := os.ReadFile(path)
+	oldVoice := cfg.TTSConfig.ElevenLabsConfig.VoiceID
+
+	patch := json.RawMessage(`{
+		"tts_config": {"eleven_labs_config": {"voice_id": "new-voice"}},
+		"embedding_config": {"google": {"model_id": "text-embedding-004"}},
+		"discord_config": {"token": "discord-token"}
+	}`)
+	result, err := r.Apply(context.Background(), patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Applied {
+		t.Fatal("applied despite a failed build")
+	}
+	got := statuses(result)
+	if got["tts"] != StatusFailed || got["embedding"] != StatusSkipped || got["discord"] != StatusSkipped {
+		t.Errorf("statuses = %v", got)
+	}
+	if len(swapped) != 0 {
+		t.Errorf("swapped = %v, want nothing", swapped)
+	}
+	if live.Load() != cfg || cfg.TTSConfig.ElevenLabsConfig.VoiceID != oldVoice || cfg.DiscordConfig.Token != "" {
+		t.Error("running config changed")
+	}
+	after, _ := os.ReadFile(path)
+	if string(before) != string(after) {
+		t.Error("config file changed")
+	}
+}
+
+func TestApplyRejectsInvalidConfig(t *testing.T) {
+	r, live, _, swapped := newTestReloader(t, nil)
+
+	patch := json.RawMessage(`{"tts_config": {"eleven_labs_config": {"voice_id": "new-voice"}}, "llm_config": {"provider": "nope"}}`)
+	if _, err := r.Apply(context.Background(), patch); err == nil {
+		t.Fatal("expected a validation error")
+	}
+	if live.Load().LLMConfig.Provider != "gemini" || len(swapped) != 0 {
+		t.Error("invalid config was applied")
+	}
+}
+
+// TestApplyWhileReading is meant for -race: readers load the config while
+// patches are applied.
+func TestApplyWhileReading(t *testing.T) {
+	r, live, _, _ := newTestReloader(t, nil)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			cfg := live.Load()
+			_, _ = cfg.MeteringConfig.PriceOf("gemini", "gemini-2.5-flash")
+			_ = cfg.AgentConfig.ToolsConfig.Permissions.Resolve("googleSearch")
+		}
+	}()
+	for _, voice := range []string{"a", "b", "c"} {
+		if _, err := r.Apply(context.Background(), ttsPatch(voice)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	<-done
+	if got := live.Load().TTSConfig.ElevenLabsConfig.VoiceID; got != "c" {
+		t.Errorf("voice = %q", got)
+	}
+}
+
+func TestApplyRebuildsToolsOnAgentChange(t *testing.T) {
+	r, live, _, swapped := newTestReloader(t, nil)
+
+	patch := json.RawMessage(`{"agent_config": {"tools_config": {"mcp": {"enable": true}}}}`)
+	result, err := r.Apply(context.Background(), patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := statuses(result)["agent"]; got != StatusReloaded {
+		t.Errorf("agent = %s, want %s", got, StatusReloaded)
+	}
+	if swapped["agent"] != 1 || !live.Load().AgentConfig.ToolsConfig.MCP.Enable {
+		t.Errorf("swapped = %v, tools were not rebuilt", swapped)
+	}
+}
+
+// TestPatchWithSchemaKeys sends a patch the way the settings screen does:
+// JSON keyed by the schema, with the redacted secret sent back.
+func TestPatchWithSchemaKeys(t *testing.T) {
+	r, live, _, _ := newTestReloader(t, nil)
+
+	patch := json.RawMessage(`{"tts_config": {"eleven_labs_config": {"voice_id": "from-form", "api_key": "` + config.RedactedValue + `"}}}`)
+	if _, err := r.Apply(context.Background(), patch); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(live.Load())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		TTS struct {
+			ElevenLabs struct {
+				VoiceID string `json:"voice_id"`
+				APIKey  string `json:"api_key"`
+			} `json:"eleven_labs_config"`
+		} `json:"tts_config"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.TTS.ElevenLabs.VoiceID != "from-form" || got.TTS.ElevenLabs.APIKey != "tts-key" {
+		t.Errorf("eleven_labs_config = %+v", got.TTS.ElevenLabs)
+	}
+}
@@ -0,0 +1,228 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// PassphraseEnv, when set, is the passphrase of the encrypted file. Without
+// it a random key is kept in secrets.key in the user's config folder, away
+// from secrets.enc, so copying or committing the project folder does not
+// hand out the key along with the file it opens.
+const PassphraseEnv = "ENE_SECRETS_PASSPHRASE"
+
+const (
+	secretsFile = "secrets.enc"
+	keyFile     = "secrets.key"
+)
+
+// keyDir is the folder of secrets.key, a variable so tests can move it.
+var keyDir = func() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ene"), nil
+}
+
+// encryptedFile is the layout of secrets.enc. Data is the AES-GCM sealed JSON
+// map of name to secret.
+type encryptedFile struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+type fileBackend struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func newFileBackend(dir string) *fileBackend {
+	return &fileBackend{dir: dir}
+}
+
+func (f *fileBackend) name() string { return KindFile }
+
+func (f *fileBackend) get(name string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	m, _, err := f.load()
+	if err != nil {
+		return "", err
+	}
+	v, ok := m[name]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+
+func (f *fileBackend) set(name, value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	m, salt, err := f.load()
+	if err != nil {
+		return err
+	}
+	m[name] = value
+	return f.save(m, salt)
+}
+
+func (f *fileBackend) delete(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	m, salt, err := f.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := m[name]; !ok {
+		return ErrNotFound
+	}
+	delete(m, name)
+	return f.save(m, salt)
+}
+
+// load decrypts the file. A missing file is an empty store with a new salt.
+func (f *fileBackend) load() (map[string]string, []byte, error) {
+	data, err := os.ReadFile(filepath.Join(f.dir, secretsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, nil, err
+		}
+		return map[string]string{}, salt, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("read secrets file: %w", err)
+	}
+	var file encryptedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, nil, fmt.Errorf("parse secrets file: %w", err)
+	}
+	key, err := f.key(file.Salt)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Earlier versions kept the key next to secrets.enc. Such a file is
+	// opened with that key and sealed again with the user's one below.
+	legacy := filepath.Join(f.dir, keyFile)
+	var moved bool
+	if os.Getenv(PassphraseEnv) == "" {
+		old, err := readKey(legacy)
+		switch {
+		case err == nil:
+			key, moved = old, true
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, nil, err
+		}
+	}
+	gcm, err := newCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	plain, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		return nil, nil, errors.New("decrypt secrets file: wrong key or passphrase")
+	}
+	m := map[string]string{}
+	if err := json.Unmarshal(plain, &m); err != nil {
+		return nil, nil, fmt.Errorf("parse secrets: %w", err)
+	}
+	if moved {
+		if err := f.save(m, file.Salt); err != nil {
+			return nil, nil, err
+		}
+		if err := os.Remove(legacy); err != nil {
+			return nil, nil, fmt.Errorf("remove old key file: %w", err)
+		}
+	}
+	return m, file.Salt, nil
+}
+
+func (f *fileBackend) save(m map[string]string, salt []byte) error {
+	plain, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	key, err := f.key(salt)
+	if err != nil {
+		return err
+	}
+	gcm, err := newCipher(key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	data, err := json.Marshal(encryptedFile{Salt: salt, Nonce: nonce, Data: gcm.Seal(nil, nonce, plain, nil)})
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(f.dir, secretsFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write secrets file: %w", err)
+	}
+	return os.Rename(tmp, filepath.Join(f.dir, secretsFile))
+}
+
+func newCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// key derives the AES key from the passphrase, or reads the key file and
+// creates it on first use.
+func (f *fileBackend) key(salt []byte) ([]byte, error) {
+	if pass := os.Getenv(PassphraseEnv); pass != "" {
+		return scrypt.Key([]byte(pass), salt, 1<<15, 8, 1, 32)
+	}
+	dir, err := keyDir()
+	if err != nil {
+		return nil, fmt.Errorf("no folder for the key file, set %s instead: %w", PassphraseEnv, err)
+	}
+	path := filepath.Join(dir, keyFile)
+	key, err := readKey(path)
+	if !errors.Is(err, os.ErrNotExist) {
+		return key, err
+	}
+	key = make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("create key folder: %w", err)
+	}
+	if err := os.WriteFile(path, key, 0600); err != nil {
+		return nil, fmt.Errorf("write key file: %w", err)
+	}
+	return key, nil
+}
+
+func readKey(path string) ([]byte, error) {
+	key, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read key file: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("%s: want a 32 byte key", path)
+	}
+	return key, nil
+}
@@ -0,0 +1,40 @@
+package secrets
+
+import (
+	"errors"
+
+	"github.com/zalando/go-keyring"
+)
+
+const keyringService = "ene"
+
+type keyringBackend struct{}
+
+// keyringAvailable asks the keyring for a secret that does not exist. Any
+// answer other than "not found" means there is no keyring to talk to.
+func keyringAvailable() bool {
+	_, err := keyring.Get(keyringService, "probe")
+	return err == nil || errors.Is(err, keyring.ErrNotFound)
+}
+
+func (keyringBackend) name() string { return KindKeyring }
+
+func (keyringBackend) get(name string) (string, error) {
+	v, err := keyring.Get(keyringService, name)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	return v, err
+}
+
+func (keyringBackend) set(name, value string) error {
+	return keyring.Set(keyringService, name, value)
+}
+
+func (keyringBackend) delete(name string) error {
+	err := keyring.Delete(keyringService, name)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,139 @@
+// Package secrets resolves the references a config value can hold instead
+// of a plain key:
+//
+//	${GEMINI_API_KEY}        environment variable
+//	file:keys/gemini.txt     file contents, relative to the config folder
+//	keyring:llm.api_key      OS keyring, or the encrypted file when there is none
+//
+// Anything else is a plain value and is returned as is.
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Where a config value comes from.
+const (
+	KindPlain   = "plain"
+	KindEnv     = "env"
+	KindFile    = "file"
+	KindKeyring = "keyring"
+)
+
+const (
+	filePrefix    = "file:"
+	keyringPrefix = "keyring:"
+)
+
+var ErrNotFound = errors.New("secret not found")
+
+var envRef = regexp.MustCompile(`^\$\{([A-Za-z_][A-Za-z0-9_]*)\}$`)
+
+// Kind tells which source a config value points at.
+func Kind(value string) string {
+	switch {
+	case envRef.MatchString(value):
+		return KindEnv
+	case strings.HasPrefix(value, filePrefix):
+		return KindFile
+	case strings.HasPrefix(value, keyringPrefix):
+		return KindKeyring
+	}
+	return KindPlain
+}
+
+// KeyringRef is the reference to the secret saved under name.
+func KeyringRef(name string) string {
+	return keyringPrefix + name
+}
+
+// KeyringName is the name a keyring: reference points at.
+func KeyringName(ref string) string {
+	return strings.TrimPrefix(ref, keyringPrefix)
+}
+
+// backend keeps named secrets, the OS keyring or the encrypted file.
+type backend interface {
+	name() string
+	get(name string) (string, error)
+	set(name, value string) error
+	delete(name string) error
+}
+
+// Store resolves references and keeps keyring: secrets. The OS keyring is
+// used when it answers, otherwise secrets go to an encrypted file in dir,
+// which is the usual case on headless Linux without a Secret Service.
+type Store struct {
+	dir     string
+	backend func() backend
+}
+
+func Open(dir string) *Store {
+	s := &Store{dir: dir}
+	s.backend = sync.OnceValue(func() backend {
+		if keyringAvailable() {
+			return keyringBackend{}
+		}
+		return newFileBackend(dir)
+	})
+	return s
+}
+
+// Backend names where keyring: secrets are kept, "keyring" or "file".
+func (s *Store) Backend() string {
+	return s.backend().name()
+}
+
+// Resolve returns the secret value points at.
+func (s *Store) Resolve(value string) (string, error) {
+	switch Kind(value) {
+	case KindEnv:
+		name := envRef.FindStringSubmatch(value)[1]
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return v, nil
+	case KindFile:
+		path := strings.TrimPrefix(value, filePrefix)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(s.dir, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("read secret file: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	case KindKeyring:
+		return s.Get(KeyringName(value))
+	}
+	return value, nil
+}
+
+func (s *Store) Get(name string) (string, error) {
+	v, err := s.backend().get(name)
+	if err != nil {
+		return "", fmt.Errorf("secret %s: %w", name, err)
+	}
+	return v, nil
+}
+
+func (s *Store) Set(name, value string) error {
+	if err := s.backend().set(name, value); err != nil {
+		return fmt.Errorf("save secret %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s *Store) Delete(name string) error {
+	if err := s.backend().delete(name); err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("delete secret %s: %w", name, err)
+	}
+	return nil
+}
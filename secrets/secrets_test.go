@@ -0,0 +1,159 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestKind(t *testing.T) {
+	for value, want := range map[string]string{
+		"${GEMINI_KEY}":  KindEnv,
+		"$GEMINI_KEY":    KindPlain,
+		"${not valid}":   KindPlain,
+		"file:key.txt":   KindFile,
+		"keyring:llm":    KindKeyring,
+		"AIzaSyPlainKey": KindPlain,
+		"":               KindPlain,
+	} {
+		if got := Kind(value); got != want {
+			t.Errorf("Kind(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	keyring.MockInit()
+	dir := t.TempDir()
+	s := Open(dir)
+
+	t.Setenv("ENE_TEST_KEY", "from-env")
+	if err := os.WriteFile(filepath.Join(dir, "key.txt"), []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("llm", "from-keyring"); err != nil {
+		t.Fatal(err)
+	}
+
+	for value, want := range map[string]string{
+		"${ENE_TEST_KEY}": "from-env",
+		"file:key.txt":    "from-file",
+		"keyring:llm":     "from-keyring",
+		"plain":           "plain",
+	} {
+		got, err := s.Resolve(value)
+		if err != nil {
+			t.Errorf("Resolve(%q): %v", value, err)
+		} else if got != want {
+			t.Errorf("Resolve(%q) = %q, want %q", value, got, want)
+		}
+	}
+
+	if _, err := s.Resolve("${ENE_TEST_UNSET}"); err == nil {
+		t.Error("unset variable resolved")
+	}
+	if _, err := s.Resolve("keyring:missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing keyring secret: %v", err)
+	}
+}
+
+// useKeyDir moves secrets.key to a temporary folder for the test.
+func useKeyDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	old := keyDir
+	keyDir = func() (string, error) { return dir, nil }
+	t.Cleanup(func() { keyDir = old })
+	return dir
+}
+
+func TestFileBackend(t *testing.T) {
+	dir := t.TempDir()
+	keys := useKeyDir(t)
+	f := newFileBackend(dir)
+
+	if _, err := f.get("llm"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("empty store: %v", err)
+	}
+	if err := f.set("llm", "secret-key"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.set("tts", "other-key"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, secretsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-key") {
+		t.Fatal("secret stored in plain text")
+	}
+	if _, err := os.Stat(filepath.Join(dir, keyFile)); !errors.Is(err, os.ErrNotExist) {
+		t.Error("key file written next to the secrets file")
+	}
+	info, err := os.Stat(filepath.Join(keys, keyFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("key file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	// A fresh backend reads what the first one wrote.
+	if got, err := newFileBackend(dir).get("llm"); err != nil || got != "secret-key" {
+		t.Fatalf("get = %q, %v", got, err)
+	}
+	if err := f.delete("llm"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.get("llm"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleted secret: %v", err)
+	}
+}
+
+func TestFileBackendMovesOldKey(t *testing.T) {
+	dir := t.TempDir()
+	keys := useKeyDir(t)
+
+	// Write the file the way earlier versions did, with the key beside it.
+	if err := newFileBackend(dir).set("llm", "secret-key"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(filepath.Join(keys, keyFile), filepath.Join(dir, keyFile)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := newFileBackend(dir).get("llm"); err != nil || got != "secret-key" {
+		t.Fatalf("get = %q, %v", got, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, keyFile)); !errors.Is(err, os.ErrNotExist) {
+		t.Error("old key file was not removed")
+	}
+	if got, err := newFileBackend(dir).get("llm"); err != nil || got != "secret-key" {
+		t.Fatalf("get after the move = %q, %v", got, err)
+	}
+}
+
+func TestFileBackendPassphrase(t *testing.T) {
+	dir := t.TempDir()
+	keys := useKeyDir(t)
+	t.Setenv(PassphraseEnv, "correct horse")
+	if err := newFileBackend(dir).set("llm", "secret-key"); err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range []string{dir, keys} {
+		if _, err := os.Stat(filepath.Join(d, keyFile)); !errors.Is(err, os.ErrNotExist) {
+			t.Error("key file written although a passphrase is set")
+		}
+	}
+
+	t.Setenv(PassphraseEnv, "wrong")
+	if _, err := newFileBackend(dir).get("llm"); err == nil {
+		t.Error("decrypted with the wrong passphrase")
+	}
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/Mirai3103/Project-Re-ENE/agent"
 	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/live2d"
 	"github.com/Mirai3103/Project-Re-ENE/package/audio"
+	"github.com/Mirai3103/Project-Re-ENE/package/lipsync"
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
 
@@ -46,7 +48,7 @@ func (a *AppService) InvokeWithAudio(ctx context.Context, conversationID string,
 	if err != nil {
 		a.logger.Error("Error processing streaming responses", "error", err)
 	}
-	return nil
+	return err
 }
 func (a *AppService) InvokeWithText(ctx context.Context, conversationID string, text string) error {
 	speakChan, err := a.ag.InferSpeak(ctx, &agent.FlowInput{
@@ -64,18 +66,32 @@ func (a *AppService) InvokeWithText(ctx context.Context, conversationID string,
 	if err != nil {
 		a.logger.Error("Error processing streaming responses", "error", err)
 	}
-	return nil
+	return err
 }
 
-func (a *AppService) processStreamingResponses(ctx context.Context, stream chan agent.SpeakResponse, onDone func()) error {
+func (a *AppService) processStreamingResponses(ctx context.Context, stream chan agent.SpeakResponse, onDone func()) (err error) {
 	defer onDone()
 	for speakResponse := range stream {
-		a.logger.Info("Received speak response", "text", speakResponse.Text)
+		if speakResponse.Err != nil {
+			err = speakResponse.Err
+			break
+		}
+		a.logger.Info("Received speak response", "text", speakResponse.Text, "emotion", speakResponse.Emotion)
 
 		// Emit event to frontend
+		if m := speakResponse.Motion; m != nil {
+			application.Get().Event.Emit("live2d:set-motion", SetMotionData{
+				Group:      m.Group,
+				Index:      m.Index,
+				Expression: m.Expression,
+			})
+		}
 		application.Get().Event.Emit("live2d:play-audio", PlayAudioData{
-			Text:   speakResponse.Text,
-			Base64: speakResponse.ToBase64(),
+			Text:    speakResponse.Text,
+			Base64:  speakResponse.ToBase64(),
+			Emotion: speakResponse.Emotion,
+			Motion:  speakResponse.Motion,
+			LipSync: speakResponse.LipSync,
 		})
 	}
 	onDone()
@@ -84,11 +100,25 @@ func (a *AppService) processStreamingResponses(ctx context.Context, stream chan
 		Base64: "",
 		IsDone: true,
 	})
-	return nil
+	return err
 }
 
 type PlayAudioData struct {
 	Text   string
 	Base64 string
 	IsDone bool
+	// Emotion and Motion belong to this chunk, so the frontend can start the
+	// animation together with the audio.
+	Emotion string
+	Motion  *live2d.Motion
+	// LipSync drives the mouth in step with this chunk's audio.
+	LipSync *lipsync.Data
+}
+
+// SetMotionData asks the frontend to play a motion group entry and, when
+// Expression is set, switch the model's expression.
+type SetMotionData struct {
+	Group      string
+	Index      int
+	Expression string
 }
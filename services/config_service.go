@@ -1,28 +1,86 @@
 package services
 
 import (
+	"context"
+	"encoding/json"
 	"log/slog"
 
 	"github.com/Mirai3103/Project-Re-ENE/config"
+	llmConfig "github.com/Mirai3103/Project-Re-ENE/config/llm"
+	"github.com/Mirai3103/Project-Re-ENE/llm"
+	"github.com/Mirai3103/Project-Re-ENE/providers"
 )
 
 type ConfigService struct {
-	cfg    *config.Config
-	logger *slog.Logger
+	cfg      *config.Live
+	reloader *providers.Reloader
+	logger   *slog.Logger
 }
 
-func NewConfigService(cfg *config.Config, logger *slog.Logger) *ConfigService {
-	return &ConfigService{cfg: cfg, logger: logger}
+func NewConfigService(cfg *config.Live, reloader *providers.Reloader, logger *slog.Logger) *ConfigService {
+	return &ConfigService{cfg: cfg, reloader: reloader, logger: logger}
 }
 
-func (h *ConfigService) GetConfig() *config.Config {
-	return h.cfg
+// GetConfig returns the running config with its secrets redacted.
+func (h *ConfigService) GetConfig() (*config.Config, error) {
+	return h.cfg.Load().Redacted()
 }
 
-func (h *ConfigService) PatchConfig(cfg *config.Config) {
-	log := h.logger
-	if err := config.MergeConfig(h.cfg, cfg); err != nil {
-		log.Error("merge config", "error", err)
-		return
+// PatchConfig merges patch, a JSON merge patch of the config, into the
+// running config, saves it and rebuilds the clients whose settings changed.
+// The result tells the UI what happened to each subsystem. Secrets still
+// holding config.RedactedValue are kept.
+func (h *ConfigService) PatchConfig(ctx context.Context, patch json.RawMessage) (*providers.ReloadResult, error) {
+	result, err := h.reloader.Apply(ctx, patch)
+	if err != nil {
+		h.logger.Error("patch config", "error", err)
+		return nil, err
 	}
+	return result, nil
+}
+
+// GetSchema returns the JSON Schema of the config, used by the settings
+// screen to render its forms.
+func (h *ConfigService) GetSchema() (json.RawMessage, error) {
+	return config.Schema()
+}
+
+// GetSecrets tells where each secret comes from and whether it is set.
+func (h *ConfigService) GetSecrets() []config.SecretInfo {
+	return h.cfg.Load().Secrets()
+}
+
+// GetLLMCapabilities tells what the running model accepts, so the UI can
+// hide what it cannot take, like tools for most Claude and Ollama models.
+func (h *ConfigService) GetLLMCapabilities() llmConfig.Capabilities {
+	return h.cfg.Load().LLMConfig.Capabilities()
+}
+
+// MigrateSecrets moves the plain text secrets out of config.yaml into the OS
+// keyring, or the encrypted secrets file when there is none.
+func (h *ConfigService) MigrateSecrets() (int, error) {
+	moved, err := h.reloader.MigrateSecrets()
+	if err != nil {
+		h.logger.Error("migrate secrets", "error", err)
+		return moved, err
+	}
+	h.logger.Info("Moved secrets out of the config file", "count", moved)
+	return moved, nil
+}
+
+// TestLLMConnection merges patch into a copy of the running config, without
+// saving it, and checks that its LLM answers.
+func (h *ConfigService) TestLLMConnection(ctx context.Context, patch json.RawMessage) (*llm.ConnectionResult, error) {
+	next, err := h.cfg.Load().Clone()
+	if err != nil {
+		return nil, err
+	}
+	if err := config.MergeConfig(next, patch); err != nil {
+		return nil, err
+	}
+	result := llm.TestConnection(ctx, next, h.logger)
+	if !result.OK {
+		h.logger.Warn("LLM connection test failed", "provider", next.LLMConfig.Provider, "error", result.Error)
+	}
+	return result, nil
 }
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"github.com/Mirai3103/Project-Re-ENE/logging"
+)
+
+// LogService lets the frontend tail the app log.
+type LogService struct {
+	recent *logging.Recent
+}
+
+func NewLogService(l *logging.Logging) *LogService {
+	return &LogService{recent: l.Recent}
+}
+
+// GetRecentLogs returns up to limit records logged after afterID, already
+// filtered by level and redacted. Pass 0 for the latest records, then the ID
+// of the last record seen to follow the log.
+func (s *LogService) GetRecentLogs(afterID int64, limit int) []logging.Entry {
+	return s.recent.Since(afterID, limit)
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"log/slog"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+// MCPService exposes the MCP server list and their health to the frontend.
+type MCPService struct {
+	manager *agent.MCPManager
+	logger  *slog.Logger
+}
+
+func NewMCPService(manager *agent.MCPManager, logger *slog.Logger) *MCPService {
+	manager.OnStatusChange(func(info agent.MCPServerInfo) {
+		if app := application.Get(); app != nil {
+			app.Event.Emit("mcp:status", info)
+		}
+	})
+	return &MCPService{manager: manager, logger: logger}
+}
+
+func (s *MCPService) ListServers() []agent.MCPServerInfo {
+	return s.manager.ListServers()
+}
+
+// ReloadServers re-reads the MCP config file right away instead of waiting
+// for the file watcher.
+func (s *MCPService) ReloadServers() error {
+	if err := s.manager.Reload(); err != nil {
+		s.logger.Error("reload mcp servers", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *MCPService) RestartServer(name string) error {
+	return s.manager.Restart(name)
+}
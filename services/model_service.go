@@ -2,7 +2,10 @@ package services
 
 import (
 	"archive/zip"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
@@ -11,31 +14,42 @@ import (
 	"strings"
 
 	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/live2d"
+	"github.com/Mirai3103/Project-Re-ENE/providers"
 	"github.com/go-chi/chi/v5"
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
 
 type ModelService struct {
-	cfg *config.Config
+	cfg *config.Live
 	*chi.Mux
-	logger *slog.Logger
+	logger       *slog.Logger
+	motionMapper *live2d.Mapper
+	reloader     *providers.Reloader
 }
 
-func NewModelService(cfg *config.Config, logger *slog.Logger) *ModelService {
+func NewModelService(cfg *config.Live, motionMapper *live2d.Mapper, reloader *providers.Reloader, logger *slog.Logger) *ModelService {
 	router := chi.NewRouter()
 	s := &ModelService{
-		cfg:    cfg,
-		Mux:    router,
-		logger: logger,
+		cfg:          cfg,
+		Mux:          router,
+		logger:       logger,
+		motionMapper: motionMapper,
+		reloader:     reloader,
 	}
 	s.setupRoutes()
 	return s
 }
 
 func (s *ModelService) setupRoutes() {
-	fs := http.FileServer(http.Dir(s.cfg.ModelsConfig.ModelDir))
+	// the model folder is looked up per request, a reload may move it
+	fs := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.FileServer(http.Dir(s.cfg.Load().ModelsConfig.ModelDir)).ServeHTTP(w, r)
+	})
 	s.Handle("/*", http.StripPrefix("/", fs))
 	s.Post("/upload-model", func(w http.ResponseWriter, r *http.Request) {
+		maxUpload, _, _ := s.cfg.Load().ModelsConfig.ImportLimits()
+		r.Body = http.MaxBytesReader(w, r.Body, maxUpload)
 		if err := r.ParseMultipartForm(64 << 20); err != nil { // 64MB max memory buffer
 			http.Error(w, err.Error(), 400)
 			return
@@ -56,6 +70,7 @@ func (s *ModelService) setupRoutes() {
 			http.Error(w, err.Error(), 500)
 			return
 		}
+		defer os.Remove(tmpFile.Name())
 		defer tmpFile.Close()
 		written, err := io.Copy(tmpFile, file)
 		s.logger.Info("Written", "written", written)
@@ -79,69 +94,25 @@ func (h *ModelService) HandleUploadZipModelFromFile(filePath string) error {
 		return err
 	}
 	defer zipFile.Close()
-	return h.handleUploadZipModel(&zipFile.Reader)
+	_, err = h.handleUploadZipModel(&zipFile.Reader)
+	return err
 }
 
-func (h *ModelService) handleUploadZipModel(zipFile *zip.Reader) error {
-	log := h.logger
-	var targetFolder string
-	targetRoot := h.cfg.ModelsConfig.ModelDir
-	for _, f := range zipFile.File {
-		if strings.HasSuffix(strings.ToLower(f.Name), ".model3.json") {
-			// Lấy thư mục cha
-			targetFolder = filepath.Dir(f.Name)
-			log.Info("Found folder:", "folder", targetFolder)
-			break
-		}
-	}
-	if targetFolder == "" {
-		return errors.New("not a valid model zip file")
-	}
-	folderName := filepath.Base(targetFolder)
-	destPath := filepath.Join(targetRoot, folderName)
-	for _, f := range zipFile.File {
-		if !strings.HasPrefix(f.Name, targetFolder) {
-			continue
-		}
-
-		relPath := strings.TrimPrefix(f.Name, targetFolder)
-		relPath = strings.TrimPrefix(relPath, "/")
-		outPath := filepath.Join(destPath, relPath)
-
-		// Thư mục
-		if f.FileInfo().IsDir() {
-			os.MkdirAll(outPath, os.ModePerm)
-			continue
-		}
-
-		// Tạo thư mục chứa file
-		os.MkdirAll(filepath.Dir(outPath), os.ModePerm)
-
-		// Mở file zip
-		rc, err := f.Open()
-		if err != nil {
-			return err
-		}
-
-		// Tạo file dest
-		dst, err := os.OpenFile(outPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, f.Mode())
-		if err != nil {
-			rc.Close()
-			return err
-		}
-
-		_, err = io.Copy(dst, rc)
-
-		dst.Close()
-		rc.Close()
-
-		if err != nil {
-			return err
-		}
+func (h *ModelService) handleUploadZipModel(zipFile *zip.Reader) (string, error) {
+	modelsConfig := h.cfg.Load().ModelsConfig
+	totalBytes, fileBytes, files := modelsConfig.ImportLimits()
+	name, err := live2d.ImportZip(zipFile, modelsConfig.ModelDir, live2d.ImportLimits{
+		MaxTotalBytes: totalBytes,
+		MaxFileBytes:  fileBytes,
+		MaxFiles:      files,
+	})
+	if err != nil {
+		h.logger.Error("import model thất bại", "error", err)
+		return "", err
 	}
-
-	log.Info("Extracted to:", "path", destPath)
-	return nil
+	h.motionMapper.Invalidate(name)
+	h.logger.Info("Imported model", "name", name)
+	return name, nil
 }
 
 func (s *ModelService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -161,16 +132,77 @@ func (s *ModelService) ChooseModel() error {
 }
 
 func (s *ModelService) DeleteModel(modelName string) error {
-	modelPath := filepath.Join(s.cfg.ModelsConfig.ModelDir, modelName)
+	modelPath, err := live2d.ModelDir(s.cfg.Load().ModelsConfig.ModelDir, modelName)
+	if err != nil {
+		return err
+	}
+	if s.isActive(modelName) {
+		return errors.New("cannot delete the active model")
+	}
+	s.motionMapper.Invalidate(modelName)
 	return os.RemoveAll(modelPath)
 }
 
 type Model struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Path     string `json:"path"`
-	Size     int64  `json:"size"`
-	IsActive bool   `json:"is_active"`
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Path      string   `json:"path"`
+	Size      int64    `json:"size"`
+	IsActive  bool     `json:"is_active"`
+	Thumbnail string   `json:"thumbnail"` // URL path, empty when none could be made
+	Version   int      `json:"version"`   // Cubism settings version, 2 for legacy models
+	Valid     bool     `json:"valid"`
+	Problems  []string `json:"problems"`
+}
+
+// GetModelInfo returns the parsed settings of a model and the problems found
+// in its files.
+func (s *ModelService) GetModelInfo(modelName string) (*live2d.ModelInfo, error) {
+	dir, err := live2d.ModelDir(s.cfg.Load().ModelsConfig.ModelDir, modelName)
+	if err != nil {
+		return nil, err
+	}
+	return live2d.Inspect(dir)
+}
+
+// SetActiveModel switches the character to another model. The choice goes
+// through the reloader like any other config change, so it is validated,
+// saved and cannot undo a concurrent patch.
+func (s *ModelService) SetActiveModel(ctx context.Context, modelName string) error {
+	info, err := s.GetModelInfo(modelName)
+	if err != nil {
+		return err
+	}
+	if !info.Valid() {
+		return fmt.Errorf("model %s is broken: %s", modelName, strings.Join(info.Problems, "; "))
+	}
+	if dir, err := live2d.ModelDir(s.cfg.Load().ModelsConfig.ModelDir, modelName); err == nil {
+		if _, err := live2d.MakeThumbnail(dir, info); err != nil {
+			s.logger.Warn("cannot make thumbnail", "model", modelName, "err", err)
+		}
+	}
+	patch, err := json.Marshal(map[string]any{
+		"character_config": map[string]any{"live2d_model_name": modelName},
+	})
+	if err != nil {
+		return err
+	}
+	result, err := s.reloader.Apply(ctx, patch)
+	if err != nil {
+		return err
+	}
+	if !result.Applied {
+		return fmt.Errorf("model %s was not applied: %v", modelName, result.Subsystems)
+	}
+	s.logger.Info("Active model changed", "model", modelName)
+	if app := application.Get(); app != nil {
+		app.Event.Emit("live2d:model-changed", modelName)
+	}
+	return nil
+}
+
+func (s *ModelService) isActive(modelName string) bool {
+	return strings.EqualFold(s.cfg.Load().CharacterConfig.Live2DModelName, modelName)
 }
 
 // UploadModel uploads a model from a file path (for Wails binding)
@@ -194,7 +226,7 @@ func (h *ModelService) UploadModel(filePath string) error {
 
 func (h *ModelService) GetModelList() ([]Model, error) {
 	log := h.logger
-	modelDir := h.cfg.ModelsConfig.ModelDir
+	modelDir := h.cfg.Load().ModelsConfig.ModelDir
 
 	entries, err := os.ReadDir(modelDir)
 	if err != nil {
@@ -204,38 +236,48 @@ func (h *ModelService) GetModelList() ([]Model, error) {
 	var results []Model
 
 	for _, entry := range entries {
-		if !entry.IsDir() {
+		// hidden folders include imports still being unpacked
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
 			continue
 		}
 
 		folderPath := filepath.Join(modelDir, entry.Name())
-		files, err := os.ReadDir(folderPath)
-
+		modelFile, err := live2d.FindModelFile(folderPath)
 		if err != nil {
-			log.Warn("cannot read folder", "folder", folderPath, "err", err)
+			if !errors.Is(err, live2d.ErrNoModelFile) {
+				log.Warn("cannot read folder", "folder", folderPath, "err", err)
+			}
 			continue
 		}
-
-		for _, f := range files {
-			if strings.HasSuffix(strings.ToLower(f.Name()), ".model3.json") {
-				size, _ := getFolderSize(folderPath)
-				results = append(results, Model{
-					ID:       entry.Name(),
-					Name:     entry.Name(),
-					Path:     h.createUrlPath(filepath.Join(folderPath, f.Name())),
-					Size:     size,
-					IsActive: strings.EqualFold(h.cfg.CharacterConfig.Live2DModelName, entry.Name()),
-				})
-				break
-			}
+		model := Model{
+			ID:       entry.Name(),
+			Name:     entry.Name(),
+			Path:     h.createUrlPath(modelFile),
+			IsActive: h.isActive(entry.Name()),
+			Problems: []string{},
+		}
+		info, err := live2d.Inspect(folderPath)
+		if err != nil {
+			model.Problems = append(model.Problems, err.Error())
+			model.Size, _ = getFolderSize(folderPath)
+		} else {
+			model.Size = info.Size
+			model.Version = info.Version
+			model.Valid = info.Valid()
+			model.Problems = info.Problems
+		}
+		// thumbnails are made on import and activation, listing only reads them
+		if thumb := live2d.Thumbnail(folderPath); thumb != "" {
+			model.Thumbnail = h.createUrlPath(filepath.Join(folderPath, thumb))
 		}
+		results = append(results, model)
 	}
 
 	return results, nil
 }
 
 func (h *ModelService) createUrlPath(fullPath string) string {
-	base := filepath.Clean(h.cfg.ModelsConfig.ModelDir)
+	base := filepath.Clean(h.cfg.Load().ModelsConfig.ModelDir)
 	target := filepath.Clean(fullPath)
 
 	// Tạo đường dẫn tương đối
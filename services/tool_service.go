@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/package/utils"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+var (
+	ErrConfirmationNotFound = errors.New("tool confirmation not found")
+	ErrNoConfirmationUI     = fmt.Errorf("no window to confirm the tool call: %w", agent.ErrNoConfirmer)
+)
+
+// ToolService forwards "ask" tool calls to the frontend and exposes the audit log.
+type ToolService struct {
+	logger  *slog.Logger
+	store   *store.Queries
+	mu      sync.Mutex
+	pending map[string]pendingConfirmation
+}
+
+type pendingConfirmation struct {
+	request agent.ToolConfirmRequest
+	answer  chan bool
+}
+
+func NewToolService(logger *slog.Logger, store *store.Queries) *ToolService {
+	return &ToolService{
+		logger:  logger,
+		store:   store,
+		pending: make(map[string]pendingConfirmation),
+	}
+}
+
+// Confirm implements agent.ToolConfirmer. It emits a "tool:confirm" event and
+// blocks until AnswerToolConfirmation is called or ctx is done.
+func (s *ToolService) Confirm(ctx context.Context, req agent.ToolConfirmRequest) (bool, error) {
+	if application.Get() == nil {
+		// headless mode, nobody can answer
+		return false, ErrNoConfirmationUI
+	}
+	answer := make(chan bool, 1)
+	s.mu.Lock()
+	s.pending[req.ID] = pendingConfirmation{request: req, answer: answer}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.pending, req.ID)
+		s.mu.Unlock()
+		application.Get().Event.Emit("tool:confirm-closed", req.ID)
+	}()
+
+	s.logger.Info("Waiting for tool confirmation", "tool", req.ToolName, "id", req.ID)
+	application.Get().Event.Emit("tool:confirm", req)
+
+	select {
+	case approved := <-answer:
+		return approved, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// AnswerToolConfirmation is called by the frontend with the user's decision.
+func (s *ToolService) AnswerToolConfirmation(id string, approved bool) error {
+	s.mu.Lock()
+	p, ok := s.pending[id]
+	s.mu.Unlock()
+	if !ok {
+		return ErrConfirmationNotFound
+	}
+	select {
+	case p.answer <- approved:
+	default:
+		// already answered
+	}
+	return nil
+}
+
+// GetPendingConfirmations returns requests still waiting for an answer, so the
+// UI can restore its dialogs after a reload.
+func (s *ToolService) GetPendingConfirmations() []agent.ToolConfirmRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := make([]agent.ToolConfirmRequest, 0, len(s.pending))
+	for _, p := range s.pending {
+		requests = append(requests, p.request)
+	}
+	return requests
+}
+
+func (s *ToolService) GetToolAudits(ctx context.Context, limit int64) ([]store.ToolAudit, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	return s.store.ListToolAudits(ctx, limit)
+}
+
+func (s *ToolService) GetConversationToolAudits(ctx context.Context, conversationID string) ([]store.ToolAudit, error) {
+	return s.store.ListConversationToolAudits(ctx, utils.Ptr(conversationID))
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Mirai3103/Project-Re-ENE/metering"
+)
+
+// UsageService tells the frontend how much each LLM task used and what the
+// paid services cost.
+type UsageService struct {
+	meter  *metering.Meter
+	logger *slog.Logger
+}
+
+func NewUsageService(meter *metering.Meter, logger *slog.Logger) *UsageService {
+	return &UsageService{meter: meter, logger: logger}
+}
+
+// GetTaskUsage returns the usage and cost of the chat, extraction and
+// summary tasks of the last days per provider and model.
+func (s *UsageService) GetTaskUsage(ctx context.Context, days int) ([]metering.TaskUsage, error) {
+	usage, err := s.meter.ByTask(ctx, days)
+	if err != nil {
+		s.logger.Error("get task usage", "error", err)
+		return nil, err
+	}
+	return usage, nil
+}
+
+// GetDailyUsage returns the usage and cost of the last days per day and
+// kind of service.
+func (s *UsageService) GetDailyUsage(ctx context.Context, days int) ([]metering.DailyUsage, error) {
+	usage, err := s.meter.Daily(ctx, days)
+	if err != nil {
+		s.logger.Error("get daily usage", "error", err)
+		return nil, err
+	}
+	return usage, nil
+}
+
+// GetConversationUsage returns the most expensive conversations of the last
+// days.
+func (s *UsageService) GetConversationUsage(ctx context.Context, days, limit int) ([]metering.ConversationUsage, error) {
+	usage, err := s.meter.ByConversation(ctx, days, limit)
+	if err != nil {
+		s.logger.Error("get conversation usage", "error", err)
+		return nil, err
+	}
+	return usage, nil
+}
+
+// GetProviderUsage returns the usage and cost of the last days per provider
+// and model.
+func (s *UsageService) GetProviderUsage(ctx context.Context, days int) ([]metering.ProviderUsage, error) {
+	usage, err := s.meter.ByProvider(ctx, days)
+	if err != nil {
+		s.logger.Error("get provider usage", "error", err)
+		return nil, err
+	}
+	return usage, nil
+}
+
+// GetBudget tells how much of the monthly budget is spent.
+func (s *UsageService) GetBudget(ctx context.Context) (*metering.BudgetStatus, error) {
+	status, err := s.meter.Budget(ctx)
+	if err != nil {
+		s.logger.Error("get budget", "error", err)
+		return nil, err
+	}
+	return status, nil
+}
@@ -96,6 +96,7 @@ const listConversationMessages = `-- name: ListConversationMessages :many
 SELECT id, conversation_id, role, content, created_at
 FROM conversation_messages
 WHERE conversation_id = ?
+ORDER BY created_at ASC
 `
 
 func (q *Queries) ListConversationMessages(ctx context.Context, conversationID *string) ([]ConversationMessage, error) {
@@ -127,6 +128,45 @@ func (q *Queries) ListConversationMessages(ctx context.Context, conversationID *
 	return items, nil
 }
 
+const listConversations = `-- name: ListConversations :many
+SELECT id, title, max_window_size, character_id, user_id, current_summary, created_at, updated_at
+FROM conversations
+ORDER BY updated_at DESC
+LIMIT ?
+`
+
+func (q *Queries) ListConversations(ctx context.Context, limit int64) ([]Conversation, error) {
+	rows, err := q.db.QueryContext(ctx, listConversations, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Conversation
+	for rows.Next() {
+		var i Conversation
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.MaxWindowSize,
+			&i.CharacterID,
+			&i.UserID,
+			&i.CurrentSummary,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listRecentMessages = `-- name: ListRecentMessages :many
 SELECT id, conversation_id, role, content, created_at
 FROM conversation_messages
@@ -141,3 +141,53 @@ func (q *Queries) GetMemoriesByIDs(ctx context.Context, ids []string) ([]Memory,
 	}
 	return items, nil
 }
+
+const listMemories = `-- name: ListMemories :many
+SELECT id, user_id, character_id, content, embedding, importance, confidence, source, tags, access_count, decay_score, last_accessed_at, created_at, updated_at
+FROM memories
+ORDER BY created_at DESC
+LIMIT ? OFFSET ?
+`
+
+type ListMemoriesParams struct {
+	Limit  int64
+	Offset int64
+}
+
+func (q *Queries) ListMemories(ctx context.Context, arg ListMemoriesParams) ([]Memory, error) {
+	rows, err := q.db.QueryContext(ctx, listMemories, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Memory
+	for rows.Next() {
+		var i Memory
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CharacterID,
+			&i.Content,
+			&i.Embedding,
+			&i.Importance,
+			&i.Confidence,
+			&i.Source,
+			&i.Tags,
+			&i.AccessCount,
+			&i.DecayScore,
+			&i.LastAccessedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,12 @@
+create table if not exists tool_audits (
+    id text primary key,
+    conversation_id text ,
+    tool_name text ,
+    permission text ,
+    decision text ,
+    arguments blob ,
+    result blob ,
+    error text ,
+    duration_ms integer ,
+    created_at timestamp  default current_timestamp
+);
@@ -0,0 +1 @@
+drop table if exists usage_events;
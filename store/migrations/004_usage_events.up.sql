@@ -0,0 +1,18 @@
+create table if not exists usage_events (
+    id integer primary key autoincrement,
+    kind text not null,
+    task text ,
+    provider text not null,
+    model text not null,
+    conversation_id text ,
+    input_tokens integer not null default 0,
+    output_tokens integer not null default 0,
+    characters integer not null default 0,
+    audio_seconds real not null default 0,
+    latency_ms integer not null default 0,
+    cost real not null default 0,
+    error text ,
+    created_at timestamp not null default current_timestamp
+);
+create index if not exists usage_events_created_at on usage_events (created_at);
+create index if not exists usage_events_conversation_id on usage_events (conversation_id);
@@ -63,6 +63,36 @@ type Memory struct {
 	UpdatedAt      *time.Time
 }
 
+type ToolAudit struct {
+	ID             string
+	ConversationID *string
+	ToolName       *string
+	Permission     *string
+	Decision       *string
+	Arguments      []byte
+	Result         []byte
+	Error          *string
+	DurationMs     *int64
+	CreatedAt      *time.Time
+}
+
+type UsageEvent struct {
+	ID             int64
+	Kind           string
+	Task           *string
+	Provider       string
+	Model          string
+	ConversationID *string
+	InputTokens    int64
+	OutputTokens   int64
+	Characters     int64
+	AudioSeconds   float64
+	LatencyMs      int64
+	Cost           float64
+	Error          *string
+	CreatedAt      time.Time
+}
+
 type User struct {
 	ID        string
 	Name      *string
@@ -28,4 +28,10 @@ SELECT *
 FROM conversation_messages
 WHERE conversation_id = ?
 ORDER BY created_at ASC
-LIMIT ?;
\ No newline at end of file
+LIMIT ?;
+
+-- name: ListConversations :many
+SELECT *
+FROM conversations
+ORDER BY updated_at DESC
+LIMIT ?;
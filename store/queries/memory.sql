@@ -16,4 +16,10 @@ WHERE id IN (sqlc.slice('ids'));
 
 -- name: DeleteMemory :exec
 DELETE FROM memories
-WHERE id = ?;
\ No newline at end of file
+WHERE id = ?;
+
+-- name: ListMemories :many
+SELECT *
+FROM memories
+ORDER BY created_at DESC
+LIMIT ? OFFSET ?;
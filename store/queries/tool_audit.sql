@@ -0,0 +1,15 @@
+-- name: CreateToolAudit :exec
+INSERT INTO tool_audits (id, conversation_id, tool_name, permission, decision, arguments, result, error, duration_ms)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListToolAudits :many
+SELECT *
+FROM tool_audits
+ORDER BY created_at DESC
+LIMIT ?;
+
+-- name: ListConversationToolAudits :many
+SELECT *
+FROM tool_audits
+WHERE conversation_id = ?
+ORDER BY created_at ASC;
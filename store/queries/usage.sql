@@ -0,0 +1,75 @@
+-- name: CreateUsageEvent :exec
+INSERT INTO usage_events (kind, task, provider, model, conversation_id, input_tokens, output_tokens, characters, audio_seconds, latency_ms, cost, error, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: SumUsageCostSince :one
+SELECT CAST(COALESCE(SUM(cost), 0) AS REAL) AS cost
+FROM usage_events
+WHERE created_at >= ?;
+
+-- name: ListDailyUsage :many
+-- created_at is written in UTC, its first 19 characters are what date() reads.
+SELECT CAST(date(substr(created_at, 1, 19), 'localtime') AS TEXT) AS day,
+       kind,
+       COUNT(*) AS calls,
+       COUNT(error) AS errors,
+       CAST(SUM(input_tokens) AS INTEGER) AS input_tokens,
+       CAST(SUM(output_tokens) AS INTEGER) AS output_tokens,
+       CAST(SUM(characters) AS INTEGER) AS characters,
+       CAST(SUM(audio_seconds) AS REAL) AS audio_seconds,
+       CAST(AVG(latency_ms) AS REAL) AS avg_latency_ms,
+       CAST(SUM(cost) AS REAL) AS cost
+FROM usage_events
+WHERE created_at >= ?
+GROUP BY day, kind
+ORDER BY day, kind;
+
+-- name: ListConversationUsage :many
+SELECT CAST(conversation_id AS TEXT) AS conversation_id,
+       COUNT(*) AS calls,
+       COUNT(error) AS errors,
+       CAST(SUM(input_tokens) AS INTEGER) AS input_tokens,
+       CAST(SUM(output_tokens) AS INTEGER) AS output_tokens,
+       CAST(SUM(characters) AS INTEGER) AS characters,
+       CAST(SUM(audio_seconds) AS REAL) AS audio_seconds,
+       CAST(AVG(latency_ms) AS REAL) AS avg_latency_ms,
+       CAST(SUM(cost) AS REAL) AS cost
+FROM usage_events
+WHERE created_at >= ? AND conversation_id IS NOT NULL
+GROUP BY conversation_id
+ORDER BY cost DESC
+LIMIT ?;
+
+-- name: ListProviderUsage :many
+SELECT kind,
+       provider,
+       model,
+       COUNT(*) AS calls,
+       COUNT(error) AS errors,
+       CAST(SUM(input_tokens) AS INTEGER) AS input_tokens,
+       CAST(SUM(output_tokens) AS INTEGER) AS output_tokens,
+       CAST(SUM(characters) AS INTEGER) AS characters,
+       CAST(SUM(audio_seconds) AS REAL) AS audio_seconds,
+       CAST(AVG(latency_ms) AS REAL) AS avg_latency_ms,
+       CAST(SUM(cost) AS REAL) AS cost
+FROM usage_events
+WHERE created_at >= ?
+GROUP BY kind, provider, model
+ORDER BY cost DESC;
+
+-- name: ListTaskUsage :many
+SELECT CAST(task AS TEXT) AS task,
+       provider,
+       model,
+       COUNT(*) AS calls,
+       COUNT(error) AS errors,
+       CAST(SUM(input_tokens) AS INTEGER) AS input_tokens,
+       CAST(SUM(output_tokens) AS INTEGER) AS output_tokens,
+       CAST(SUM(characters) AS INTEGER) AS characters,
+       CAST(SUM(audio_seconds) AS REAL) AS audio_seconds,
+       CAST(AVG(latency_ms) AS REAL) AS avg_latency_ms,
+       CAST(SUM(cost) AS REAL) AS cost
+FROM usage_events
+WHERE created_at >= ? AND task IS NOT NULL
+GROUP BY task, provider, model
+ORDER BY task, provider, model;
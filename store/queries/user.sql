@@ -13,4 +13,10 @@ LIMIT ?;
 
 -- name: AddUserFact :exec
 INSERT INTO user_facts (id, user_id, name, value, type)
-VALUES (?, ?, ?, ?, ?);
\ No newline at end of file
+VALUES (?, ?, ?, ?, ?);
+
+-- name: UpsertUser :exec
+INSERT INTO users (id, name)
+VALUES (?, ?)
+ON CONFLICT (id) DO UPDATE SET name       = COALESCE(excluded.name, users.name),
+                               updated_at = CURRENT_TIMESTAMP;
@@ -96,3 +96,20 @@ func (q *Queries) GetUserFacts(ctx context.Context, arg GetUserFactsParams) ([]U
 	}
 	return items, nil
 }
+
+const upsertUser = `-- name: UpsertUser :exec
+INSERT INTO users (id, name)
+VALUES (?, ?)
+ON CONFLICT (id) DO UPDATE SET name       = COALESCE(excluded.name, users.name),
+                               updated_at = CURRENT_TIMESTAMP
+`
+
+type UpsertUserParams struct {
+	ID   string
+	Name *string
+}
+
+func (q *Queries) UpsertUser(ctx context.Context, arg UpsertUserParams) error {
+	_, err := q.db.ExecContext(ctx, upsertUser, arg.ID, arg.Name)
+	return err
+}
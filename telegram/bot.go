@@ -0,0 +1,250 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/metering"
+	"github.com/Mirai3103/Project-Re-ENE/package/transcode"
+	"github.com/Mirai3103/Project-Re-ENE/package/utils"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+)
+
+// maxMessageLength is Telegram's limit for one text message.
+const maxMessageLength = 4096
+
+// retryDelay is how long polling waits after a failed getUpdates.
+const retryDelay = 3 * time.Second
+
+// Agent is what the bot needs from agent.Agent.
+type Agent interface {
+	Chat(ctx context.Context, input *agent.FlowInput) (string, error)
+	Speak(ctx context.Context, text string) ([]byte, error)
+}
+
+// UserStore keeps a users row for every Telegram user Ene talks to.
+type UserStore interface {
+	UpsertUser(ctx context.Context, arg store.UpsertUserParams) error
+}
+
+// VoiceEncoder turns TTS audio into an OGG/Opus voice note.
+type VoiceEncoder func(ctx context.Context, audio []byte) ([]byte, error)
+
+// Bot answers text messages and voice notes from allowed users, with text
+// and, when enabled, a voice message.
+type Bot struct {
+	cfg    *config.TelegramConfig
+	client *Client
+	agent  Agent
+	users  UserStore
+	encode VoiceEncoder
+	logger *slog.Logger
+
+	chatLocks sync.Map // chat ID -> *sync.Mutex, keeps turns of a chat in order
+}
+
+func New(cfg *config.Config, ag *agent.Agent, store *store.Queries, logger *slog.Logger) *Bot {
+	client := NewClient(cfg.TelegramConfig.APIURL, cfg.TelegramConfig.Token, nil)
+	return newBot(&cfg.TelegramConfig, client, ag, store, EncodeVoice, logger)
+}
+
+func newBot(cfg *config.TelegramConfig, client *Client, ag Agent, users UserStore, encode VoiceEncoder, logger *slog.Logger) *Bot {
+	return &Bot{
+		cfg:    cfg,
+		client: client,
+		agent:  ag,
+		users:  users,
+		encode: encode,
+		logger: logger.With("component", "telegram"),
+	}
+}
+
+// Start checks the token and polls for updates until ctx is done.
+func (b *Bot) Start(ctx context.Context) error {
+	me, err := b.client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("telegram getMe: %w", err)
+	}
+	if len(b.cfg.AllowedUsers) == 0 {
+		b.logger.Warn("Telegram allowed_users is empty, the bot will not answer anyone")
+	}
+	b.logger.Info("Telegram bot connected", "username", me.Username)
+	go b.poll(ctx)
+	return nil
+}
+
+func (b *Bot) poll(ctx context.Context) {
+	var offset int64
+	for ctx.Err() == nil {
+		updates, err := b.client.GetUpdates(ctx, offset, b.cfg.PollTimeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			b.logger.Error("Telegram getUpdates failed", "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryDelay):
+			}
+			continue
+		}
+		for _, u := range updates {
+			offset = u.UpdateID + 1
+			if u.Message != nil {
+				go b.handleMessage(ctx, u.Message)
+			}
+		}
+	}
+}
+
+func (b *Bot) handleMessage(ctx context.Context, m *Message) {
+	if m.From == nil || m.From.IsBot {
+		return
+	}
+	if !b.cfg.IsAllowed(m.From.ID) {
+		b.logger.Warn("Telegram user is not allowed", "user_id", m.From.ID, "username", m.From.Username)
+		return
+	}
+
+	input := &agent.FlowInput{
+		ConversationID: conversationID(m.Chat.ID),
+		CharacterID:    b.cfg.CharacterID,
+	}
+	switch {
+	case m.Voice != nil:
+		audio, err := b.downloadVoice(ctx, m.Voice)
+		if err != nil {
+			b.logger.Error("Cannot download voice note", "chat_id", m.Chat.ID, "error", err)
+			return
+		}
+		input.Audio = audio
+	case strings.HasPrefix(m.Text, "/"):
+		b.handleCommand(ctx, m)
+		return
+	case strings.TrimSpace(m.Text) != "":
+		input.Text = m.Text
+	default:
+		return
+	}
+
+	userID, err := b.ensureUser(ctx, m.From)
+	if err != nil {
+		b.logger.Error("Cannot save telegram user", "user_id", m.From.ID, "error", err)
+		return
+	}
+	input.UserID = userID
+
+	lock := b.chatLock(m.Chat.ID)
+	lock.Lock()
+	defer lock.Unlock()
+	b.reply(ctx, m, input)
+}
+
+func (b *Bot) handleCommand(ctx context.Context, m *Message) {
+	command, _, _ := strings.Cut(strings.TrimPrefix(m.Text, "/"), " ")
+	command, _, _ = strings.Cut(command, "@") // /start@EneBot in groups
+	switch command {
+	case "start":
+		if err := b.client.SendMessage(ctx, m.Chat.ID, "Chào! Nhắn tin hoặc gửi voice cho mình nhé.", 0); err != nil {
+			b.logger.Error("Cannot send telegram message", "chat_id", m.Chat.ID, "error", err)
+		}
+	}
+}
+
+// errorReply is sent when a turn fails, so the chat is not left waiting.
+const errorReply = "Xin lỗi, mình đang gặp chút trục trặc. Bạn thử lại sau nhé."
+
+// reply runs one turn and sends the answer as text, then as one voice note
+// when voice replies are enabled.
+func (b *Bot) reply(ctx context.Context, m *Message, input *agent.FlowInput) {
+	b.client.SendChatAction(ctx, m.Chat.ID, "typing")
+
+	text, err := b.agent.Chat(ctx, input)
+	if err != nil {
+		b.logger.Error("Telegram turn failed", "chat_id", m.Chat.ID, "error", err)
+		if err := b.client.SendMessage(ctx, m.Chat.ID, errorReply, m.MessageID); err != nil {
+			b.logger.Error("Cannot send telegram message", "chat_id", m.Chat.ID, "error", err)
+		}
+		return
+	}
+	text = strings.TrimSpace(text)
+	for i, part := range utils.SplitMessage(text, maxMessageLength) {
+		var replyTo int64
+		if i == 0 {
+			replyTo = m.MessageID
+		}
+		if err := b.client.SendMessage(ctx, m.Chat.ID, part, replyTo); err != nil {
+			b.logger.Error("Cannot send telegram message", "chat_id", m.Chat.ID, "error", err)
+			return
+		}
+	}
+
+	if !b.cfg.VoiceReplies || text == "" {
+		return
+	}
+	b.client.SendChatAction(ctx, m.Chat.ID, "record_voice")
+	audio, err := b.agent.Speak(metering.WithConversation(ctx, input.ConversationID), text)
+	if err != nil {
+		b.logger.Warn("Cannot synthesize voice reply", "chat_id", m.Chat.ID, "error", err)
+		return
+	}
+	if len(audio) == 0 {
+		return
+	}
+	voice, err := b.encode(ctx, audio)
+	if err != nil {
+		b.logger.Warn("Cannot encode voice reply", "error", err)
+		return
+	}
+	if err := b.client.SendVoice(ctx, m.Chat.ID, voice, m.MessageID); err != nil {
+		b.logger.Error("Cannot send telegram voice", "chat_id", m.Chat.ID, "error", err)
+	}
+}
+
+// downloadVoice returns the OGG/Opus bytes of a voice note, which the ASR
+// path accepts as they are.
+func (b *Bot) downloadVoice(ctx context.Context, voice *Voice) ([]byte, error) {
+	file, err := b.client.GetFile(ctx, voice.FileID)
+	if err != nil {
+		return nil, err
+	}
+	if file.FilePath == "" {
+		return nil, errors.New("telegram returned no file path")
+	}
+	return b.client.DownloadFile(ctx, file.FilePath)
+}
+
+// ensureUser maps a Telegram user to a users row and keeps its name fresh.
+func (b *Bot) ensureUser(ctx context.Context, u *User) (string, error) {
+	if userID, ok := b.cfg.Users[u.ID]; ok {
+		return userID, nil
+	}
+	params := store.UpsertUserParams{ID: "telegram:" + strconv.FormatInt(u.ID, 10)}
+	if name := u.DisplayName(); name != "" {
+		params.Name = utils.Ptr(name)
+	}
+	return params.ID, b.users.UpsertUser(ctx, params)
+}
+
+func (b *Bot) chatLock(chatID int64) *sync.Mutex {
+	lock, _ := b.chatLocks.LoadOrStore(chatID, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+func conversationID(chatID int64) string {
+	return "telegram:" + strconv.FormatInt(chatID, 10)
+}
+
+// EncodeVoice converts TTS audio to a mono OGG/Opus voice note.
+func EncodeVoice(ctx context.Context, audio []byte) ([]byte, error) {
+	return transcode.ToOggOpus(ctx, audio, 1)
+}
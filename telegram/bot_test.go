@@ -0,0 +1,371 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/store"
+)
+
+const testToken = "123:secret"
+
+type sentMessage struct {
+	ChatID  int64
+	Text    string
+	ReplyTo int64
+}
+
+type sentVoice struct {
+	ChatID int64
+	Voice  []byte
+}
+
+// fakeBotAPI is a local stand-in for api.telegram.org.
+type fakeBotAPI struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	updates  []Update
+	messages []sentMessage
+	voices   []sentVoice
+	actions  []string
+	files    map[string][]byte // file path -> content
+	sent     chan struct{}
+}
+
+func newFakeBotAPI(t *testing.T) *fakeBotAPI {
+	t.Helper()
+	api := &fakeBotAPI{files: map[string][]byte{}, sent: make(chan struct{}, 16)}
+	mux := http.NewServeMux()
+	prefix := "/bot" + testToken + "/"
+	mux.HandleFunc(prefix+"getMe", func(w http.ResponseWriter, r *http.Request) {
+		api.ok(w, User{ID: 1, IsBot: true, FirstName: "Ene", Username: "ene_bot"})
+	})
+	mux.HandleFunc(prefix+"getUpdates", func(w http.ResponseWriter, r *http.Request) {
+		var params struct{ Offset int64 }
+		json.NewDecoder(r.Body).Decode(&params)
+		api.mu.Lock()
+		var pending []Update
+		for _, u := range api.updates {
+			if u.UpdateID >= params.Offset {
+				pending = append(pending, u)
+			}
+		}
+		api.mu.Unlock()
+		if len(pending) == 0 {
+			time.Sleep(20 * time.Millisecond)
+		}
+		api.ok(w, pending)
+	})
+	mux.HandleFunc(prefix+"sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		var params struct {
+			ChatID          int64  `json:"chat_id"`
+			Text            string `json:"text"`
+			ReplyParameters struct {
+				MessageID int64 `json:"message_id"`
+			} `json:"reply_parameters"`
+		}
+		json.NewDecoder(r.Body).Decode(&params)
+		api.mu.Lock()
+		api.messages = append(api.messages, sentMessage{params.ChatID, params.Text, params.ReplyParameters.MessageID})
+		api.mu.Unlock()
+		api.ok(w, Message{MessageID: 100})
+		api.sent <- struct{}{}
+	})
+	mux.HandleFunc(prefix+"sendChatAction", func(w http.ResponseWriter, r *http.Request) {
+		var params struct{ Action string }
+		json.NewDecoder(r.Body).Decode(&params)
+		api.mu.Lock()
+		api.actions = append(api.actions, params.Action)
+		api.mu.Unlock()
+		api.ok(w, true)
+	})
+	mux.HandleFunc(prefix+"sendVoice", func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("voice")
+		if err != nil {
+			api.fail(w, 400, "Bad Request: voice is missing")
+			return
+		}
+		data, _ := io.ReadAll(file)
+		var chatID int64
+		json.Unmarshal([]byte(r.FormValue("chat_id")), &chatID)
+		api.mu.Lock()
+		api.voices = append(api.voices, sentVoice{chatID, data})
+		api.mu.Unlock()
+		api.ok(w, Message{MessageID: 101})
+		api.sent <- struct{}{}
+	})
+	mux.HandleFunc(prefix+"getFile", func(w http.ResponseWriter, r *http.Request) {
+		var params struct {
+			FileID string `json:"file_id"`
+		}
+		json.NewDecoder(r.Body).Decode(&params)
+		api.ok(w, File{FileID: params.FileID, FilePath: "voice/" + params.FileID + ".oga"})
+	})
+	mux.HandleFunc("/file/bot"+testToken+"/", func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/file/bot"+testToken+"/")
+		api.mu.Lock()
+		data, ok := api.files[path]
+		api.mu.Unlock()
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		api.fail(w, 401, "Unauthorized")
+	})
+	api.Server = httptest.NewServer(mux)
+	t.Cleanup(api.Close)
+	return api
+}
+
+func (api *fakeBotAPI) ok(w http.ResponseWriter, result any) {
+	data, _ := json.Marshal(result)
+	json.NewEncoder(w).Encode(apiResponse{OK: true, Result: data})
+}
+
+func (api *fakeBotAPI) fail(w http.ResponseWriter, code int, description string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(apiResponse{OK: false, ErrorCode: code, Description: description})
+}
+
+func (api *fakeBotAPI) waitSent(t *testing.T, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case <-api.sent:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("only %d of %d sends arrived", i, n)
+		}
+	}
+}
+
+type fakeAgent struct {
+	mu     sync.Mutex
+	inputs []*agent.FlowInput
+	spoken []string
+	err    error
+}
+
+func (a *fakeAgent) Chat(ctx context.Context, input *agent.FlowInput) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.inputs = append(a.inputs, input)
+	if a.err != nil {
+		return "", a.err
+	}
+	return "Chào bạn. Hôm nay vui ghê!", nil
+}
+
+func (a *fakeAgent) Speak(ctx context.Context, text string) ([]byte, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.spoken = append(a.spoken, text)
+	return []byte("mp3:" + text), nil
+}
+
+func (a *fakeAgent) lastInput() *agent.FlowInput {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.inputs) == 0 {
+		return nil
+	}
+	return a.inputs[len(a.inputs)-1]
+}
+
+type fakeUsers struct {
+	mu      sync.Mutex
+	upserts []store.UpsertUserParams
+}
+
+func (u *fakeUsers) UpsertUser(ctx context.Context, arg store.UpsertUserParams) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.upserts = append(u.upserts, arg)
+	return nil
+}
+
+func newTestBot(t *testing.T) (*Bot, *fakeBotAPI, *fakeAgent, *fakeUsers) {
+	t.Helper()
+	api := newFakeBotAPI(t)
+	cfg := &config.TelegramConfig{
+		Enable:       true,
+		Token:        testToken,
+		APIURL:       api.URL,
+		CharacterID:  "1",
+		AllowedUsers: []int64{7, 42},
+		Users:        map[int64]string{42: "huuhoang"},
+		VoiceReplies: true,
+		PollTimeout:  1,
+	}
+	ag := &fakeAgent{}
+	users := &fakeUsers{}
+	encode := func(ctx context.Context, audio []byte) ([]byte, error) {
+		return append([]byte("ogg:"), audio...), nil
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	bot := newBot(cfg, NewClient(api.URL, testToken, nil), ag, users, encode, logger)
+	return bot, api, ag, users
+}
+
+func TestTextMessageThroughPolling(t *testing.T) {
+	bot, api, ag, users := newTestBot(t)
+	api.updates = []Update{{
+		UpdateID: 10,
+		Message: &Message{
+			MessageID: 5,
+			From:      &User{ID: 7, FirstName: "Takane", LastName: "Enomoto"},
+			Chat:      Chat{ID: 700, Type: "private"},
+			Text:      "ê Ene",
+		},
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := bot.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	api.waitSent(t, 2)
+
+	input := ag.lastInput()
+	if input.Text != "ê Ene" || input.ConversationID != "telegram:700" || input.UserID != "telegram:7" {
+		t.Errorf("unexpected input %+v", input)
+	}
+	if len(users.upserts) != 1 || *users.upserts[0].Name != "Takane Enomoto" {
+		t.Errorf("upserts = %+v", users.upserts)
+	}
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if len(ag.inputs) != 1 {
+		t.Errorf("update handled %d times, offset not advanced", len(ag.inputs))
+	}
+	want := sentMessage{ChatID: 700, Text: "Chào bạn. Hôm nay vui ghê!", ReplyTo: 5}
+	if len(api.messages) != 1 || api.messages[0] != want {
+		t.Errorf("messages = %+v", api.messages)
+	}
+	if len(api.voices) != 1 || string(api.voices[0].Voice) != "ogg:mp3:Chào bạn. Hôm nay vui ghê!" || api.voices[0].ChatID != 700 {
+		t.Errorf("voices = %+v", api.voices)
+	}
+	if len(api.actions) != 2 || api.actions[0] != "typing" || api.actions[1] != "record_voice" {
+		t.Errorf("actions = %v", api.actions)
+	}
+}
+
+func TestVoiceNoteGoesToASR(t *testing.T) {
+	bot, api, ag, users := newTestBot(t)
+	api.files["voice/abc.oga"] = []byte("OggS voice")
+
+	bot.handleMessage(context.Background(), &Message{
+		MessageID: 6,
+		From:      &User{ID: 42, FirstName: "Hoàng"},
+		Chat:      Chat{ID: 420, Type: "private"},
+		Voice:     &Voice{FileID: "abc", Duration: 2, MimeType: "audio/ogg"},
+	})
+
+	input := ag.lastInput()
+	if input == nil {
+		t.Fatal("agent was not called")
+	}
+	if string(input.Audio) != "OggS voice" || input.Text != "" {
+		t.Errorf("audio = %q, text = %q", input.Audio, input.Text)
+	}
+	if input.UserID != "huuhoang" || input.ConversationID != "telegram:420" {
+		t.Errorf("unexpected input %+v", input)
+	}
+	if len(users.upserts) != 0 {
+		t.Errorf("mapped users must not be upserted, got %+v", users.upserts)
+	}
+	if len(api.voices) != 1 {
+		t.Errorf("expected a voice reply, got %d", len(api.voices))
+	}
+}
+
+func TestUserNotAllowed(t *testing.T) {
+	bot, api, ag, _ := newTestBot(t)
+	bot.handleMessage(context.Background(), &Message{
+		MessageID: 1,
+		From:      &User{ID: 666, FirstName: "Stranger"},
+		Chat:      Chat{ID: 666, Type: "private"},
+		Text:      "hello",
+	})
+	if ag.lastInput() != nil || len(api.messages) != 0 {
+		t.Error("messages from users outside the allow-list must be ignored")
+	}
+}
+
+func TestVoiceRepliesDisabled(t *testing.T) {
+	bot, api, ag, _ := newTestBot(t)
+	bot.cfg.VoiceReplies = false
+	bot.handleMessage(context.Background(), &Message{
+		MessageID: 1,
+		From:      &User{ID: 7, FirstName: "Takane"},
+		Chat:      Chat{ID: 700, Type: "private"},
+		Text:      "hello",
+	})
+	if len(api.messages) != 1 || len(api.voices) != 0 {
+		t.Errorf("messages = %d voices = %d", len(api.messages), len(api.voices))
+	}
+	if len(api.actions) != 1 || api.actions[0] != "typing" {
+		t.Errorf("actions = %v", api.actions)
+	}
+	if len(ag.spoken) != 0 {
+		t.Errorf("nothing must be synthesized, got %q", ag.spoken)
+	}
+}
+
+func TestTurnFailureRepliesWithError(t *testing.T) {
+	bot, api, ag, _ := newTestBot(t)
+	ag.err = errors.New("all fallbacks failed")
+	bot.handleMessage(context.Background(), &Message{
+		MessageID: 3,
+		From:      &User{ID: 7, FirstName: "Takane"},
+		Chat:      Chat{ID: 700, Type: "private"},
+		Text:      "hello",
+	})
+	want := sentMessage{ChatID: 700, Text: errorReply, ReplyTo: 3}
+	if len(api.messages) != 1 || api.messages[0] != want {
+		t.Errorf("messages = %+v", api.messages)
+	}
+	if len(api.voices) != 0 || len(ag.spoken) != 0 {
+		t.Errorf("a failed turn must not be voiced, voices = %d spoken = %q", len(api.voices), ag.spoken)
+	}
+}
+
+func TestStartCommand(t *testing.T) {
+	bot, api, ag, _ := newTestBot(t)
+	bot.handleMessage(context.Background(), &Message{
+		MessageID: 1,
+		From:      &User{ID: 7},
+		Chat:      Chat{ID: 700, Type: "private"},
+		Text:      "/start",
+	})
+	if ag.lastInput() != nil {
+		t.Error("commands must not reach the agent")
+	}
+	if len(api.messages) != 1 {
+		t.Errorf("expected a greeting, got %+v", api.messages)
+	}
+}
+
+func TestClientAPIError(t *testing.T) {
+	api := newFakeBotAPI(t)
+	client := NewClient(api.URL, "wrong-token", nil)
+	_, err := client.GetMe(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 401 {
+		t.Fatalf("expected a 401 APIError, got %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentation = "github.com/Mirai3103/Project-Re-ENE"
+
+// Kinds of traced calls, the kind attribute of spans and metrics.
+const (
+	KindLLM       = "llm"
+	KindTTS       = "tts"
+	KindASR       = "asr"
+	KindEmbedding = "embedding"
+	KindTool      = "tool"
+)
+
+// The global meter hands out instruments that follow the provider set later
+// by New.
+var (
+	meter = otel.Meter(instrumentation)
+
+	timeToFirstToken = histogram("ene.llm.time_to_first_token", "Time from an LLM request to its first streamed chunk")
+	timeToFirstAudio = histogram("ene.turn.time_to_first_audio", "Time from a voice request to its first spoken sentence")
+	callDuration     = histogram("ene.call.duration", "Duration of a call to a provider or tool")
+	tokens           = counter("ene.llm.tokens", "{token}", "Tokens used by LLM calls, by direction")
+)
+
+// seconds fits a voice turn, where anything past a few seconds is felt.
+var seconds = []float64{0.05, 0.1, 0.25, 0.5, 0.75, 1, 1.5, 2, 3, 5, 7.5, 10, 20, 40}
+
+func histogram(name, description string) metric.Float64Histogram {
+	h, err := meter.Float64Histogram(name,
+		metric.WithUnit("s"),
+		metric.WithDescription(description),
+		metric.WithExplicitBucketBoundaries(seconds...))
+	if err != nil {
+		otel.Handle(err)
+	}
+	return h
+}
+
+func counter(name, unit, description string) metric.Int64Counter {
+	c, err := meter.Int64Counter(name, metric.WithUnit(unit), metric.WithDescription(description))
+	if err != nil {
+		otel.Handle(err)
+	}
+	return c
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentation)
+}
+
+// Start opens a span below the one in ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End closes span, marking it failed when err is set.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Call traces one request to a provider or tool and times it.
+type Call struct {
+	ctx        context.Context
+	span       trace.Span
+	start      time.Time
+	attrs      []attribute.KeyValue
+	firstToken sync.Once
+}
+
+// StartCall opens the span of a call, named after its kind and model.
+func StartCall(ctx context.Context, kind, provider, model string) (context.Context, *Call) {
+	attrs := []attribute.KeyValue{
+		attribute.String("ene.kind", kind),
+		attribute.String("ene.provider", provider),
+		attribute.String("ene.model", model),
+	}
+	ctx, span := tracer().Start(ctx, kind+" "+model,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+	return ctx, &Call{ctx: ctx, span: span, start: time.Now(), attrs: attrs}
+}
+
+// FirstToken records the time to first token on the first streamed chunk,
+// later chunks change nothing.
+func (c *Call) FirstToken() {
+	c.firstToken.Do(func() {
+		c.span.AddEvent("first_token")
+		timeToFirstToken.Record(c.ctx, time.Since(c.start).Seconds(), metric.WithAttributes(c.attrs...))
+	})
+}
+
+// Tokens adds the token usage of an LLM reply.
+func (c *Call) Tokens(input, output int) {
+	c.span.SetAttributes(
+		attribute.Int("gen_ai.usage.input_tokens", input),
+		attribute.Int("gen_ai.usage.output_tokens", output))
+	tokens.Add(c.ctx, int64(input), metric.WithAttributes(append(c.attrs, attribute.String("direction", "input"))...))
+	tokens.Add(c.ctx, int64(output), metric.WithAttributes(append(c.attrs, attribute.String("direction", "output"))...))
+}
+
+// SetAttributes adds attributes to the span of the call.
+func (c *Call) SetAttributes(attrs ...attribute.KeyValue) {
+	c.span.SetAttributes(attrs...)
+}
+
+// End closes the call and records its duration.
+func (c *Call) End(err error) {
+	attrs := append(c.attrs, attribute.Bool("error", err != nil))
+	callDuration.Record(c.ctx, time.Since(c.start).Seconds(), metric.WithAttributes(attrs...))
+	End(c.span, err)
+}
+
+type turnKey struct{}
+
+type turn struct {
+	span       trace.Span
+	start      time.Time
+	firstAudio sync.Once
+}
+
+// StartTurn opens the span of one user turn, the parent of every call it
+// makes, and starts the clock of its time to first audio.
+func StartTurn(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx, span := Start(ctx, name, attrs...)
+	return context.WithValue(ctx, turnKey{}, &turn{span: span, start: time.Now()}), span
+}
+
+// FirstAudio records the time to first audio of the turn in ctx when its
+// first sentence is handed over for playback. Only the first call counts.
+func FirstAudio(ctx context.Context) {
+	t, ok := ctx.Value(turnKey{}).(*turn)
+	if !ok {
+		return
+	}
+	t.firstAudio.Do(func() {
+		t.span.AddEvent("first_audio")
+		timeToFirstAudio.Record(ctx, time.Since(t.start).Seconds())
+	})
+}
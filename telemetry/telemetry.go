@@ -0,0 +1,121 @@
+// Package telemetry exports OpenTelemetry traces and metrics of the voice
+// turns: a span per turn with the ASR, LLM, TTS and tool calls below it, and
+// the time to first token and to first audio as metrics. Genkit traces its
+// flows and generations on the same global provider, so they join the turn.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Mirai3103/Project-Re-ENE/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Telemetry owns the exporting providers. Without it, or when disabled,
+// spans and metrics go nowhere.
+type Telemetry struct {
+	shutdown []func(context.Context) error
+}
+
+// New installs the tracer and meter providers of cfg as the global ones.
+func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Telemetry, error) {
+	c := &cfg.TelemetryConfig
+	t := &Telemetry{}
+	if !c.Enable {
+		return t, nil
+	}
+	res := resource.NewSchemaless(attribute.String("service.name", c.ServiceName))
+
+	var spans sdktrace.SpanExporter
+	var metrics sdkmetric.Exporter
+	switch c.Exporter {
+	case "otlp":
+		traceOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(c.Endpoint)}
+		metricOpts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(c.Endpoint)}
+		if c.Insecure {
+			traceOpts = append(traceOpts, otlptracehttp.WithInsecure())
+			metricOpts = append(metricOpts, otlpmetrichttp.WithInsecure())
+		}
+		var err error
+		if spans, err = otlptracehttp.New(ctx, traceOpts...); err != nil {
+			return nil, fmt.Errorf("otlp trace exporter: %w", err)
+		}
+		if metrics, err = otlpmetrichttp.New(ctx, metricOpts...); err != nil {
+			return nil, fmt.Errorf("otlp metric exporter: %w", err)
+		}
+	case "file":
+		traceFile, err := openAppend(filepath.Join(c.Directory, "traces.jsonl"))
+		if err != nil {
+			return nil, err
+		}
+		metricFile, err := openAppend(filepath.Join(c.Directory, "metrics.jsonl"))
+		if err != nil {
+			traceFile.Close()
+			return nil, err
+		}
+		t.shutdown = append(t.shutdown, closer(traceFile), closer(metricFile))
+		if spans, err = stdouttrace.New(stdouttrace.WithWriter(traceFile)); err != nil {
+			return nil, err
+		}
+		if metrics, err = stdoutmetric.New(stdoutmetric.WithWriter(metricFile)); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("telemetry exporter not found: %s", c.Exporter)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spans),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.SampleRatio))),
+	)
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metrics,
+			sdkmetric.WithInterval(time.Duration(c.MetricsInterval)*time.Second))),
+		sdkmetric.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetMeterProvider(mp)
+	// the providers flush before the files they write to are closed
+	t.shutdown = append([]func(context.Context) error{tp.Shutdown, mp.Shutdown}, t.shutdown...)
+	logger.Info("Telemetry enabled", "exporter", c.Exporter, "sample_ratio", c.SampleRatio)
+	return t, nil
+}
+
+// Shutdown flushes what is left to export.
+func (t *Telemetry) Shutdown(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	var errs []error
+	for _, fn := range t.shutdown {
+		errs = append(errs, fn(ctx))
+	}
+	t.shutdown = nil
+	return errors.Join(errs...)
+}
+
+func openAppend(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+}
+
+func closer(f *os.File) func(context.Context) error {
+	return func(context.Context) error { return f.Close() }
+}
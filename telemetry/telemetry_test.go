@@ -0,0 +1,80 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mirai3103/Project-Re-ENE/config"
+)
+
+func TestFileExport(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{TelemetryConfig: config.TelemetryConfig{
+		Enable: true, Exporter: "file", Directory: dir, ServiceName: "ene-test", SampleRatio: 1, MetricsInterval: 60,
+	}}
+	tel, err := New(context.Background(), cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, turn := StartTurn(context.Background(), "voice.turn")
+	llmCtx, llm := StartCall(ctx, KindLLM, "gemini", "gemini-2.5-flash")
+	llm.FirstToken()
+	llm.FirstToken()
+	llm.Tokens(120, 30)
+	_, tool := StartCall(llmCtx, KindTool, "mcp", "search")
+	tool.End(errors.New("server gone"))
+	llm.End(nil)
+	FirstAudio(ctx)
+	turn.End()
+
+	if err := tel.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	traces := readFile(t, filepath.Join(dir, "traces.jsonl"))
+	for _, want := range []string{`"Name":"voice.turn"`, `"Name":"llm gemini-2.5-flash"`, `"Name":"tool search"`, `"first_token"`, `"first_audio"`, `"server gone"`, `"ene-test"`} {
+		if !strings.Contains(traces, want) {
+			t.Errorf("traces miss %s", want)
+		}
+	}
+	if strings.Count(traces, `"first_token"`) != 1 {
+		t.Error("first token recorded twice")
+	}
+	metrics := readFile(t, filepath.Join(dir, "metrics.jsonl"))
+	for _, want := range []string{"ene.llm.time_to_first_token", "ene.turn.time_to_first_audio", "ene.call.duration", "ene.llm.tokens"} {
+		if !strings.Contains(metrics, want) {
+			t.Errorf("metrics miss %s", want)
+		}
+	}
+}
+
+func TestDisabledTelemetry(t *testing.T) {
+	tel, err := New(context.Background(), &config.Config{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// without a turn in ctx there is nothing to time
+	FirstAudio(context.Background())
+	if err := tel.Shutdown(context.Background()); err != nil {
+		t.Error(err)
+	}
+	var none *Telemetry
+	if err := none.Shutdown(context.Background()); err != nil {
+		t.Error(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -2,26 +2,34 @@ package tts
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"io"
 	"log/slog"
+	"time"
+	"unicode/utf8"
 
 	ttsConfig "github.com/Mirai3103/Project-Re-ENE/config/tts"
+	"github.com/Mirai3103/Project-Re-ENE/metering"
 	"github.com/Mirai3103/Project-Re-ENE/package/elevenlabs"
 	"github.com/Mirai3103/Project-Re-ENE/package/utils"
+	"github.com/Mirai3103/Project-Re-ENE/telemetry"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type elevenlabsTTSAgent struct {
 	client          *elevenlabs.Client
 	cfg             *ttsConfig.ElevenLabsConfig
 	cachingTTSAgent CachingTTSAgent
+	meter           *metering.Meter
 	logger          *slog.Logger
 }
 
-func newElevenlabsTTSAgent(cfg *ttsConfig.ElevenLabsConfig, cachingTTSAgent CachingTTSAgent, logger *slog.Logger) TTSAgent {
+func newElevenlabsTTSAgent(cfg *ttsConfig.ElevenLabsConfig, cachingTTSAgent CachingTTSAgent, meter *metering.Meter, logger *slog.Logger) TTSAgent {
 	client := elevenlabs.NewClient(elevenlabs.NewClientOptions{
 		APIKey: cfg.APIKey,
 	}, logger)
-	return &elevenlabsTTSAgent{client: client, cfg: cfg, cachingTTSAgent: cachingTTSAgent, logger: logger}
+	return &elevenlabsTTSAgent{client: client, cfg: cfg, cachingTTSAgent: cachingTTSAgent, meter: meter, logger: logger}
 }
 func (a *elevenlabsTTSAgent) GetTTS(ctx context.Context, text string) ([]byte, error) {
 	log := a.logger
@@ -31,6 +39,11 @@ func (a *elevenlabsTTSAgent) GetTTS(ctx context.Context, text string) ([]byte, e
 	if audioBuffer != nil {
 		return audioBuffer, nil
 	}
+	if err := a.meter.Allow(ctx); err != nil {
+		return nil, err
+	}
+	ctx, call := telemetry.StartCall(ctx, telemetry.KindTTS, "elevenlabs", a.cfg.ModelID)
+	start := time.Now()
 	reader, err := a.client.TTS(ctx, elevenlabs.TTSOptions{
 		Text:         text,
 		VoiceID:      a.cfg.VoiceID,
@@ -39,10 +52,13 @@ func (a *elevenlabsTTSAgent) GetTTS(ctx context.Context, text string) ([]byte, e
 		LanguageCode: utils.Ptr("vi"),
 	})
 	if err != nil {
+		a.record(ctx, call, text, start, err)
 		log.Error("Failed to get TTS", "err", err)
 		return nil, err
 	}
+	// the audio streams in with the body, the call lasts until it is read
 	audioBuffer, err = io.ReadAll(reader)
+	a.record(ctx, call, text, start, err)
 	if err != nil {
 		log.Error("Failed to read TTS", "err", err)
 		return nil, err
@@ -51,3 +67,72 @@ func (a *elevenlabsTTSAgent) GetTTS(ctx context.Context, text string) ([]byte, e
 
 	return audioBuffer, nil
 }
+
+type timedSpeech struct {
+	Audio     []byte     `json:"audio"`
+	Alignment *Alignment `json:"alignment"`
+}
+
+func (a *elevenlabsTTSAgent) GetTimedTTS(ctx context.Context, text string) ([]byte, *Alignment, error) {
+	if !a.cfg.Timestamps {
+		audio, err := a.GetTTS(ctx, text)
+		return audio, nil, err
+	}
+	log := a.logger
+	cacheKey := text + a.cfg.VoiceID + a.cfg.ModelID + "|timed"
+	if cached := a.cachingTTSAgent.GetCachedAudioBuffer(cacheKey); cached != nil {
+		var speech timedSpeech
+		if err := json.Unmarshal(cached, &speech); err == nil {
+			return speech.Audio, speech.Alignment, nil
+		}
+	}
+	if err := a.meter.Allow(ctx); err != nil {
+		return nil, nil, err
+	}
+	ctx, call := telemetry.StartCall(ctx, telemetry.KindTTS, "elevenlabs", a.cfg.ModelID)
+	start := time.Now()
+	resp, err := a.client.TTSWithTimestamps(ctx, elevenlabs.TTSOptions{
+		Text:         text,
+		VoiceID:      a.cfg.VoiceID,
+		ModelID:      utils.Ptr(a.cfg.ModelID),
+		OutputFormat: elevenlabs.OutputFormatMP3_44100_128,
+		LanguageCode: utils.Ptr("vi"),
+	})
+	if err != nil {
+		a.record(ctx, call, text, start, err)
+		log.Error("Failed to get timed TTS", "err", err)
+		return nil, nil, err
+	}
+	audio, err := base64.StdEncoding.DecodeString(resp.AudioBase64)
+	a.record(ctx, call, text, start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	var alignment *Alignment
+	if al := resp.Alignment; al != nil {
+		alignment = &Alignment{
+			Characters: al.Characters,
+			Starts:     al.CharacterStartTimesSeconds,
+			Ends:       al.CharacterEndTimesSeconds,
+		}
+	}
+	if data, err := json.Marshal(timedSpeech{Audio: audio, Alignment: alignment}); err == nil {
+		_ = a.cachingTTSAgent.SaveCachedAudioBuffer(cacheKey, data)
+	}
+	return audio, alignment, nil
+}
+
+// record traces and meters a synthesis, cache hits are free and not recorded.
+func (a *elevenlabsTTSAgent) record(ctx context.Context, call *telemetry.Call, text string, start time.Time, err error) {
+	characters := utf8.RuneCountInString(text)
+	call.SetAttributes(attribute.Int("ene.characters", characters))
+	call.End(err)
+	a.meter.Record(ctx, metering.Event{
+		Kind:       metering.KindTTS,
+		Provider:   "elevenlabs",
+		Model:      a.cfg.ModelID,
+		Characters: characters,
+		Latency:    time.Since(start),
+		Err:        err,
+	})
+}
@@ -6,23 +6,37 @@ import (
 	"log/slog"
 
 	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/metering"
 )
 
 type TTSAgent interface {
 	GetTTS(ctx context.Context, text string) ([]byte, error)
 }
 
+// Alignment is when each character of the text is spoken, in seconds.
+type Alignment struct {
+	Characters []string  `json:"characters"`
+	Starts     []float64 `json:"starts"`
+	Ends       []float64 `json:"ends"`
+}
+
+// TimedTTSAgent is implemented by providers that can report character
+// timings. The alignment is nil when the provider did not return one.
+type TimedTTSAgent interface {
+	GetTimedTTS(ctx context.Context, text string) ([]byte, *Alignment, error)
+}
+
 type ttsProvider struct {
 	cfg             *config.Config
 	cachingTTSAgent CachingTTSAgent
 	logger          *slog.Logger
 }
 
-func New(cfg *config.Config, logger *slog.Logger) (TTSAgent, error) {
+func New(cfg *config.Config, meter *metering.Meter, logger *slog.Logger) (TTSAgent, error) {
 	cachingTTSAgent := NewHashCachingTTSAgent(".cache/tts")
 	switch cfg.TTSConfig.Provider {
 	case "elevenlabs":
-		return newElevenlabsTTSAgent(cfg.TTSConfig.ElevenLabsConfig, cachingTTSAgent, logger), nil
+		return newElevenlabsTTSAgent(cfg.TTSConfig.ElevenLabsConfig, cachingTTSAgent, meter, logger), nil
 	default:
 		return nil, fmt.Errorf("tts provider not found")
 	}
@@ -4,31 +4,22 @@ package main
 
 import (
 	"context"
-	"database/sql"
 	"log/slog"
-	"os"
 
 	"github.com/Mirai3103/Project-Re-ENE/agent"
-	"github.com/Mirai3103/Project-Re-ENE/asr"
+	"github.com/Mirai3103/Project-Re-ENE/api"
 	"github.com/Mirai3103/Project-Re-ENE/config"
-	"github.com/Mirai3103/Project-Re-ENE/embedding"
-	"github.com/Mirai3103/Project-Re-ENE/llm"
+	"github.com/Mirai3103/Project-Re-ENE/discord"
+	"github.com/Mirai3103/Project-Re-ENE/logging"
+	"github.com/Mirai3103/Project-Re-ENE/mcpserver"
 	"github.com/Mirai3103/Project-Re-ENE/package/audio"
+	"github.com/Mirai3103/Project-Re-ENE/providers"
 	"github.com/Mirai3103/Project-Re-ENE/services"
-	"github.com/Mirai3103/Project-Re-ENE/store"
-	"github.com/Mirai3103/Project-Re-ENE/tts"
-	"github.com/firebase/genkit/go/ai"
-	"github.com/firebase/genkit/go/genkit"
+	"github.com/Mirai3103/Project-Re-ENE/telegram"
+	"github.com/Mirai3103/Project-Re-ENE/telemetry"
 	"github.com/google/wire"
-	"github.com/lmittmann/tint"
 )
 
-// ProvideLogger creates a new logger instance
-func ProvideLogger() *slog.Logger {
-	w := os.Stderr
-	return slog.New(tint.NewHandler(w, nil))
-}
-
 // ProvideAudioRecorder creates a new audio recorder
 func ProvideAudioRecorder(cfg *config.Config) (audio.Recorder, error) {
 	return audio.NewFFmpegRecorder(audio.RecorderConfig{
@@ -38,23 +29,6 @@ func ProvideAudioRecorder(cfg *config.Config) (audio.Recorder, error) {
 	})
 }
 
-// ProvideLLMModel wraps the llm.New function
-func ProvideLLMModel(ctx context.Context, cfg *config.Config) (*genkit.Genkit, error) {
-	model, _, err := llm.New(ctx, cfg)
-	return model, err
-}
-
-// ProvideLLMModelArg provides the model argument
-func ProvideLLMModelArg(ctx context.Context, cfg *config.Config) (ai.ModelArg, error) {
-	_, modelArg, err := llm.New(ctx, cfg)
-	return modelArg, err
-}
-
-// ProvideAgentConfig extracts agent config from main config
-func ProvideAgentConfig(cfg *config.Config) *config.AgentConfig {
-	return &cfg.AgentConfig
-}
-
 // Application holds all initialized services
 type Application struct {
 	AppService       *services.AppService
@@ -62,41 +36,48 @@ type Application struct {
 	RecorderService  *services.RecorderService
 	ConfigService    *services.ConfigService
 	ChatService      *services.ChatService
+	ToolService      *services.ToolService
+	MCPService       *services.MCPService
+	UsageService     *services.UsageService
+	LogService       *services.LogService
 	Agent            *agent.Agent
 	EmbeddingService *agent.EmbeddingService
+	MCPServer        *mcpserver.Server
+	APIServer        *api.Server
+	DiscordBot       *discord.Bot
+	TelegramBot      *telegram.Bot
+	Telemetry        *telemetry.Telemetry
+	Logger           *slog.Logger
 }
 
-// InitializeApplication wires up all dependencies
-func InitializeApplication(ctx context.Context, cfg *config.Config) (*Application, error) {
+// InitializeApplication wires up all dependencies. The cleanup function
+// closes the log file, call it once everything else has shut down.
+func InitializeApplication(ctx context.Context, cfg *config.Config) (*Application, func(), error) {
 	wire.Build(
 		// Infrastructure
-		store.NewSQLiteDB,
-		ProvideLogger,
+		providers.CoreSet,
+		logging.New,
+		providers.ProvideLogger,
 		ProvideAudioRecorder,
 
-		// Store components
-		wire.Bind(new(store.DBTX), new(*sql.DB)),
-		store.New,
-		// Config
-		ProvideAgentConfig,
-
-		// Agents and Models
-		asr.New,
-		tts.New,
-		ProvideLLMModel,
-		ProvideLLMModelArg,
-		agent.NewAgent,
-
 		// Services
 		services.NewAppService,
 		services.NewModelService,
 		services.NewRecorderService,
 		services.NewConfigService,
+		providers.NewReloader,
 		services.NewChatService,
-		agent.NewEmbeddingService,
-		embedding.New,
+		services.NewToolService,
+		services.NewMCPService,
+		services.NewUsageService,
+		services.NewLogService,
+		wire.Bind(new(agent.ToolConfirmer), new(*services.ToolService)),
+		mcpserver.New,
+		api.New,
+		discord.New,
+		telegram.New,
 		// Application
 		wire.Struct(new(Application), "*"),
 	)
-	return nil, nil
+	return nil, nil, nil
 }
@@ -9,19 +9,24 @@ package main
 import (
 	"context"
 	"github.com/Mirai3103/Project-Re-ENE/agent"
+	"github.com/Mirai3103/Project-Re-ENE/api"
 	"github.com/Mirai3103/Project-Re-ENE/asr"
 	"github.com/Mirai3103/Project-Re-ENE/config"
+	"github.com/Mirai3103/Project-Re-ENE/discord"
 	"github.com/Mirai3103/Project-Re-ENE/embedding"
+	"github.com/Mirai3103/Project-Re-ENE/live2d"
 	"github.com/Mirai3103/Project-Re-ENE/llm"
+	"github.com/Mirai3103/Project-Re-ENE/logging"
+	"github.com/Mirai3103/Project-Re-ENE/mcpserver"
+	"github.com/Mirai3103/Project-Re-ENE/metering"
 	"github.com/Mirai3103/Project-Re-ENE/package/audio"
+	"github.com/Mirai3103/Project-Re-ENE/providers"
 	"github.com/Mirai3103/Project-Re-ENE/services"
 	"github.com/Mirai3103/Project-Re-ENE/store"
+	"github.com/Mirai3103/Project-Re-ENE/telegram"
+	"github.com/Mirai3103/Project-Re-ENE/telemetry"
 	"github.com/Mirai3103/Project-Re-ENE/tts"
-	"github.com/firebase/genkit/go/ai"
-	"github.com/firebase/genkit/go/genkit"
-	"github.com/lmittmann/tint"
 	"log/slog"
-	"os"
 )
 
 import (
@@ -30,66 +35,96 @@ import (
 
 // Injectors from wire.go:
 
-// InitializeApplication wires up all dependencies
-func InitializeApplication(ctx context.Context, cfg *config.Config) (*Application, error) {
-	logger := ProvideLogger()
-	recorder, err := ProvideAudioRecorder(cfg)
+// InitializeApplication wires up all dependencies. The cleanup function
+// closes the log file, call it once everything else has shut down.
+func InitializeApplication(ctx context.Context, cfg *config.Config) (*Application, func(), error) {
+	loggingLogging, cleanup, err := logging.New(cfg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	genkit, err := ProvideLLMModel(ctx, cfg)
+	logger := providers.ProvideLogger(loggingLogging)
+	recorder, err := ProvideAudioRecorder(cfg)
 	if err != nil {
-		return nil, err
+		cleanup()
+		return nil, nil, err
 	}
-	modelArg, err := ProvideLLMModelArg(ctx, cfg)
+	live := config.NewLive(cfg)
+	db, err := store.NewSQLiteDB()
 	if err != nil {
-		return nil, err
+		cleanup()
+		return nil, nil, err
 	}
-	model, err := embedding.New(ctx, cfg)
+	queries := store.New(db)
+	meter := metering.New(live, queries, logger)
+	models, err := llm.NewModels(ctx, cfg, meter, logger)
 	if err != nil {
-		return nil, err
+		cleanup()
+		return nil, nil, err
 	}
-	db, err := store.NewSQLiteDB()
+	model, err := embedding.New(ctx, cfg, meter)
 	if err != nil {
-		return nil, err
+		cleanup()
+		return nil, nil, err
 	}
-	queries := store.New(db)
 	embeddingService := agent.NewEmbeddingService(cfg, logger, model, queries)
-	ttsAgent, err := tts.New(cfg, logger)
+	ttsAgent, err := tts.New(cfg, meter, logger)
 	if err != nil {
-		return nil, err
+		cleanup()
+		return nil, nil, err
 	}
-	asrAgent, err := asr.New(cfg, logger)
+	asrAgent, err := asr.New(cfg, meter, logger)
 	if err != nil {
-		return nil, err
+		cleanup()
+		return nil, nil, err
 	}
-	agentConfig := ProvideAgentConfig(cfg)
-	agentAgent := agent.NewAgent(genkit, modelArg, embeddingService, ttsAgent, asrAgent, queries, agentConfig, logger)
+	toolService := services.NewToolService(logger, queries)
+	mcpManager := agent.NewMCPManager(live, logger)
+	mapper := live2d.NewMapper(live, logger)
+	agentAgent := agent.NewAgent(models, embeddingService, ttsAgent, asrAgent, queries, live, toolService, mcpManager, mapper, logger)
 	appService := services.NewAppService(cfg, logger, recorder, agentAgent)
-	modelService := services.NewModelService(cfg, logger)
+	reloader := providers.NewReloader(live, agentAgent, embeddingService, meter, logger)
+	modelService := services.NewModelService(live, mapper, reloader, logger)
 	recorderService := services.NewRecorderService(cfg, recorder)
-	configService := services.NewConfigService(cfg, logger)
+	configService := services.NewConfigService(live, reloader, logger)
 	chatService := services.NewChatService(cfg, logger, queries)
+	mcpService := services.NewMCPService(mcpManager, logger)
+	usageService := services.NewUsageService(meter, logger)
+	logService := services.NewLogService(loggingLogging)
+	server := mcpserver.New(cfg, agentAgent, embeddingService, queries, logger)
+	apiServer := api.New(cfg, agentAgent, embeddingService, queries, logger)
+	bot := discord.New(cfg, agentAgent, queries, logger)
+	telegramBot := telegram.New(cfg, agentAgent, queries, logger)
+	telemetryTelemetry, err := telemetry.New(ctx, cfg, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
 	application := &Application{
 		AppService:       appService,
 		ModelService:     modelService,
 		RecorderService:  recorderService,
 		ConfigService:    configService,
 		ChatService:      chatService,
+		ToolService:      toolService,
+		MCPService:       mcpService,
+		UsageService:     usageService,
+		LogService:       logService,
 		Agent:            agentAgent,
 		EmbeddingService: embeddingService,
+		MCPServer:        server,
+		APIServer:        apiServer,
+		DiscordBot:       bot,
+		TelegramBot:      telegramBot,
+		Telemetry:        telemetryTelemetry,
+		Logger:           logger,
 	}
-	return application, nil
+	return application, func() {
+		cleanup()
+	}, nil
 }
 
 // wire.go:
 
-// ProvideLogger creates a new logger instance
-func ProvideLogger() *slog.Logger {
-	w := os.Stderr
-	return slog.New(tint.NewHandler(w, nil))
-}
-
 // ProvideAudioRecorder creates a new audio recorder
 func ProvideAudioRecorder(cfg *config.Config) (audio.Recorder, error) {
 	return audio.NewFFmpegRecorder(audio.RecorderConfig{
@@ -99,23 +134,6 @@ func ProvideAudioRecorder(cfg *config.Config) (audio.Recorder, error) {
 	})
 }
 
-// ProvideLLMModel wraps the llm.New function
-func ProvideLLMModel(ctx context.Context, cfg *config.Config) (*genkit.Genkit, error) {
-	model, _, err := llm.New(ctx, cfg)
-	return model, err
-}
-
-// ProvideLLMModelArg provides the model argument
-func ProvideLLMModelArg(ctx context.Context, cfg *config.Config) (ai.ModelArg, error) {
-	_, modelArg, err := llm.New(ctx, cfg)
-	return modelArg, err
-}
-
-// ProvideAgentConfig extracts agent config from main config
-func ProvideAgentConfig(cfg *config.Config) *config.AgentConfig {
-	return &cfg.AgentConfig
-}
-
 // Application holds all initialized services
 type Application struct {
 	AppService       *services.AppService
@@ -123,6 +141,16 @@ type Application struct {
 	RecorderService  *services.RecorderService
 	ConfigService    *services.ConfigService
 	ChatService      *services.ChatService
+	ToolService      *services.ToolService
+	MCPService       *services.MCPService
+	UsageService     *services.UsageService
+	LogService       *services.LogService
 	Agent            *agent.Agent
 	EmbeddingService *agent.EmbeddingService
+	MCPServer        *mcpserver.Server
+	APIServer        *api.Server
+	DiscordBot       *discord.Bot
+	TelegramBot      *telegram.Bot
+	Telemetry        *telemetry.Telemetry
+	Logger           *slog.Logger
 }